@@ -9,13 +9,23 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig
-	Logger   Logger
-	Postgres PostgresConfig
-	Redis    RedisConfig
-	Http     Http
-	Cookie   Cookie
-	Session  Session
+	Server        ServerConfig
+	Logger        Logger
+	Postgres      PostgresConfig
+	Redis         RedisConfig
+	Http          Http
+	Cookie        Cookie
+	Session       Session
+	Catalog       Catalog
+	Loan          Loan
+	Fine          Fine
+	Hold          Hold
+	Grpc          Grpc
+	Jwt           Jwt
+	Mailer        Mailer
+	Signup        Signup
+	PasswordReset PasswordReset
+	Lockout       Lockout
 }
 
 type ServerConfig struct {
@@ -46,6 +56,7 @@ type Http struct {
 	HttpClientDebug     bool
 	DebugErrorsResponse bool
 	IgnoreLogUrls       []string
+	TrustedProxies      []string
 }
 
 type Logger struct {
@@ -86,9 +97,94 @@ type Cookie struct {
 }
 
 type Session struct {
-	Prefix string
-	Name   string
-	Expire int
+	Prefix       string
+	Name         string
+	CacheSeconds int
+}
+
+type Catalog struct {
+	Provider              string
+	OpenLibraryURL        string
+	FixturePath           string
+	CacheDuration         int
+	NotFoundCacheDuration int
+}
+
+type Loan struct {
+	PeriodDays       int
+	MaxRenewals      int
+	RenewalGraceDays int
+}
+
+type Fine struct {
+	Currency      string
+	OverduePerDay int64
+	OverdueCap    int64
+	LostCharge    int64
+}
+
+type Hold struct {
+	ReadyHours           int
+	SweepIntervalSeconds int
+}
+
+type Grpc struct {
+	PublicMethods []string
+	MethodRoles   []MethodRoles
+}
+
+type MethodRoles struct {
+	Method string
+	Roles  []string
+}
+
+type Jwt struct {
+	Keys               []JwtKey
+	Issuer             string
+	Audience           string
+	AccessTokenExpire  int
+	RefreshTokenExpire int
+	ClockSkew          int
+}
+
+type JwtKey struct {
+	ID             string
+	Algorithm      string
+	PrivateKeyFile string
+	PrivateKey     string
+	NotBefore      string
+	NotAfter       string
+}
+
+type Mailer struct {
+	Provider     string
+	From         string
+	SmtpHost     string
+	SmtpPort     int
+	SmtpUsername string
+	SmtpPassword string
+	OutboxPath   string
+}
+
+type Signup struct {
+	VerifyURL          string
+	VerificationExpire int
+}
+
+type PasswordReset struct {
+	UserResetURL      string
+	LibrarianResetURL string
+	Expire            int
+}
+
+type Lockout struct {
+	FreeAttempts      int
+	MaxAttempts       int
+	IPMaxAttempts     int
+	BackoffSeconds    int
+	MaxBackoffSeconds int
+	WindowSeconds     int
+	LockSeconds       int
 }
 
 // LoadConfig Load config file from given path
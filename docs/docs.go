@@ -62,14 +62,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/librarian": {
+        "/fine": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Admin find all librarians",
+                "description": "Users find their own fines, staff find all fines or those of one user",
                 "consumes": [
                     "application/json"
                 ],
@@ -77,10 +77,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Librarians"
+                    "Fines"
                 ],
-                "summary": "Find all librarians",
+                "summary": "Find all fines",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "user id, staff only",
+                        "name": "user_id",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "pagination size",
@@ -98,18 +104,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.LibrarianFindResponseDto"
+                            "$ref": "#/definitions/dto.FineFindResponseDto"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/fine/balance": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Admin create librarian",
+                "description": "Users get their own outstanding balance, staff must pass user_id",
                 "consumes": [
                     "application/json"
                 ],
@@ -117,33 +125,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Librarians"
+                    "Fines"
                 ],
-                "summary": "To register librarian",
+                "summary": "Outstanding balance",
                 "parameters": [
                     {
-                        "description": "Payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.LibrarianRegisterRequestDto"
-                        }
+                        "type": "string",
+                        "description": "user id, staff only",
+                        "name": "user_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.LibrarianRegisterResponseDto"
+                            "$ref": "#/definitions/dto.FineBalanceResponseDto"
                         }
                     }
                 }
             }
         },
-        "/librarian/login": {
+        "/fine/{id}/pay": {
             "post": {
-                "description": "Librarian login with email and password",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Librarian record full payment of outstanding fine",
                 "consumes": [
                     "application/json"
                 ],
@@ -151,17 +161,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Librarians"
+                    "Fines"
                 ],
-                "summary": "Librarian login",
+                "summary": "Record payment",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "description": "Payload",
                         "name": "payload",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.LibrarianLoginRequestDto"
+                            "$ref": "#/definitions/dto.FinePayRequestDto"
                         }
                     }
                 ],
@@ -169,68 +185,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.LibrarianLoginResponseDto"
+                            "$ref": "#/definitions/dto.FineResponseDto"
                         }
                     }
                 }
             }
         },
-        "/librarian/logout": {
+        "/fine/{id}/waive": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete current session",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Librarians"
-                ],
-                "summary": "Librarian logout",
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/librarian/me": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Get session id from token, find librarian by uuid and returns it",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Librarians"
-                ],
-                "summary": "Find me",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.LibrarianResponseDto"
-                        }
-                    }
-                }
-            }
-        },
-        "/librarian/refresh": {
-            "post": {
-                "description": "Refresh access token",
+                "description": "Librarian waive outstanding fine, a note is required",
                 "consumes": [
                     "application/json"
                 ],
@@ -238,17 +206,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Librarians"
+                    "Fines"
                 ],
-                "summary": "Refresh access token",
+                "summary": "Waive fine",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Fine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "description": "Payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.LibrarianRefreshTokenDto"
+                            "$ref": "#/definitions/dto.FineWaiveRequestDto"
                         }
                     }
                 ],
@@ -256,20 +231,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.LibrarianRefreshTokenResponseDto"
+                            "$ref": "#/definitions/dto.FineResponseDto"
                         }
                     }
                 }
             }
         },
-        "/librarian/{id}": {
+        "/hold": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Find existing librarian by id",
+                "description": "Users find their own holds, staff find all holds or the queue of one book",
                 "consumes": [
                     "application/json"
                 ],
@@ -277,25 +252,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Librarians"
+                    "Holds"
+                ],
+                "summary": "Find all holds",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "book key, staff only",
+                        "name": "key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pagination size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pagination page",
+                        "name": "page",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Find librarian",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.LibrarianResponseDto"
+                            "$ref": "#/definitions/dto.HoldFindResponseDto"
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update existing librarian",
+                "description": "User join the queue of a book with no copy available",
                 "consumes": [
                     "application/json"
                 ],
@@ -303,43 +298,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Librarians"
+                    "Holds"
                 ],
-                "summary": "Update librarian",
+                "summary": "Place hold",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Librarian ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "Payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.LibrarianUpdateRequestDto"
+                            "$ref": "#/definitions/dto.HoldCreateRequestDto"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.LibrarianResponseDto"
+                            "$ref": "#/definitions/dto.HoldResponseDto"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/hold/{id}": {
             "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete existing librarian, admin only",
+                "description": "User leave the queue, a copy set aside for the hold goes to the next holder",
                 "consumes": [
                     "application/json"
                 ],
@@ -347,13 +337,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Librarians"
+                    "Holds"
                 ],
-                "summary": "Delete librarian",
+                "summary": "Cancel hold",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Librarian ID",
+                        "description": "Hold ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -361,19 +351,22 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HoldResponseDto"
+                        }
                     }
                 }
             }
         },
-        "/order": {
+        "/inventory": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Find all orders",
+                "description": "Find all copies, optionally of one book",
                 "consumes": [
                     "application/json"
                 ],
@@ -381,10 +374,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Inventory"
                 ],
-                "summary": "Find all orders",
+                "summary": "Find all copies",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "book key",
+                        "name": "key",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "pagination size",
@@ -402,7 +401,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.OrderFindResponseDto"
+                            "$ref": "#/definitions/dto.CopyFindResponseDto"
                         }
                     }
                 }
@@ -413,7 +412,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Admin create order",
+                "description": "Librarian register a physical copy of a book",
                 "consumes": [
                     "application/json"
                 ],
@@ -421,9 +420,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Inventory"
                 ],
-                "summary": "To register order",
+                "summary": "To register copy",
                 "parameters": [
                     {
                         "description": "Payload",
@@ -431,7 +430,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.OrderCreateRequestDto"
+                            "$ref": "#/definitions/dto.CopyCreateRequestDto"
                         }
                     }
                 ],
@@ -439,20 +438,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.OrderCreateResponseDto"
+                            "$ref": "#/definitions/dto.CopyCreateResponseDto"
                         }
                     }
                 }
             }
         },
-        "/order/{id}": {
+        "/inventory/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Find existing order by id",
+                "description": "Find existing copy by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -460,14 +459,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Inventory"
+                ],
+                "summary": "Find copy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Copy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Find order",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.OrderResponseDto"
+                            "$ref": "#/definitions/dto.CopyResponseDto"
                         }
                     }
                 }
@@ -478,7 +486,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Librarian accept order",
+                "description": "Librarian update barcode, condition, location or status of copy. Status moves between available, lost and withdrawn only, copies on loan or on hold follow their order or hold",
                 "consumes": [
                     "application/json"
                 ],
@@ -486,36 +494,77 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Inventory"
                 ],
-                "summary": "Accept order",
+                "summary": "Update copy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
+                        "description": "Copy ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CopyUpdateRequestDto"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.OrderResponseDto"
+                            "$ref": "#/definitions/dto.CopyResponseDto"
                         }
                     }
                 }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Librarian delete existing copy, copies on loan, on hold or referenced by orders or holds answer 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Delete copy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Copy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
             }
         },
-        "/user": {
+        "/librarian": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Admin find all users",
+                "description": "Admin find all librarians",
                 "consumes": [
                     "application/json"
                 ],
@@ -523,9 +572,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Librarians"
                 ],
-                "summary": "Find all users",
+                "summary": "Find all librarians",
                 "parameters": [
                     {
                         "type": "string",
@@ -538,13 +587,19 @@ const docTemplate = `{
                         "description": "pagination page",
                         "name": "page",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, takes precedence over page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.UserFindResponseDto"
+                            "$ref": "#/definitions/dto.LibrarianFindResponseDto"
                         }
                     }
                 }
@@ -555,7 +610,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Admin create user",
+                "description": "Admin create librarian",
                 "consumes": [
                     "application/json"
                 ],
@@ -563,9 +618,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Librarians"
                 ],
-                "summary": "To register user",
+                "summary": "To register librarian",
                 "parameters": [
                     {
                         "description": "Payload",
@@ -573,7 +628,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UserRegisterRequestDto"
+                            "$ref": "#/definitions/dto.LibrarianRegisterRequestDto"
                         }
                     }
                 ],
@@ -581,15 +636,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.UserRegisterResponseDto"
+                            "$ref": "#/definitions/dto.LibrarianRegisterResponseDto"
                         }
                     }
                 }
             }
         },
-        "/user/login": {
+        "/librarian/login": {
             "post": {
-                "description": "User login with email and password",
+                "description": "Librarian login with email and password",
                 "consumes": [
                     "application/json"
                 ],
@@ -597,9 +652,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Librarians"
                 ],
-                "summary": "User login",
+                "summary": "Librarian login",
                 "parameters": [
                     {
                         "description": "Payload",
@@ -607,7 +662,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UserLoginRequestDto"
+                            "$ref": "#/definitions/dto.LibrarianLoginRequestDto"
                         }
                     }
                 ],
@@ -615,13 +670,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.UserLoginResponseDto"
+                            "$ref": "#/definitions/dto.LibrarianLoginResponseDto"
                         }
                     }
                 }
             }
         },
-        "/user/logout": {
+        "/librarian/logout": {
             "post": {
                 "security": [
                     {
@@ -635,25 +690,1271 @@ const docTemplate = `{
                 "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Librarians"
+                ],
+                "summary": "Librarian logout",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/librarian/me": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get librarian id from token, find librarian by uuid and returns it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Librarians"
+                ],
+                "summary": "Find me",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LibrarianResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/librarian/me/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find all active sessions of current librarian, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Librarians"
+                ],
+                "summary": "Find my sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LibrarianSessionFindResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/librarian/me/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete one of sessions of current librarian",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Librarians"
+                ],
+                "summary": "Revoke my session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/librarian/password/forgot": {
+            "post": {
+                "description": "Email a password reset link, answers the same whether the email is registered or not",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Librarians"
+                ],
+                "summary": "Forgot password",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LibrarianForgotPasswordRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    }
+                }
+            }
+        },
+        "/librarian/password/reset": {
+            "post": {
+                "description": "Set a new password with the token of the reset link, signs out all sessions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Librarians"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LibrarianResetPasswordRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/librarian/refresh": {
+            "post": {
+                "description": "Refresh access token, refresh tokens are single use and reusing one revokes its session",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Librarians"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LibrarianRefreshTokenDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LibrarianRefreshTokenResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/librarian/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find existing librarian by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Librarians"
+                ],
+                "summary": "Find librarian",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LibrarianResponseDto"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update existing librarian",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Librarians"
+                ],
+                "summary": "Update librarian",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Librarian ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LibrarianUpdateRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LibrarianResponseDto"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete existing librarian, admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Librarians"
+                ],
+                "summary": "Delete librarian",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Librarian ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/librarian/{id}/lockout": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Admin reset failed logins of librarian, optionally of a client ip too",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Librarians"
+                ],
+                "summary": "Unlock login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Librarian ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client IP",
+                        "name": "ip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/librarian/{id}/sessions": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Admin delete all sessions of librarian",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Librarians"
+                ],
+                "summary": "Revoke all sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Librarian ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/order": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find all orders, users only ever see their own",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Find all orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pagination size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pagination page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at, updated_at, pickup_schedule, due_at or status, optionally suffixed with :asc or :desc, defaults to created_at:desc,id:desc",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, takes precedence over page, not combinable with orderBy",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "order status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "book key",
+                        "name": "book_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "user id, admin only",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "librarian id",
+                        "name": "librarian_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created at or after, RFC3339",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created before, RFC3339",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pickup scheduled at or after, RFC3339",
+                        "name": "pickup_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pickup scheduled before, RFC3339",
+                        "name": "pickup_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderFindResponseDto"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Admin create order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "To register order",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderCreateRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderCreateResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/overdue": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Librarian find picked up orders past their due date, oldest due date first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Find overdue orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pagination size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pagination page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderFindResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find existing order by id visible to the caller",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Find order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Librarian accept pending order, reserving an available copy of the book",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Accept order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "User cancel own order which has not been picked up yet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Cancel order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/lost": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Librarian flag picked up order as lost",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Mark order lost",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/overdue": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Librarian flag picked up order as overdue",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Mark order overdue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/pickup": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Librarian hand accepted order over to its user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Pick up order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Librarian reject pending order with a reason",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Reject order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderRejectRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/renew": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "User extend own active loan by another loan period, denied once the renewal limit is reached, the loan is overdue beyond the grace period or the book is reserved by another user. Renewing an overdue loan fines the days it is late",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Renew order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/order/{id}/return": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Librarian take order back from its user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Return order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrderResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Admin find all users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Find all users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pagination size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pagination page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page, takes precedence over page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserFindResponseDto"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Admin create user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "To register user",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserRegisterRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserRegisterResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/login": {
+            "post": {
+                "description": "User login with email and password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "User login",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserLoginRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserLoginResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/logout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete current session",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "User logout",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/user/me": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get user id from token, find user by uuid and returns it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Find me",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/me/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Find all active sessions of current user, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Find my sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserSessionFindResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/me/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete one of sessions of current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Revoke my session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/user/password/forgot": {
+            "post": {
+                "description": "Email a password reset link, answers the same whether the email is registered or not",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Forgot password",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserForgotPasswordRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    }
+                }
+            }
+        },
+        "/user/password/reset": {
+            "post": {
+                "description": "Set a new password with the token of the reset link, signs out all sessions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserResetPasswordRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/user/refresh": {
+            "post": {
+                "description": "Refresh access token, refresh tokens are single use and reusing one revokes its session",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Users"
                 ],
-                "summary": "User logout",
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserRefreshTokenDto"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserRefreshTokenResponseDto"
+                        }
                     }
                 }
             }
         },
-        "/user/me": {
-            "get": {
-                "security": [
+        "/user/signup": {
+            "post": {
+                "description": "Create unverified user and email the verification link",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "To sign up user",
+                "parameters": [
                     {
-                        "ApiKeyAuth": []
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserSignupRequestDto"
+                        }
                     }
                 ],
-                "description": "Get session id from token, find user by uuid and returns it",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserRegisterResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/verify": {
+            "post": {
+                "description": "Verify email address with the token of the verification link",
                 "consumes": [
                     "application/json"
                 ],
@@ -663,7 +1964,18 @@ const docTemplate = `{
                 "tags": [
                     "Users"
                 ],
-                "summary": "Find me",
+                "summary": "Verify email",
+                "parameters": [
+                    {
+                        "description": "Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserVerifyEmailRequestDto"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -674,9 +1986,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/refresh": {
+        "/user/verify/resend": {
             "post": {
-                "description": "Refresh access token",
+                "description": "Email a new verification link to an unverified account, answers the same for unknown or verified emails",
                 "consumes": [
                     "application/json"
                 ],
@@ -686,7 +1998,7 @@ const docTemplate = `{
                 "tags": [
                     "Users"
                 ],
-                "summary": "Refresh access token",
+                "summary": "Resend verification email",
                 "parameters": [
                     {
                         "description": "Payload",
@@ -694,16 +2006,13 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UserRefreshTokenDto"
+                            "$ref": "#/definitions/dto.UserResendVerificationRequestDto"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserRefreshTokenResponseDto"
-                        }
+                    "202": {
+                        "description": "Accepted"
                     }
                 }
             }
@@ -811,43 +2120,360 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.BookFindResponseDto": {
+        },
+        "/user/{id}/lockout": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Admin reset failed logins of user, optionally of a client ip too",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Unlock login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client IP",
+                        "name": "ip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/user/{id}/sessions": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Admin delete all sessions of user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Revoke all sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.BookFindResponseDto": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BookReponseDto"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/utils.PaginationMetaDto"
+                }
+            }
+        },
+        "dto.BookReponseDto": {
+            "type": "object",
+            "properties": {
+                "authors": {
+                    "type": "array",
+                    "items": {}
+                },
+                "cover_edition_key": {
+                    "type": "string"
+                },
+                "cover_id": {
+                    "type": "number"
+                },
+                "edition_count": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CopyCreateRequestDto": {
+            "type": "object",
+            "required": [
+                "barcode",
+                "key"
+            ],
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "condition": {
+                    "type": "string",
+                    "enum": [
+                        "new",
+                        "good",
+                        "fair",
+                        "poor",
+                        "damaged"
+                    ]
+                },
+                "key": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 128
+                }
+            }
+        },
+        "dto.CopyCreateResponseDto": {
+            "type": "object",
+            "required": [
+                "copy_id"
+            ],
+            "properties": {
+                "copy_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CopyFindResponseDto": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "meta": {
+                    "$ref": "#/definitions/utils.PaginationMetaDto"
+                }
+            }
+        },
+        "dto.CopyResponseDto": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "condition": {
+                    "type": "string"
+                },
+                "copy_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CopyUpdateRequestDto": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "condition": {
+                    "type": "string",
+                    "enum": [
+                        "new",
+                        "good",
+                        "fair",
+                        "poor",
+                        "damaged"
+                    ]
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "available",
+                        "lost",
+                        "withdrawn"
+                    ]
+                }
+            }
+        },
+        "dto.FineBalanceResponseDto": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.FineFindResponseDto": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "meta": {
+                    "$ref": "#/definitions/utils.PaginationMetaDto"
+                }
+            }
+        },
+        "dto.FinePayRequestDto": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.BookReponseDto"
-                    }
+                "note": {
+                    "type": "string",
+                    "maxLength": 256
+                }
+            }
+        },
+        "dto.FineResponseDto": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "fine_id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "librarian_id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "settled_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.FineWaiveRequestDto": {
+            "type": "object",
+            "required": [
+                "note"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 256
+                }
+            }
+        },
+        "dto.HoldCreateRequestDto": {
+            "type": "object",
+            "required": [
+                "key"
+            ],
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "dto.HoldFindResponseDto": {
+            "type": "object",
+            "properties": {
+                "data": {},
                 "meta": {
                     "$ref": "#/definitions/utils.PaginationMetaDto"
                 }
             }
         },
-        "dto.BookReponseDto": {
+        "dto.HoldResponseDto": {
             "type": "object",
             "properties": {
-                "authors": {
-                    "type": "array",
-                    "items": {}
-                },
-                "cover_edition_key": {
+                "copy_id": {
                     "type": "string"
                 },
-                "cover_id": {
-                    "type": "number"
+                "created_at": {
+                    "type": "string"
                 },
-                "edition_count": {
-                    "type": "integer"
+                "hold_id": {
+                    "type": "string"
                 },
                 "key": {
                     "type": "string"
                 },
-                "title": {
+                "ready_until": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
@@ -861,6 +2487,18 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.LibrarianForgotPasswordRequestDto": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 60
+                }
+            }
+        },
         "dto.LibrarianLoginRequestDto": {
             "type": "object",
             "required": [
@@ -955,6 +2593,21 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.LibrarianResetPasswordRequestDto": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.LibrarianResponseDto": {
             "type": "object",
             "properties": {
@@ -981,6 +2634,37 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.LibrarianSessionFindResponseDto": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LibrarianSessionResponseDto"
+                    }
+                }
+            }
+        },
+        "dto.LibrarianSessionResponseDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.LibrarianUpdateRequestDto": {
             "type": "object",
             "properties": {
@@ -1035,12 +2719,30 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.OrderRejectRequestDto": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 250
+                }
+            }
+        },
         "dto.OrderResponseDto": {
             "type": "object",
             "properties": {
+                "copy_id": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "due_at": {
+                    "type": "string"
+                },
                 "item": {
                     "$ref": "#/definitions/models.OrderItem"
                 },
@@ -1050,9 +2752,21 @@ const docTemplate = `{
                 "order_id": {
                     "type": "string"
                 },
+                "picked_up_at": {
+                    "type": "string"
+                },
                 "pickup_schedule": {
                     "type": "string"
                 },
+                "reject_reason": {
+                    "type": "string"
+                },
+                "renewal_count": {
+                    "type": "integer"
+                },
+                "returned_at": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "string"
                 },
@@ -1073,6 +2787,18 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UserForgotPasswordRequestDto": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 60
+                }
+            }
+        },
         "dto.UserLoginRequestDto": {
             "type": "object",
             "required": [
@@ -1171,6 +2897,33 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UserResendVerificationRequestDto": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 60
+                }
+            }
+        },
+        "dto.UserResetPasswordRequestDto": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.UserResponseDto": {
             "type": "object",
             "properties": {
@@ -1183,6 +2936,9 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
+                "email_verified_at": {
+                    "type": "string"
+                },
                 "first_name": {
                     "type": "string"
                 },
@@ -1200,6 +2956,63 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UserSessionFindResponseDto": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UserSessionResponseDto"
+                    }
+                }
+            }
+        },
+        "dto.UserSessionResponseDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UserSignupRequestDto": {
+            "type": "object",
+            "required": [
+                "email",
+                "first_name",
+                "last_name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 60
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.UserUpdateRequestDto": {
             "type": "object",
             "properties": {
@@ -1219,6 +3032,17 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UserVerifyEmailRequestDto": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
         "models.OrderItem": {
             "type": "object",
             "properties": {
@@ -1246,14 +3070,29 @@ const docTemplate = `{
         "utils.PaginationMetaDto": {
             "type": "object",
             "properties": {
+                "has_more": {
+                    "type": "boolean"
+                },
                 "limit": {
                     "type": "integer"
                 },
+                "next_cursor": {
+                    "type": "string"
+                },
                 "offset": {
                     "type": "integer"
                 },
                 "page": {
                     "type": "integer"
+                },
+                "sort": {
+                    "type": "string"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
                 }
             }
         }
@@ -0,0 +1,32 @@
+package catalog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/book"
+	"github.com/dinorain/pinjembuku/pkg/http_client"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+)
+
+const (
+	ProviderOpenLibrary = "openlibrary"
+	ProviderFixture     = "fixture"
+)
+
+// NewCatalogProvider returns the catalog provider selected in config
+func NewCatalogProvider(cfg *config.Config, logger logger.Logger) (book.CatalogProvider, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Catalog.Provider)) {
+	case "", ProviderOpenLibrary:
+		return NewOpenLibraryProvider(http_client.NewHttpClient(cfg.Http.HttpClientDebug), cfg.Catalog.OpenLibraryURL, logger), nil
+	case ProviderFixture:
+		return NewFixtureProvider(cfg.Catalog.FixturePath)
+	}
+
+	return nil, fmt.Errorf("catalog provider invalid: %v", cfg.Catalog.Provider)
+}
+
+func normalizeBookKey(bookKey string) string {
+	return "/" + strings.Trim(strings.TrimSpace(bookKey), "/")
+}
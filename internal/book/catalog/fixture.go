@@ -0,0 +1,89 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/internal/book"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+type fixtureWork struct {
+	models.Book
+	Subjects []string `json:"subjects"`
+}
+
+// Fixture catalog provider, serves works from a local json file
+type fixtureProvider struct {
+	works []fixtureWork
+}
+
+var _ book.CatalogProvider = (*fixtureProvider)(nil)
+
+// Fixture catalog provider constructor
+func NewFixtureProvider(path string) (*fixtureProvider, error) {
+	fixtureBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "fixtureProvider.ReadFile")
+	}
+
+	var works []fixtureWork
+	if err := json.Unmarshal(fixtureBytes, &works); err != nil {
+		return nil, errors.Wrap(err, "fixtureProvider.json.Unmarshal")
+	}
+
+	return &fixtureProvider{works: works}, nil
+}
+
+// FindAllBySubject find works of subject
+func (p *fixtureProvider) FindAllBySubject(ctx context.Context, subject string, pagination *utils.Pagination) ([]models.Book, error) {
+	books := p.findBySubject(subject)
+
+	offset := pagination.GetOffset()
+	if offset >= len(books) {
+		return nil, nil
+	}
+	books = books[offset:]
+	if limit := pagination.GetLimit(); limit > 0 && limit < len(books) {
+		books = books[:limit]
+	}
+
+	return books, nil
+}
+
+// CountBySubject count works of subject
+func (p *fixtureProvider) CountBySubject(ctx context.Context, subject string) (int, error) {
+	return len(p.findBySubject(subject)), nil
+}
+
+// FindByWork find work by key
+func (p *fixtureProvider) FindByWork(ctx context.Context, bookKey string) (*models.Book, error) {
+	bookKey = normalizeBookKey(bookKey)
+	for _, work := range p.works {
+		if normalizeBookKey(work.BookKey) == bookKey {
+			found := work.Book
+			return &found, nil
+		}
+	}
+
+	return nil, errors.Wrapf(grpc_errors.ErrNotFound, "fixtureProvider.FindByWork: %v", bookKey)
+}
+
+func (p *fixtureProvider) findBySubject(subject string) []models.Book {
+	var books []models.Book
+	for _, work := range p.works {
+		for _, s := range work.Subjects {
+			if strings.EqualFold(s, subject) {
+				books = append(books, work.Book)
+				break
+			}
+		}
+	}
+	return books
+}
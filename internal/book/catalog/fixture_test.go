@@ -0,0 +1,56 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+func TestFixtureProvider_FindAllBySubject(t *testing.T) {
+	t.Parallel()
+
+	provider, err := NewFixtureProvider("../../../fixtures/books.json")
+	require.NoError(t, err)
+
+	books, err := provider.FindAllBySubject(context.Background(), "love", utils.NewPaginationQuery(2, 1))
+	require.NoError(t, err)
+	require.Len(t, books, 2)
+
+	books, err = provider.FindAllBySubject(context.Background(), "love", utils.NewPaginationQuery(2, 2))
+	require.NoError(t, err)
+	require.Len(t, books, 1)
+
+	books, err = provider.FindAllBySubject(context.Background(), "love", utils.NewPaginationQuery(2, 3))
+	require.NoError(t, err)
+	require.Nil(t, books)
+}
+
+func TestFixtureProvider_CountBySubject(t *testing.T) {
+	t.Parallel()
+
+	provider, err := NewFixtureProvider("../../../fixtures/books.json")
+	require.NoError(t, err)
+
+	count, err := provider.CountBySubject(context.Background(), "love")
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+}
+
+func TestFixtureProvider_FindByWork(t *testing.T) {
+	t.Parallel()
+
+	provider, err := NewFixtureProvider("../../../fixtures/books.json")
+	require.NoError(t, err)
+
+	book, err := provider.FindByWork(context.Background(), "works/OL66554W")
+	require.NoError(t, err)
+	require.Equal(t, "Pride and Prejudice", book.Title)
+
+	_, err = provider.FindByWork(context.Background(), "/works/OL0W")
+	require.True(t, errors.Is(err, grpc_errors.ErrNotFound))
+}
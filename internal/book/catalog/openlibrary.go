@@ -0,0 +1,112 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/internal/book"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+const (
+	defaultOpenLibraryURL = "https://openlibrary.org"
+)
+
+// OpenLibrary catalog provider
+type openLibraryProvider struct {
+	client  *resty.Client
+	baseURL string
+	logger  logger.Logger
+}
+
+var _ book.CatalogProvider = (*openLibraryProvider)(nil)
+
+// OpenLibrary catalog provider constructor
+func NewOpenLibraryProvider(client *resty.Client, baseURL string, logger logger.Logger) *openLibraryProvider {
+	if baseURL == "" {
+		baseURL = defaultOpenLibraryURL
+	}
+	return &openLibraryProvider{client: client, baseURL: strings.TrimRight(baseURL, "/"), logger: logger}
+}
+
+// FindAllBySubject find works of subject
+func (p *openLibraryProvider) FindAllBySubject(ctx context.Context, subject string, pagination *utils.Pagination) ([]models.Book, error) {
+	type openlibraryReponseDto struct {
+		Works []models.Book `json:"works"`
+	}
+
+	result := &openlibraryReponseDto{}
+	resp, err := p.client.R().
+		SetContext(ctx).
+		SetQueryParam("offset", strconv.Itoa(pagination.GetOffset())).
+		SetQueryParam("limit", strconv.Itoa(pagination.GetLimit())).
+		SetResult(result).
+		Get(fmt.Sprintf("%s/subjects/%s.json", p.baseURL, url.PathEscape(subject)))
+	if err != nil {
+		return nil, errors.Wrap(err, "openLibraryProvider.FindAllBySubject.Get")
+	}
+	if err := p.checkResponse(resp); err != nil {
+		return nil, errors.Wrapf(err, "openLibraryProvider.FindAllBySubject: %v", subject)
+	}
+
+	return result.Works, nil
+}
+
+// CountBySubject count works of subject
+func (p *openLibraryProvider) CountBySubject(ctx context.Context, subject string) (int, error) {
+	type openlibraryReponseDto struct {
+		WorkCount int `json:"work_count"`
+	}
+
+	result := &openlibraryReponseDto{}
+	resp, err := p.client.R().
+		SetContext(ctx).
+		SetQueryParam("limit", "0").
+		SetResult(result).
+		Get(fmt.Sprintf("%s/subjects/%s.json", p.baseURL, url.PathEscape(subject)))
+	if err != nil {
+		return 0, errors.Wrap(err, "openLibraryProvider.CountBySubject.Get")
+	}
+	if err := p.checkResponse(resp); err != nil {
+		return 0, errors.Wrapf(err, "openLibraryProvider.CountBySubject: %v", subject)
+	}
+
+	return result.WorkCount, nil
+}
+
+// FindByWork find work by key
+func (p *openLibraryProvider) FindByWork(ctx context.Context, bookKey string) (*models.Book, error) {
+	result := &models.Book{}
+	resp, err := p.client.R().
+		SetContext(ctx).
+		SetResult(result).
+		Get(fmt.Sprintf("%s%s.json", p.baseURL, normalizeBookKey(bookKey)))
+	if err != nil {
+		return nil, errors.Wrap(err, "openLibraryProvider.FindByWork.Get")
+	}
+	if err := p.checkResponse(resp); err != nil {
+		return nil, errors.Wrapf(err, "openLibraryProvider.FindByWork: %v", bookKey)
+	}
+
+	return result, nil
+}
+
+func (p *openLibraryProvider) checkResponse(resp *resty.Response) error {
+	switch {
+	case resp.StatusCode() == http.StatusNotFound:
+		return grpc_errors.ErrNotFound
+	case resp.IsError():
+		return fmt.Errorf("unexpected status: %v", resp.Status())
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package catalog
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/http_client"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+func SetupOpenLibrary(t *testing.T) *openLibraryProvider {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/subjects/love.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") == "0" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"work_count": 11823, "works": []}`))
+			return
+		}
+		require.Equal(t, "10", r.URL.Query().Get("offset"))
+		require.Equal(t, "10", r.URL.Query().Get("limit"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"works": [{"key": "/works/OL66554W", "title": "Pride and Prejudice", "edition_count": 3052}]}`))
+	})
+	mux.HandleFunc("/works/OL66554W.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key": "/works/OL66554W", "title": "Pride and Prejudice"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return NewOpenLibraryProvider(http_client.NewHttpClient(false).SetRetryCount(0), server.URL, nil)
+}
+
+func TestOpenLibraryProvider_FindAllBySubject(t *testing.T) {
+	t.Parallel()
+
+	provider := SetupOpenLibrary(t)
+
+	books, err := provider.FindAllBySubject(context.Background(), "love", utils.NewPaginationQuery(10, 2))
+	require.NoError(t, err)
+	require.Len(t, books, 1)
+	require.Equal(t, "/works/OL66554W", books[0].BookKey)
+	require.Equal(t, 3052, books[0].EditionCount)
+}
+
+func TestOpenLibraryProvider_CountBySubject(t *testing.T) {
+	t.Parallel()
+
+	provider := SetupOpenLibrary(t)
+
+	count, err := provider.CountBySubject(context.Background(), "love")
+	require.NoError(t, err)
+	require.Equal(t, 11823, count)
+}
+
+func TestOpenLibraryProvider_FindByWork(t *testing.T) {
+	t.Parallel()
+
+	provider := SetupOpenLibrary(t)
+
+	book, err := provider.FindByWork(context.Background(), "works/OL66554W")
+	require.NoError(t, err)
+	require.Equal(t, "Pride and Prejudice", book.Title)
+
+	_, err = provider.FindByWork(context.Background(), "/works/OL0W")
+	require.True(t, errors.Is(err, grpc_errors.ErrNotFound))
+}
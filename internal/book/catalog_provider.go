@@ -0,0 +1,16 @@
+//go:generate mockgen -source catalog_provider.go -destination mock/catalog_provider.go -package mock
+package book
+
+import (
+	"context"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+// Book catalog provider interface
+type CatalogProvider interface {
+	FindAllBySubject(ctx context.Context, subject string, pagination *utils.Pagination) ([]models.Book, error)
+	CountBySubject(ctx context.Context, subject string) (int, error)
+	FindByWork(ctx context.Context, bookKey string) (*models.Book, error)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+	bookService "github.com/dinorain/pinjembuku/proto/book"
+)
+
+// FindAllBySubject find books of a subject page by page
+func (b *booksServiceGRPC) FindAllBySubject(ctx context.Context, r *bookService.FindAllBySubjectRequest) (*bookService.FindAllBySubjectResponse, error) {
+	subject := r.GetSubject()
+	if subject == "" {
+		b.logger.Errorf("subject required")
+		return nil, status.Errorf(codes.InvalidArgument, "subject required")
+	}
+
+	pq := utils.NewPaginationFromRequest(r.GetSize(), r.GetPage())
+
+	books, err := b.bookUC.CachedFindAllBySubject(ctx, subject, pq)
+	if err != nil {
+		b.logger.Errorf("bookUC.CachedFindAllBySubject: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "bookUC.CachedFindAllBySubject: %v", err)
+	}
+
+	totalCount, err := b.bookUC.CachedCountBySubject(ctx, subject)
+	if err != nil {
+		b.logger.Errorf("bookUC.CachedCountBySubject: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "bookUC.CachedCountBySubject: %v", err)
+	}
+
+	booksProto := make([]*bookService.Book, 0, len(books))
+	for i := range books {
+		booksProto = append(booksProto, b.bookModelToProto(&books[i]))
+	}
+
+	return &bookService.FindAllBySubjectResponse{Books: booksProto, TotalCount: int64(totalCount)}, nil
+}
+
+// FindByWork find book by work key
+func (b *booksServiceGRPC) FindByWork(ctx context.Context, r *bookService.FindByWorkRequest) (*bookService.FindByWorkResponse, error) {
+	key := r.GetKey()
+	if key == "" {
+		b.logger.Errorf("key required")
+		return nil, status.Errorf(codes.InvalidArgument, "key required")
+	}
+
+	book, err := b.bookUC.CachedFindByWork(ctx, key)
+	if err != nil {
+		b.logger.Errorf("bookUC.CachedFindByWork: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "bookUC.CachedFindByWork: %v", err)
+	}
+
+	return &bookService.FindByWorkResponse{Book: b.bookModelToProto(book)}, nil
+}
+
+func (b *booksServiceGRPC) bookModelToProto(book *models.Book) *bookService.Book {
+	return &bookService.Book{
+		Key:             book.BookKey,
+		Title:           book.Title,
+		EditionCount:    int64(book.EditionCount),
+		CoverId:         book.CoverID,
+		CoverEditionKey: book.CoverEditionKey,
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/book/mock"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/grpc_test_utils"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+	bookService "github.com/dinorain/pinjembuku/proto/book"
+)
+
+func newBookServiceClient(t *testing.T, ctrl *gomock.Controller) (bookService.BookServiceClient, *mock.MockBookUseCase) {
+	bookUC := mock.NewMockBookUseCase(ctrl)
+
+	cfg := &config.Config{}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+
+	conn := grpc_test_utils.NewBufconnClientConn(t, func(grpcServer *grpc.Server) {
+		bookService.RegisterBookServiceServer(grpcServer, NewBookServerGRPC(appLogger, cfg, bookUC))
+	})
+
+	return bookService.NewBookServiceClient(conn), bookUC
+}
+
+func TestBooksService_FindAllBySubject(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client, bookUC := newBookServiceClient(t, ctrl)
+
+	bookUC.EXPECT().CachedFindAllBySubject(gomock.Any(), "love", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, pq *utils.Pagination) ([]models.Book, error) {
+		require.Equal(t, 10, pq.GetSize())
+		require.Equal(t, 2, pq.GetPage())
+		return []models.Book{{BookKey: "/works/OL1W", Title: "Title", EditionCount: 3, CoverID: 42}}, nil
+	})
+	bookUC.EXPECT().CachedCountBySubject(gomock.Any(), "love").Return(11, nil)
+
+	res, err := client.FindAllBySubject(context.Background(), &bookService.FindAllBySubjectRequest{Subject: "love", Page: 2})
+	require.NoError(t, err)
+	require.Len(t, res.GetBooks(), 1)
+	require.Equal(t, "/works/OL1W", res.GetBooks()[0].GetKey())
+	require.Equal(t, int64(3), res.GetBooks()[0].GetEditionCount())
+	require.Equal(t, int64(11), res.GetTotalCount())
+
+	_, err = client.FindAllBySubject(context.Background(), &bookService.FindAllBySubjectRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestBooksService_FindByWork(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client, bookUC := newBookServiceClient(t, ctrl)
+
+	bookUC.EXPECT().CachedFindByWork(gomock.Any(), "/works/OL1W").Return(&models.Book{BookKey: "/works/OL1W", Title: "Title"}, nil)
+
+	res, err := client.FindByWork(context.Background(), &bookService.FindByWorkRequest{Key: "/works/OL1W"})
+	require.NoError(t, err)
+	require.Equal(t, "Title", res.GetBook().GetTitle())
+
+	bookUC.EXPECT().CachedFindByWork(gomock.Any(), "/works/missing").Return(nil, grpc_errors.ErrNotFound)
+
+	_, err = client.FindByWork(context.Background(), &bookService.FindByWorkRequest{Key: "/works/missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/book"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+)
+
+type booksServiceGRPC struct {
+	logger logger.Logger
+	cfg    *config.Config
+	bookUC book.BookUseCase
+}
+
+// Book service constructor
+func NewBookServerGRPC(logger logger.Logger, cfg *config.Config, bookUC book.BookUseCase) *booksServiceGRPC {
+	return &booksServiceGRPC{logger: logger, cfg: cfg, bookUC: bookUC}
+}
@@ -61,9 +61,15 @@ func (h *bookHandlersHTTP) FindBySubject() echo.HandlerFunc {
 
 		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
 
-		books, err := h.bookUC.FindAllBySubject(ctx, subject, pq)
+		books, err := h.bookUC.CachedFindAllBySubject(ctx, subject, pq)
 		if err != nil {
-			h.logger.Errorf("userUC.FindAll: %v", err)
+			h.logger.Errorf("bookUC.CachedFindAllBySubject: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		totalCount, err := h.bookUC.CachedCountBySubject(ctx, subject)
+		if err != nil {
+			h.logger.Errorf("bookUC.CachedCountBySubject: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
@@ -81,11 +87,7 @@ func (h *bookHandlersHTTP) FindBySubject() echo.HandlerFunc {
 
 		return c.JSON(http.StatusOK, dto.BookFindResponseDto{
 			Data: data,
-			Meta: utils.PaginationMetaDto{
-				Limit:  pq.GetLimit(),
-				Offset: pq.GetOffset(),
-				Page:   pq.GetPage(),
-			},
+			Meta: utils.NewPaginationMetaDto(pq, totalCount),
 		})
 	}
 }
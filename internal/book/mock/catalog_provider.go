@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: catalog_provider.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/pinjembuku/internal/models"
+	utils "github.com/dinorain/pinjembuku/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCatalogProvider is a mock of CatalogProvider interface.
+type MockCatalogProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogProviderMockRecorder
+}
+
+// MockCatalogProviderMockRecorder is the mock recorder for MockCatalogProvider.
+type MockCatalogProviderMockRecorder struct {
+	mock *MockCatalogProvider
+}
+
+// NewMockCatalogProvider creates a new mock instance.
+func NewMockCatalogProvider(ctrl *gomock.Controller) *MockCatalogProvider {
+	mock := &MockCatalogProvider{ctrl: ctrl}
+	mock.recorder = &MockCatalogProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogProvider) EXPECT() *MockCatalogProviderMockRecorder {
+	return m.recorder
+}
+
+// CountBySubject mocks base method.
+func (m *MockCatalogProvider) CountBySubject(ctx context.Context, subject string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBySubject", ctx, subject)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBySubject indicates an expected call of CountBySubject.
+func (mr *MockCatalogProviderMockRecorder) CountBySubject(ctx, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBySubject", reflect.TypeOf((*MockCatalogProvider)(nil).CountBySubject), ctx, subject)
+}
+
+// FindAllBySubject mocks base method.
+func (m *MockCatalogProvider) FindAllBySubject(ctx context.Context, subject string, pagination *utils.Pagination) ([]models.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllBySubject", ctx, subject, pagination)
+	ret0, _ := ret[0].([]models.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllBySubject indicates an expected call of FindAllBySubject.
+func (mr *MockCatalogProviderMockRecorder) FindAllBySubject(ctx, subject, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllBySubject", reflect.TypeOf((*MockCatalogProvider)(nil).FindAllBySubject), ctx, subject, pagination)
+}
+
+// FindByWork mocks base method.
+func (m *MockCatalogProvider) FindByWork(ctx context.Context, bookKey string) (*models.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByWork", ctx, bookKey)
+	ret0, _ := ret[0].(*models.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByWork indicates an expected call of FindByWork.
+func (mr *MockCatalogProviderMockRecorder) FindByWork(ctx, bookKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWork", reflect.TypeOf((*MockCatalogProvider)(nil).FindByWork), ctx, bookKey)
+}
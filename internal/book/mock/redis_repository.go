@@ -0,0 +1,151 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: redis_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/pinjembuku/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBookRedisRepository is a mock of BookRedisRepository interface.
+type MockBookRedisRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookRedisRepositoryMockRecorder
+}
+
+// MockBookRedisRepositoryMockRecorder is the mock recorder for MockBookRedisRepository.
+type MockBookRedisRepositoryMockRecorder struct {
+	mock *MockBookRedisRepository
+}
+
+// NewMockBookRedisRepository creates a new mock instance.
+func NewMockBookRedisRepository(ctrl *gomock.Controller) *MockBookRedisRepository {
+	mock := &MockBookRedisRepository{ctrl: ctrl}
+	mock.recorder = &MockBookRedisRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookRedisRepository) EXPECT() *MockBookRedisRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteBookCtx mocks base method.
+func (m *MockBookRedisRepository) DeleteBookCtx(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBookCtx", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBookCtx indicates an expected call of DeleteBookCtx.
+func (mr *MockBookRedisRepositoryMockRecorder) DeleteBookCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBookCtx", reflect.TypeOf((*MockBookRedisRepository)(nil).DeleteBookCtx), ctx, key)
+}
+
+// GetBySubjectCtx mocks base method.
+func (m *MockBookRedisRepository) GetBySubjectCtx(ctx context.Context, key string) ([]models.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySubjectCtx", ctx, key)
+	ret0, _ := ret[0].([]models.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySubjectCtx indicates an expected call of GetBySubjectCtx.
+func (mr *MockBookRedisRepositoryMockRecorder) GetBySubjectCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySubjectCtx", reflect.TypeOf((*MockBookRedisRepository)(nil).GetBySubjectCtx), ctx, key)
+}
+
+// GetByWorkCtx mocks base method.
+func (m *MockBookRedisRepository) GetByWorkCtx(ctx context.Context, key string) (*models.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByWorkCtx", ctx, key)
+	ret0, _ := ret[0].(*models.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByWorkCtx indicates an expected call of GetByWorkCtx.
+func (mr *MockBookRedisRepositoryMockRecorder) GetByWorkCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByWorkCtx", reflect.TypeOf((*MockBookRedisRepository)(nil).GetByWorkCtx), ctx, key)
+}
+
+// GetSubjectCountCtx mocks base method.
+func (m *MockBookRedisRepository) GetSubjectCountCtx(ctx context.Context, key string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubjectCountCtx", ctx, key)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubjectCountCtx indicates an expected call of GetSubjectCountCtx.
+func (mr *MockBookRedisRepositoryMockRecorder) GetSubjectCountCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubjectCountCtx", reflect.TypeOf((*MockBookRedisRepository)(nil).GetSubjectCountCtx), ctx, key)
+}
+
+// SetBookCtx mocks base method.
+func (m *MockBookRedisRepository) SetBookCtx(ctx context.Context, key string, seconds int, book *models.Book) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookCtx", ctx, key, seconds, book)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBookCtx indicates an expected call of SetBookCtx.
+func (mr *MockBookRedisRepositoryMockRecorder) SetBookCtx(ctx, key, seconds, book interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookCtx", reflect.TypeOf((*MockBookRedisRepository)(nil).SetBookCtx), ctx, key, seconds, book)
+}
+
+// SetBookNotFoundCtx mocks base method.
+func (m *MockBookRedisRepository) SetBookNotFoundCtx(ctx context.Context, key string, seconds int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookNotFoundCtx", ctx, key, seconds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBookNotFoundCtx indicates an expected call of SetBookNotFoundCtx.
+func (mr *MockBookRedisRepositoryMockRecorder) SetBookNotFoundCtx(ctx, key, seconds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookNotFoundCtx", reflect.TypeOf((*MockBookRedisRepository)(nil).SetBookNotFoundCtx), ctx, key, seconds)
+}
+
+// SetSubjectCountCtx mocks base method.
+func (m *MockBookRedisRepository) SetSubjectCountCtx(ctx context.Context, key string, seconds, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSubjectCountCtx", ctx, key, seconds, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSubjectCountCtx indicates an expected call of SetSubjectCountCtx.
+func (mr *MockBookRedisRepositoryMockRecorder) SetSubjectCountCtx(ctx, key, seconds, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubjectCountCtx", reflect.TypeOf((*MockBookRedisRepository)(nil).SetSubjectCountCtx), ctx, key, seconds, count)
+}
+
+// SetSubjectCtx mocks base method.
+func (m *MockBookRedisRepository) SetSubjectCtx(ctx context.Context, key string, seconds int, books []models.Book) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSubjectCtx", ctx, key, seconds, books)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSubjectCtx indicates an expected call of SetSubjectCtx.
+func (mr *MockBookRedisRepositoryMockRecorder) SetSubjectCtx(ctx, key, seconds, books interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSubjectCtx", reflect.TypeOf((*MockBookRedisRepository)(nil).SetSubjectCtx), ctx, key, seconds, books)
+}
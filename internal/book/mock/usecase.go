@@ -36,6 +36,66 @@ func (m *MockBookUseCase) EXPECT() *MockBookUseCaseMockRecorder {
 	return m.recorder
 }
 
+// CachedCountBySubject mocks base method.
+func (m *MockBookUseCase) CachedCountBySubject(ctx context.Context, subject string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CachedCountBySubject", ctx, subject)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CachedCountBySubject indicates an expected call of CachedCountBySubject.
+func (mr *MockBookUseCaseMockRecorder) CachedCountBySubject(ctx, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CachedCountBySubject", reflect.TypeOf((*MockBookUseCase)(nil).CachedCountBySubject), ctx, subject)
+}
+
+// CachedFindAllBySubject mocks base method.
+func (m *MockBookUseCase) CachedFindAllBySubject(ctx context.Context, subject string, pagination *utils.Pagination) ([]models.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CachedFindAllBySubject", ctx, subject, pagination)
+	ret0, _ := ret[0].([]models.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CachedFindAllBySubject indicates an expected call of CachedFindAllBySubject.
+func (mr *MockBookUseCaseMockRecorder) CachedFindAllBySubject(ctx, subject, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CachedFindAllBySubject", reflect.TypeOf((*MockBookUseCase)(nil).CachedFindAllBySubject), ctx, subject, pagination)
+}
+
+// CachedFindByWork mocks base method.
+func (m *MockBookUseCase) CachedFindByWork(ctx context.Context, bookKey string) (*models.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CachedFindByWork", ctx, bookKey)
+	ret0, _ := ret[0].(*models.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CachedFindByWork indicates an expected call of CachedFindByWork.
+func (mr *MockBookUseCaseMockRecorder) CachedFindByWork(ctx, bookKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CachedFindByWork", reflect.TypeOf((*MockBookUseCase)(nil).CachedFindByWork), ctx, bookKey)
+}
+
+// CountBySubject mocks base method.
+func (m *MockBookUseCase) CountBySubject(ctx context.Context, subject string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBySubject", ctx, subject)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBySubject indicates an expected call of CountBySubject.
+func (mr *MockBookUseCaseMockRecorder) CountBySubject(ctx, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBySubject", reflect.TypeOf((*MockBookUseCase)(nil).CountBySubject), ctx, subject)
+}
+
 // FindAllBySubject mocks base method.
 func (m *MockBookUseCase) FindAllBySubject(ctx context.Context, subject string, pagination *utils.Pagination) ([]models.Book, error) {
 	m.ctrl.T.Helper()
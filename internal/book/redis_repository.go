@@ -0,0 +1,20 @@
+//go:generate mockgen -source redis_repository.go -destination mock/redis_repository.go -package mock
+package book
+
+import (
+	"context"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+)
+
+// Book Redis repository interface
+type BookRedisRepository interface {
+	GetByWorkCtx(ctx context.Context, key string) (*models.Book, error)
+	SetBookCtx(ctx context.Context, key string, seconds int, book *models.Book) error
+	SetBookNotFoundCtx(ctx context.Context, key string, seconds int) error
+	DeleteBookCtx(ctx context.Context, key string) error
+	GetBySubjectCtx(ctx context.Context, key string) ([]models.Book, error)
+	SetSubjectCtx(ctx context.Context, key string, seconds int, books []models.Book) error
+	GetSubjectCountCtx(ctx context.Context, key string) (int, error)
+	SetSubjectCountCtx(ctx context.Context, key string, seconds int, count int) error
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/dinorain/pinjembuku/internal/book"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+)
+
+var notFoundMarker = []byte("not_found")
+
+// Book redis repository
+type bookRedisRepo struct {
+	redisClient *redis.Client
+	basePrefix  string
+	logger      logger.Logger
+}
+
+var _ book.BookRedisRepository = (*bookRedisRepo)(nil)
+
+// Book redis repository constructor
+func NewBookRedisRepo(redisClient *redis.Client, logger logger.Logger) *bookRedisRepo {
+	return &bookRedisRepo{redisClient: redisClient, basePrefix: "book:", logger: logger}
+}
+
+// Get book by work key, returns grpc_errors.ErrNotFound for keys cached as unknown
+func (r *bookRedisRepo) GetByWorkCtx(ctx context.Context, key string) (*models.Book, error) {
+	bookBytes, err := r.redisClient.Get(ctx, r.createKey("work", key)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(bookBytes, notFoundMarker) {
+		return nil, grpc_errors.ErrNotFound
+	}
+
+	book := &models.Book{}
+	if err = json.Unmarshal(bookBytes, book); err != nil {
+		return nil, err
+	}
+
+	return book, nil
+}
+
+// Cache book with duration in seconds
+func (r *bookRedisRepo) SetBookCtx(ctx context.Context, key string, seconds int, book *models.Book) error {
+	bookBytes, err := json.Marshal(book)
+	if err != nil {
+		return err
+	}
+
+	return r.redisClient.Set(ctx, r.createKey("work", key), bookBytes, time.Second*time.Duration(seconds)).Err()
+}
+
+// Cache unknown work key with duration in seconds
+func (r *bookRedisRepo) SetBookNotFoundCtx(ctx context.Context, key string, seconds int) error {
+	return r.redisClient.Set(ctx, r.createKey("work", key), notFoundMarker, time.Second*time.Duration(seconds)).Err()
+}
+
+// Delete book by work key
+func (r *bookRedisRepo) DeleteBookCtx(ctx context.Context, key string) error {
+	return r.redisClient.Del(ctx, r.createKey("work", key)).Err()
+}
+
+// Get subject page by key
+func (r *bookRedisRepo) GetBySubjectCtx(ctx context.Context, key string) ([]models.Book, error) {
+	booksBytes, err := r.redisClient.Get(ctx, r.createKey("subject", key)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var books []models.Book
+	if err = json.Unmarshal(booksBytes, &books); err != nil {
+		return nil, err
+	}
+
+	return books, nil
+}
+
+// Cache subject page with duration in seconds
+func (r *bookRedisRepo) SetSubjectCtx(ctx context.Context, key string, seconds int, books []models.Book) error {
+	booksBytes, err := json.Marshal(books)
+	if err != nil {
+		return err
+	}
+
+	return r.redisClient.Set(ctx, r.createKey("subject", key), booksBytes, time.Second*time.Duration(seconds)).Err()
+}
+
+// Get number of works in subject by key
+func (r *bookRedisRepo) GetSubjectCountCtx(ctx context.Context, key string) (int, error) {
+	return r.redisClient.Get(ctx, r.createKey("subject_count", key)).Int()
+}
+
+// Cache number of works in subject with duration in seconds
+func (r *bookRedisRepo) SetSubjectCountCtx(ctx context.Context, key string, seconds int, count int) error {
+	return r.redisClient.Set(ctx, r.createKey("subject_count", key), count, time.Second*time.Duration(seconds)).Err()
+}
+
+func (r *bookRedisRepo) createKey(kind string, value string) string {
+	return fmt.Sprintf("%s%s: %s", r.basePrefix, kind, value)
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+)
+
+func SetupRedis() *bookRedisRepo {
+	mr, err := miniredis.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	bookRedisRepository := NewBookRedisRepo(client, nil)
+	return bookRedisRepository
+}
+
+func TestBookRedisRepo_SetBookCtx(t *testing.T) {
+	t.Parallel()
+
+	redisRepo := SetupRedis()
+
+	t.Run("SetBookCtx", func(t *testing.T) {
+		book := &models.Book{BookKey: "/works/OL66554W"}
+
+		err := redisRepo.SetBookCtx(context.Background(), book.BookKey, 10, book)
+		require.NoError(t, err)
+	})
+}
+
+func TestBookRedisRepo_GetByWorkCtx(t *testing.T) {
+	t.Parallel()
+
+	redisRepo := SetupRedis()
+
+	t.Run("GetByWorkCtx", func(t *testing.T) {
+		book := &models.Book{BookKey: "/works/OL66554W", Title: "Pride and Prejudice"}
+
+		err := redisRepo.SetBookCtx(context.Background(), book.BookKey, 10, book)
+		require.NoError(t, err)
+
+		cachedBook, err := redisRepo.GetByWorkCtx(context.Background(), book.BookKey)
+		require.NoError(t, err)
+		require.Equal(t, book.Title, cachedBook.Title)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, err := redisRepo.GetByWorkCtx(context.Background(), "/works/OL1W")
+		require.True(t, errors.Is(err, redis.Nil))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		err := redisRepo.SetBookNotFoundCtx(context.Background(), "/works/OL0W", 10)
+		require.NoError(t, err)
+
+		_, err = redisRepo.GetByWorkCtx(context.Background(), "/works/OL0W")
+		require.True(t, errors.Is(err, grpc_errors.ErrNotFound))
+	})
+}
+
+func TestBookRedisRepo_DeleteBookCtx(t *testing.T) {
+	t.Parallel()
+
+	redisRepo := SetupRedis()
+
+	t.Run("DeleteBookCtx", func(t *testing.T) {
+		err := redisRepo.DeleteBookCtx(context.Background(), "/works/OL66554W")
+		require.NoError(t, err)
+	})
+}
+
+func TestBookRedisRepo_GetBySubjectCtx(t *testing.T) {
+	t.Parallel()
+
+	redisRepo := SetupRedis()
+
+	t.Run("GetBySubjectCtx", func(t *testing.T) {
+		books := []models.Book{{BookKey: "/works/OL66554W"}, {BookKey: "/works/OL21177W"}}
+
+		err := redisRepo.SetSubjectCtx(context.Background(), "love:0:10", 10, books)
+		require.NoError(t, err)
+
+		cachedBooks, err := redisRepo.GetBySubjectCtx(context.Background(), "love:0:10")
+		require.NoError(t, err)
+		require.Len(t, cachedBooks, 2)
+	})
+}
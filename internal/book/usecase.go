@@ -11,5 +11,9 @@ import (
 //  Book UseCase interface
 type BookUseCase interface {
 	FindAllBySubject(ctx context.Context, subject string, pagination *utils.Pagination) ([]models.Book, error)
+	CachedFindAllBySubject(ctx context.Context, subject string, pagination *utils.Pagination) ([]models.Book, error)
+	CountBySubject(ctx context.Context, subject string) (int, error)
+	CachedCountBySubject(ctx context.Context, subject string) (int, error)
 	FindByWork(ctx context.Context, bookKey string) (*models.Book, error)
+	CachedFindByWork(ctx context.Context, bookKey string) (*models.Book, error)
 }
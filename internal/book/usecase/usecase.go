@@ -2,67 +2,160 @@ package usecase
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
 
 	"github.com/dinorain/pinjembuku/config"
 	"github.com/dinorain/pinjembuku/internal/book"
 	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
 	"github.com/dinorain/pinjembuku/pkg/logger"
 	"github.com/dinorain/pinjembuku/pkg/utils"
 )
 
 const (
-	bookByIdCacheDuration = 3600
+	bookByIdCacheDuration     = 3600
+	bookNotFoundCacheDuration = 300
 )
 
 // Book UseCase
 type bookUseCase struct {
-	cfg    *config.Config
-	logger logger.Logger
+	cfg       *config.Config
+	logger    logger.Logger
+	catalog   book.CatalogProvider
+	redisRepo book.BookRedisRepository
 }
 
 var _ book.BookUseCase = (*bookUseCase)(nil)
 
 // New Book UseCase
-func NewBookUseCase(cfg *config.Config, logger logger.Logger) *bookUseCase {
-	return &bookUseCase{cfg: cfg, logger: logger}
+func NewBookUseCase(cfg *config.Config, logger logger.Logger, catalog book.CatalogProvider, redisRepo book.BookRedisRepository) *bookUseCase {
+	return &bookUseCase{cfg: cfg, logger: logger, catalog: catalog, redisRepo: redisRepo}
 }
 
 // FindAllBySubject find books by subject id
 func (u *bookUseCase) FindAllBySubject(ctx context.Context, subject string, pagination *utils.Pagination) ([]models.Book, error) {
-	resp, err := http.Get(fmt.Sprintf("https://openlibrary.org/subjects/%v.json?offset=%v&limit=%v", subject, pagination.GetOffset(), pagination.GetLimit()))
+	books, err := u.catalog.FindAllBySubject(ctx, subject, pagination)
 	if err != nil {
-		u.logger.Errorf("bookUseCase.http.Get: %v", err)
-		return nil, err
+		return nil, errors.Wrap(err, "catalog.FindAllBySubject")
+	}
+
+	return books, nil
+}
+
+// CachedFindAllBySubject find books by subject id from cache
+func (u *bookUseCase) CachedFindAllBySubject(ctx context.Context, subject string, pagination *utils.Pagination) ([]models.Book, error) {
+	key := fmt.Sprintf("%s:%d:%d", strings.ToLower(subject), pagination.GetOffset(), pagination.GetLimit())
+
+	cachedBooks, err := u.redisRepo.GetBySubjectCtx(ctx, key)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		u.logger.Errorf("redisRepo.GetBySubjectCtx", err)
+	}
+	if err == nil {
+		return cachedBooks, nil
+	}
+
+	books, err := u.catalog.FindAllBySubject(ctx, subject, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "catalog.FindAllBySubject")
+	}
+
+	if err := u.redisRepo.SetSubjectCtx(ctx, key, u.cacheDuration(), books); err != nil {
+		u.logger.Errorf("redisRepo.SetSubjectCtx", err)
+	}
+
+	return books, nil
+}
+
+// CountBySubject count books of subject
+func (u *bookUseCase) CountBySubject(ctx context.Context, subject string) (int, error) {
+	count, err := u.catalog.CountBySubject(ctx, subject)
+	if err != nil {
+		return 0, errors.Wrap(err, "catalog.CountBySubject")
+	}
+
+	return count, nil
+}
+
+// CachedCountBySubject count books of subject from cache
+func (u *bookUseCase) CachedCountBySubject(ctx context.Context, subject string) (int, error) {
+	key := strings.ToLower(subject)
+
+	cachedCount, err := u.redisRepo.GetSubjectCountCtx(ctx, key)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		u.logger.Errorf("redisRepo.GetSubjectCountCtx", err)
+	}
+	if err == nil {
+		return cachedCount, nil
 	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
 
-	type openlibraryReponseDto struct {
-		Works []models.Book `json:"works"`
+	count, err := u.catalog.CountBySubject(ctx, subject)
+	if err != nil {
+		return 0, errors.Wrap(err, "catalog.CountBySubject")
 	}
 
-	var bookResponseDto openlibraryReponseDto
-	_ = json.Unmarshal(body, &bookResponseDto)
+	if err := u.redisRepo.SetSubjectCountCtx(ctx, key, u.cacheDuration(), count); err != nil {
+		u.logger.Errorf("redisRepo.SetSubjectCountCtx", err)
+	}
 
-	return bookResponseDto.Works, nil
+	return count, nil
 }
 
-// FindById find book by uuid
+// FindByWork find book by work key
 func (u *bookUseCase) FindByWork(ctx context.Context, bookKey string) (*models.Book, error) {
-	resp, err := http.Get(fmt.Sprintf("https://openlibrary.org/%v.json", bookKey))
+	foundBook, err := u.catalog.FindByWork(ctx, bookKey)
 	if err != nil {
-		u.logger.Errorf("bookUseCase.http.Get: %v", err)
-		return nil, err
+		return nil, errors.Wrap(err, "catalog.FindByWork")
 	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
 
-	var book models.Book
-	_ = json.Unmarshal(body, &book)
+	return foundBook, nil
+}
+
+// CachedFindByWork find book by work key from cache, unknown keys are cached as well
+func (u *bookUseCase) CachedFindByWork(ctx context.Context, bookKey string) (*models.Book, error) {
+	key := strings.Trim(strings.TrimSpace(bookKey), "/")
 
-	return &book, nil
+	cachedBook, err := u.redisRepo.GetByWorkCtx(ctx, key)
+	if errors.Is(err, grpc_errors.ErrNotFound) {
+		return nil, errors.Wrap(err, "redisRepo.GetByWorkCtx")
+	}
+	if err != nil && !errors.Is(err, redis.Nil) {
+		u.logger.Errorf("redisRepo.GetByWorkCtx", err)
+	}
+	if cachedBook != nil {
+		return cachedBook, nil
+	}
+
+	foundBook, err := u.catalog.FindByWork(ctx, bookKey)
+	if err != nil {
+		if errors.Is(err, grpc_errors.ErrNotFound) {
+			if err := u.redisRepo.SetBookNotFoundCtx(ctx, key, u.notFoundCacheDuration()); err != nil {
+				u.logger.Errorf("redisRepo.SetBookNotFoundCtx", err)
+			}
+		}
+		return nil, errors.Wrap(err, "catalog.FindByWork")
+	}
+
+	if err := u.redisRepo.SetBookCtx(ctx, key, u.cacheDuration(), foundBook); err != nil {
+		u.logger.Errorf("redisRepo.SetBookCtx", err)
+	}
+
+	return foundBook, nil
+}
+
+func (u *bookUseCase) cacheDuration() int {
+	if u.cfg.Catalog.CacheDuration > 0 {
+		return u.cfg.Catalog.CacheDuration
+	}
+	return bookByIdCacheDuration
+}
+
+func (u *bookUseCase) notFoundCacheDuration() int {
+	if u.cfg.Catalog.NotFoundCacheDuration > 0 {
+		return u.cfg.Catalog.NotFoundCacheDuration
+	}
+	return bookNotFoundCacheDuration
 }
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/book/mock"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+func TestBookUseCase_CachedFindAllBySubject(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	catalogProvider := mock.NewMockCatalogProvider(ctrl)
+	bookRedisRepository := mock.NewMockBookRedisRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{Catalog: config.Catalog{CacheDuration: 60}}
+	bookUC := NewBookUseCase(cfg, apiLogger, catalogProvider, bookRedisRepository)
+
+	ctx := context.Background()
+	pagination := utils.NewPaginationQuery(10, 1)
+	books := []models.Book{{BookKey: "/works/OL66554W"}}
+
+	bookRedisRepository.EXPECT().GetBySubjectCtx(gomock.Any(), "love:0:10").Return(nil, redis.Nil)
+	catalogProvider.EXPECT().FindAllBySubject(gomock.Any(), "love", pagination).Return(books, nil)
+	bookRedisRepository.EXPECT().SetSubjectCtx(gomock.Any(), "love:0:10", 60, books).Return(nil)
+
+	foundBooks, err := bookUC.CachedFindAllBySubject(ctx, "love", pagination)
+	require.NoError(t, err)
+	require.Equal(t, books, foundBooks)
+
+	bookRedisRepository.EXPECT().GetBySubjectCtx(gomock.Any(), "love:0:10").Return(books, nil)
+
+	foundBooks, err = bookUC.CachedFindAllBySubject(ctx, "love", pagination)
+	require.NoError(t, err)
+	require.Equal(t, books, foundBooks)
+}
+
+func TestBookUseCase_CachedCountBySubject(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	catalogProvider := mock.NewMockCatalogProvider(ctrl)
+	bookRedisRepository := mock.NewMockBookRedisRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{Catalog: config.Catalog{CacheDuration: 60}}
+	bookUC := NewBookUseCase(cfg, apiLogger, catalogProvider, bookRedisRepository)
+
+	ctx := context.Background()
+
+	bookRedisRepository.EXPECT().GetSubjectCountCtx(gomock.Any(), "love").Return(0, redis.Nil)
+	catalogProvider.EXPECT().CountBySubject(gomock.Any(), "Love").Return(42, nil)
+	bookRedisRepository.EXPECT().SetSubjectCountCtx(gomock.Any(), "love", 60, 42).Return(nil)
+
+	count, err := bookUC.CachedCountBySubject(ctx, "Love")
+	require.NoError(t, err)
+	require.Equal(t, 42, count)
+
+	bookRedisRepository.EXPECT().GetSubjectCountCtx(gomock.Any(), "love").Return(42, nil)
+
+	count, err = bookUC.CachedCountBySubject(ctx, "Love")
+	require.NoError(t, err)
+	require.Equal(t, 42, count)
+}
+
+func TestBookUseCase_CachedFindByWork(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	catalogProvider := mock.NewMockCatalogProvider(ctrl)
+	bookRedisRepository := mock.NewMockBookRedisRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{}
+	bookUC := NewBookUseCase(cfg, apiLogger, catalogProvider, bookRedisRepository)
+
+	ctx := context.Background()
+	mockBook := &models.Book{BookKey: "/works/OL66554W", Title: "Pride and Prejudice"}
+
+	t.Run("Cache miss", func(t *testing.T) {
+		bookRedisRepository.EXPECT().GetByWorkCtx(gomock.Any(), "works/OL66554W").Return(nil, redis.Nil)
+		catalogProvider.EXPECT().FindByWork(gomock.Any(), mockBook.BookKey).Return(mockBook, nil)
+		bookRedisRepository.EXPECT().SetBookCtx(gomock.Any(), "works/OL66554W", bookByIdCacheDuration, mockBook).Return(nil)
+
+		book, err := bookUC.CachedFindByWork(ctx, mockBook.BookKey)
+		require.NoError(t, err)
+		require.Equal(t, mockBook.Title, book.Title)
+	})
+
+	t.Run("Cache hit", func(t *testing.T) {
+		bookRedisRepository.EXPECT().GetByWorkCtx(gomock.Any(), "works/OL66554W").Return(mockBook, nil)
+
+		book, err := bookUC.CachedFindByWork(ctx, mockBook.BookKey)
+		require.NoError(t, err)
+		require.Equal(t, mockBook.Title, book.Title)
+	})
+
+	t.Run("Negative cache", func(t *testing.T) {
+		bookRedisRepository.EXPECT().GetByWorkCtx(gomock.Any(), "works/OL0W").Return(nil, redis.Nil)
+		catalogProvider.EXPECT().FindByWork(gomock.Any(), "/works/OL0W").Return(nil, grpc_errors.ErrNotFound)
+		bookRedisRepository.EXPECT().SetBookNotFoundCtx(gomock.Any(), "works/OL0W", bookNotFoundCacheDuration).Return(nil)
+
+		_, err := bookUC.CachedFindByWork(ctx, "/works/OL0W")
+		require.True(t, errors.Is(err, grpc_errors.ErrNotFound))
+
+		bookRedisRepository.EXPECT().GetByWorkCtx(gomock.Any(), "works/OL0W").Return(nil, grpc_errors.ErrNotFound)
+
+		_, err = bookUC.CachedFindByWork(ctx, "/works/OL0W")
+		require.True(t, errors.Is(err, grpc_errors.ErrNotFound))
+	})
+}
@@ -0,0 +1,9 @@
+package dto
+
+import "github.com/google/uuid"
+
+type FineBalanceResponseDto struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Balance  int64     `json:"balance"`
+	Currency string    `json:"currency"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+)
+
+type FineResponseDto struct {
+	FineID      uuid.UUID  `json:"fine_id"`
+	OrderID     uuid.UUID  `json:"order_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	LibrarianID *uuid.UUID `json:"librarian_id"`
+	Kind        string     `json:"kind"`
+	Amount      int64      `json:"amount"`
+	Status      string     `json:"status"`
+	Note        *string    `json:"note"`
+	SettledAt   *time.Time `json:"settled_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func FineResponseFromModel(fine *models.Fine) *FineResponseDto {
+	return &FineResponseDto{
+		FineID:      fine.FineID,
+		OrderID:     fine.OrderID,
+		UserID:      fine.UserID,
+		LibrarianID: fine.LibrarianID,
+		Kind:        fine.Kind,
+		Amount:      fine.Amount,
+		Status:      fine.Status,
+		Note:        fine.Note,
+		SettledAt:   fine.SettledAt,
+		CreatedAt:   fine.CreatedAt,
+		UpdatedAt:   fine.UpdatedAt,
+	}
+}
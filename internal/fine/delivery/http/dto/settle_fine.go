@@ -0,0 +1,9 @@
+package dto
+
+type FineWaiveRequestDto struct {
+	Note string `json:"note" validate:"required,lte=256"`
+}
+
+type FinePayRequestDto struct {
+	Note *string `json:"note" validate:"omitempty,lte=256"`
+}
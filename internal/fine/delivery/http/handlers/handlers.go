@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/fine"
+	"github.com/dinorain/pinjembuku/internal/fine/delivery/http/dto"
+	"github.com/dinorain/pinjembuku/internal/middlewares"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/constants"
+	httpErrors "github.com/dinorain/pinjembuku/pkg/http_errors"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+type fineHandlersHTTP struct {
+	group  *echo.Group
+	logger logger.Logger
+	cfg    *config.Config
+	mw     middlewares.MiddlewareManager
+	v      *validator.Validate
+	fineUC fine.FineUseCase
+}
+
+var _ fine.FineHandlers = (*fineHandlersHTTP)(nil)
+
+func NewFineHandlersHTTP(
+	group *echo.Group,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	v *validator.Validate,
+	fineUC fine.FineUseCase,
+) *fineHandlersHTTP {
+	return &fineHandlersHTTP{group: group, logger: logger, cfg: cfg, mw: mw, v: v, fineUC: fineUC}
+}
+
+// FindAll
+// @Tags Fines
+// @Summary Find all fines
+// @Description Users find their own fines, staff find all fines or those of one user
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param user_id query string false "user id, staff only"
+// @Param size query string false "pagination size"
+// @Param page query string false "pagination page"
+// @Success 200 {object} dto.FineFindResponseDto
+// @Router /fine [get]
+func (h *fineHandlersHTTP) FindAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
+
+		userUUID, err := h.getTargetUserID(c)
+		if err != nil {
+			h.logger.WarnMsg("getTargetUserID", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		var fines []models.Fine
+		var totalCount int
+		if userUUID != uuid.Nil {
+			if res, err := h.fineUC.FindAllByUserId(ctx, userUUID, pq); err != nil {
+				h.logger.Errorf("fineUC.FindAllByUserId: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			} else {
+				fines = res
+			}
+			if count, err := h.fineUC.CountAllByUserId(ctx, userUUID); err != nil {
+				h.logger.Errorf("fineUC.CountAllByUserId: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			} else {
+				totalCount = count
+			}
+		} else {
+			if res, err := h.fineUC.FindAll(ctx, pq); err != nil {
+				h.logger.Errorf("fineUC.FindAll: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			} else {
+				fines = res
+			}
+			if count, err := h.fineUC.CountAll(ctx); err != nil {
+				h.logger.Errorf("fineUC.CountAll: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			} else {
+				totalCount = count
+			}
+		}
+
+		return c.JSON(http.StatusOK, dto.FineFindResponseDto{
+			Data: fines,
+			Meta: utils.NewPaginationMetaDto(pq, totalCount),
+		})
+	}
+}
+
+// Balance
+// @Tags Fines
+// @Summary Outstanding balance
+// @Description Users get their own outstanding balance, staff must pass user_id
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param user_id query string false "user id, staff only"
+// @Success 200 {object} dto.FineBalanceResponseDto
+// @Router /fine/balance [get]
+func (h *fineHandlersHTTP) Balance() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		userUUID, err := h.getTargetUserID(c)
+		if err != nil {
+			h.logger.WarnMsg("getTargetUserID", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+		if userUUID == uuid.Nil {
+			return httpErrors.NewBadRequestError(c, "user_id is required", h.cfg.Http.DebugErrorsResponse)
+		}
+
+		balance, err := h.fineUC.BalanceByUserId(ctx, userUUID)
+		if err != nil {
+			h.logger.Errorf("fineUC.BalanceByUserId: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, dto.FineBalanceResponseDto{
+			UserID:   userUUID,
+			Balance:  balance,
+			Currency: h.cfg.Fine.Currency,
+		})
+	}
+}
+
+// WaiveById
+// @Tags Fines
+// @Summary Waive fine
+// @Description Librarian waive outstanding fine, a note is required
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Fine ID"
+// @Param payload body dto.FineWaiveRequestDto true "Payload"
+// @Success 200 {object} dto.FineResponseDto
+// @Router /fine/{id}/waive [post]
+func (h *fineHandlersHTTP) WaiveById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		fineUUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		waiveDto := &dto.FineWaiveRequestDto{}
+		if err := c.Bind(waiveDto); err != nil {
+			h.logger.WarnMsg("bind", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.v.StructCtx(ctx, waiveDto); err != nil {
+			h.logger.WarnMsg("validate", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		fine, err := h.fineUC.WaiveById(ctx, fineUUID, principal.ID, waiveDto.Note)
+		if err != nil {
+			h.logger.Errorf("fineUC.WaiveById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, dto.FineResponseFromModel(fine))
+	}
+}
+
+// PayById
+// @Tags Fines
+// @Summary Record payment
+// @Description Librarian record full payment of outstanding fine
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Fine ID"
+// @Param payload body dto.FinePayRequestDto false "Payload"
+// @Success 200 {object} dto.FineResponseDto
+// @Router /fine/{id}/pay [post]
+func (h *fineHandlersHTTP) PayById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		fineUUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		payDto := &dto.FinePayRequestDto{}
+		if err := c.Bind(payDto); err != nil {
+			h.logger.WarnMsg("bind", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.v.StructCtx(ctx, payDto); err != nil {
+			h.logger.WarnMsg("validate", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		fine, err := h.fineUC.PayById(ctx, fineUUID, principal.ID, payDto.Note)
+		if err != nil {
+			h.logger.Errorf("fineUC.PayById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, dto.FineResponseFromModel(fine))
+	}
+}
+
+// getTargetUserID returns the caller for users, the user_id query param (possibly uuid.Nil) for staff
+func (h *fineHandlersHTTP) getTargetUserID(c echo.Context) (uuid.UUID, error) {
+	principal, err := middlewares.PrincipalFromCtx(c)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if principal.Role == models.UserRoleUser {
+		return principal.ID, nil
+	}
+
+	if userID := c.QueryParam(constants.UserID); userID != "" {
+		return uuid.Parse(userID)
+	}
+	return uuid.Nil, nil
+}
@@ -0,0 +1,10 @@
+package handlers
+
+func (h *fineHandlersHTTP) FineMapRoutes() {
+	h.group.Use(h.mw.IsLoggedIn())
+	h.group.GET("", h.FindAll())
+	h.group.GET("/balance", h.Balance())
+
+	h.group.POST("/:id/waive", h.WaiveById(), h.mw.IsLibrarian)
+	h.group.POST("/:id/pay", h.PayById(), h.mw.IsLibrarian)
+}
@@ -0,0 +1,11 @@
+package fine
+
+import "github.com/labstack/echo/v4"
+
+// Fine HTTP Handlers interface
+type FineHandlers interface {
+	FindAll() echo.HandlerFunc
+	Balance() echo.HandlerFunc
+	WaiveById() echo.HandlerFunc
+	PayById() echo.HandlerFunc
+}
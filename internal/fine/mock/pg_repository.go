@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/pinjembuku/internal/models"
+	utils "github.com/dinorain/pinjembuku/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockFinePGRepository is a mock of FinePGRepository interface.
+type MockFinePGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFinePGRepositoryMockRecorder
+}
+
+// MockFinePGRepositoryMockRecorder is the mock recorder for MockFinePGRepository.
+type MockFinePGRepositoryMockRecorder struct {
+	mock *MockFinePGRepository
+}
+
+// NewMockFinePGRepository creates a new mock instance.
+func NewMockFinePGRepository(ctrl *gomock.Controller) *MockFinePGRepository {
+	mock := &MockFinePGRepository{ctrl: ctrl}
+	mock.recorder = &MockFinePGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFinePGRepository) EXPECT() *MockFinePGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountAll mocks base method.
+func (m *MockFinePGRepository) CountAll(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockFinePGRepositoryMockRecorder) CountAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockFinePGRepository)(nil).CountAll), ctx)
+}
+
+// CountAllByUserId mocks base method.
+func (m *MockFinePGRepository) CountAllByUserId(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllByUserId", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllByUserId indicates an expected call of CountAllByUserId.
+func (mr *MockFinePGRepositoryMockRecorder) CountAllByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllByUserId", reflect.TypeOf((*MockFinePGRepository)(nil).CountAllByUserId), ctx, userID)
+}
+
+// Create mocks base method.
+func (m *MockFinePGRepository) Create(ctx context.Context, fine *models.Fine) (*models.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, fine)
+	ret0, _ := ret[0].(*models.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFinePGRepositoryMockRecorder) Create(ctx, fine interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFinePGRepository)(nil).Create), ctx, fine)
+}
+
+// FindAll mocks base method.
+func (m *MockFinePGRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret0, _ := ret[0].([]models.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockFinePGRepositoryMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFinePGRepository)(nil).FindAll), ctx, pagination)
+}
+
+// FindAllByOrderId mocks base method.
+func (m *MockFinePGRepository) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByOrderId", ctx, orderID)
+	ret0, _ := ret[0].([]models.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByOrderId indicates an expected call of FindAllByOrderId.
+func (mr *MockFinePGRepositoryMockRecorder) FindAllByOrderId(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByOrderId", reflect.TypeOf((*MockFinePGRepository)(nil).FindAllByOrderId), ctx, orderID)
+}
+
+// FindAllByUserId mocks base method.
+func (m *MockFinePGRepository) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByUserId", ctx, userID, pagination)
+	ret0, _ := ret[0].([]models.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByUserId indicates an expected call of FindAllByUserId.
+func (mr *MockFinePGRepositoryMockRecorder) FindAllByUserId(ctx, userID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserId", reflect.TypeOf((*MockFinePGRepository)(nil).FindAllByUserId), ctx, userID, pagination)
+}
+
+// FindById mocks base method.
+func (m *MockFinePGRepository) FindById(ctx context.Context, fineID uuid.UUID) (*models.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, fineID)
+	ret0, _ := ret[0].(*models.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockFinePGRepositoryMockRecorder) FindById(ctx, fineID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockFinePGRepository)(nil).FindById), ctx, fineID)
+}
+
+// SettleById mocks base method.
+func (m *MockFinePGRepository) SettleById(ctx context.Context, fineID uuid.UUID, status string, librarianID uuid.UUID, note *string) (*models.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleById", ctx, fineID, status, librarianID, note)
+	ret0, _ := ret[0].(*models.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettleById indicates an expected call of SettleById.
+func (mr *MockFinePGRepositoryMockRecorder) SettleById(ctx, fineID, status, librarianID, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleById", reflect.TypeOf((*MockFinePGRepository)(nil).SettleById), ctx, fineID, status, librarianID, note)
+}
+
+// SumOutstandingByUserId mocks base method.
+func (m *MockFinePGRepository) SumOutstandingByUserId(ctx context.Context, userID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumOutstandingByUserId", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumOutstandingByUserId indicates an expected call of SumOutstandingByUserId.
+func (mr *MockFinePGRepositoryMockRecorder) SumOutstandingByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumOutstandingByUserId", reflect.TypeOf((*MockFinePGRepository)(nil).SumOutstandingByUserId), ctx, userID)
+}
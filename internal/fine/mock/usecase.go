@@ -0,0 +1,203 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/pinjembuku/internal/models"
+	utils "github.com/dinorain/pinjembuku/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockFineUseCase is a mock of FineUseCase interface.
+type MockFineUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockFineUseCaseMockRecorder
+}
+
+// MockFineUseCaseMockRecorder is the mock recorder for MockFineUseCase.
+type MockFineUseCaseMockRecorder struct {
+	mock *MockFineUseCase
+}
+
+// NewMockFineUseCase creates a new mock instance.
+func NewMockFineUseCase(ctrl *gomock.Controller) *MockFineUseCase {
+	mock := &MockFineUseCase{ctrl: ctrl}
+	mock.recorder = &MockFineUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFineUseCase) EXPECT() *MockFineUseCaseMockRecorder {
+	return m.recorder
+}
+
+// BalanceByUserId mocks base method.
+func (m *MockFineUseCase) BalanceByUserId(ctx context.Context, userID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalanceByUserId", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceByUserId indicates an expected call of BalanceByUserId.
+func (mr *MockFineUseCaseMockRecorder) BalanceByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceByUserId", reflect.TypeOf((*MockFineUseCase)(nil).BalanceByUserId), ctx, userID)
+}
+
+// ChargeLost mocks base method.
+func (m *MockFineUseCase) ChargeLost(ctx context.Context, order *models.Order) (*models.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeLost", ctx, order)
+	ret0, _ := ret[0].(*models.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargeLost indicates an expected call of ChargeLost.
+func (mr *MockFineUseCaseMockRecorder) ChargeLost(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeLost", reflect.TypeOf((*MockFineUseCase)(nil).ChargeLost), ctx, order)
+}
+
+// ChargeOverdue mocks base method.
+func (m *MockFineUseCase) ChargeOverdue(ctx context.Context, order *models.Order) (*models.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeOverdue", ctx, order)
+	ret0, _ := ret[0].(*models.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargeOverdue indicates an expected call of ChargeOverdue.
+func (mr *MockFineUseCaseMockRecorder) ChargeOverdue(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeOverdue", reflect.TypeOf((*MockFineUseCase)(nil).ChargeOverdue), ctx, order)
+}
+
+// ChargeReturnedLost mocks base method.
+func (m *MockFineUseCase) ChargeReturnedLost(ctx context.Context, order *models.Order, librarianID uuid.UUID) (*models.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeReturnedLost", ctx, order, librarianID)
+	ret0, _ := ret[0].(*models.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargeReturnedLost indicates an expected call of ChargeReturnedLost.
+func (mr *MockFineUseCaseMockRecorder) ChargeReturnedLost(ctx, order, librarianID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeReturnedLost", reflect.TypeOf((*MockFineUseCase)(nil).ChargeReturnedLost), ctx, order, librarianID)
+}
+
+// CountAll mocks base method.
+func (m *MockFineUseCase) CountAll(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockFineUseCaseMockRecorder) CountAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockFineUseCase)(nil).CountAll), ctx)
+}
+
+// CountAllByUserId mocks base method.
+func (m *MockFineUseCase) CountAllByUserId(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllByUserId", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllByUserId indicates an expected call of CountAllByUserId.
+func (mr *MockFineUseCaseMockRecorder) CountAllByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllByUserId", reflect.TypeOf((*MockFineUseCase)(nil).CountAllByUserId), ctx, userID)
+}
+
+// FindAll mocks base method.
+func (m *MockFineUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret0, _ := ret[0].([]models.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockFineUseCaseMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFineUseCase)(nil).FindAll), ctx, pagination)
+}
+
+// FindAllByUserId mocks base method.
+func (m *MockFineUseCase) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByUserId", ctx, userID, pagination)
+	ret0, _ := ret[0].([]models.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByUserId indicates an expected call of FindAllByUserId.
+func (mr *MockFineUseCaseMockRecorder) FindAllByUserId(ctx, userID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserId", reflect.TypeOf((*MockFineUseCase)(nil).FindAllByUserId), ctx, userID, pagination)
+}
+
+// FindById mocks base method.
+func (m *MockFineUseCase) FindById(ctx context.Context, fineID uuid.UUID) (*models.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, fineID)
+	ret0, _ := ret[0].(*models.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockFineUseCaseMockRecorder) FindById(ctx, fineID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockFineUseCase)(nil).FindById), ctx, fineID)
+}
+
+// PayById mocks base method.
+func (m *MockFineUseCase) PayById(ctx context.Context, fineID, librarianID uuid.UUID, note *string) (*models.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayById", ctx, fineID, librarianID, note)
+	ret0, _ := ret[0].(*models.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayById indicates an expected call of PayById.
+func (mr *MockFineUseCaseMockRecorder) PayById(ctx, fineID, librarianID, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayById", reflect.TypeOf((*MockFineUseCase)(nil).PayById), ctx, fineID, librarianID, note)
+}
+
+// WaiveById mocks base method.
+func (m *MockFineUseCase) WaiveById(ctx context.Context, fineID, librarianID uuid.UUID, note string) (*models.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaiveById", ctx, fineID, librarianID, note)
+	ret0, _ := ret[0].(*models.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaiveById indicates an expected call of WaiveById.
+func (mr *MockFineUseCaseMockRecorder) WaiveById(ctx, fineID, librarianID, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaiveById", reflect.TypeOf((*MockFineUseCase)(nil).WaiveById), ctx, fineID, librarianID, note)
+}
@@ -0,0 +1,24 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package fine
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+// Fine pg repository
+type FinePGRepository interface {
+	Create(ctx context.Context, fine *models.Fine) (*models.Fine, error)
+	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Fine, error)
+	CountAll(ctx context.Context) (int, error)
+	FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Fine, error)
+	CountAllByUserId(ctx context.Context, userID uuid.UUID) (int, error)
+	FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Fine, error)
+	FindById(ctx context.Context, fineID uuid.UUID) (*models.Fine, error)
+	SumOutstandingByUserId(ctx context.Context, userID uuid.UUID) (int64, error)
+	SettleById(ctx context.Context, fineID uuid.UUID, status string, librarianID uuid.UUID, note *string) (*models.Fine, error)
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/internal/fine"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+// Fine repository
+type FineRepository struct {
+	db *sqlx.DB
+}
+
+var _ fine.FinePGRepository = (*FineRepository)(nil)
+
+// Fine repository constructor
+func NewFinePGRepository(db *sqlx.DB) *FineRepository {
+	return &FineRepository{db: db}
+}
+
+// Create new fine, the amount is added to an outstanding fine of the same order and kind
+func (r *FineRepository) Create(ctx context.Context, fine *models.Fine) (*models.Fine, error) {
+	createdFine := &models.Fine{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createFineQuery,
+		fine.OrderID,
+		fine.UserID,
+		fine.Kind,
+		fine.Amount,
+		fine.Status,
+	).StructScan(createdFine); err != nil {
+		return nil, errors.Wrap(err, "FinePGRepository.Create.QueryRowxContext")
+	}
+
+	return createdFine, nil
+}
+
+// FindAll Find fines, newest first
+func (r *FineRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Fine, error) {
+	var fines []models.Fine
+	if err := r.db.SelectContext(ctx, &fines, findAllQuery, pagination.GetLimit(), pagination.GetOffset()); err != nil {
+		return nil, errors.Wrap(err, "FinePGRepository.FindAll.SelectContext")
+	}
+
+	return fines, nil
+}
+
+// CountAll Count fines
+func (r *FineRepository) CountAll(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, countAllQuery); err != nil {
+		return 0, errors.Wrap(err, "FinePGRepository.CountAll.GetContext")
+	}
+
+	return count, nil
+}
+
+// FindAllByUserId Find fines of user, newest first
+func (r *FineRepository) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Fine, error) {
+	var fines []models.Fine
+	if err := r.db.SelectContext(ctx, &fines, findAllByUserIdQuery, userID, pagination.GetLimit(), pagination.GetOffset()); err != nil {
+		return nil, errors.Wrap(err, "FinePGRepository.FindAllByUserId.SelectContext")
+	}
+
+	return fines, nil
+}
+
+// FindAllByOrderId Find fines of order, oldest first
+func (r *FineRepository) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Fine, error) {
+	var fines []models.Fine
+	if err := r.db.SelectContext(ctx, &fines, findAllByOrderIdQuery, orderID); err != nil {
+		return nil, errors.Wrap(err, "FinePGRepository.FindAllByOrderId.SelectContext")
+	}
+
+	return fines, nil
+}
+
+// CountAllByUserId Count fines of user
+func (r *FineRepository) CountAllByUserId(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, countAllByUserIdQuery, userID); err != nil {
+		return 0, errors.Wrap(err, "FinePGRepository.CountAllByUserId.GetContext")
+	}
+
+	return count, nil
+}
+
+// FindById Find fine by uuid
+func (r *FineRepository) FindById(ctx context.Context, fineID uuid.UUID) (*models.Fine, error) {
+	fine := &models.Fine{}
+	if err := r.db.GetContext(ctx, fine, findByIdQuery, fineID); err != nil {
+		return nil, errors.Wrap(err, "FinePGRepository.FindById.GetContext")
+	}
+
+	return fine, nil
+}
+
+// SumOutstandingByUserId Sum amounts of outstanding fines of user
+func (r *FineRepository) SumOutstandingByUserId(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var balance int64
+	if err := r.db.GetContext(ctx, &balance, sumOutstandingByUserIdQuery, userID); err != nil {
+		return 0, errors.Wrap(err, "FinePGRepository.SumOutstandingByUserId.GetContext")
+	}
+
+	return balance, nil
+}
+
+// SettleById move outstanding fine to paid or waived
+func (r *FineRepository) SettleById(ctx context.Context, fineID uuid.UUID, status string, librarianID uuid.UUID, note *string) (*models.Fine, error) {
+	settledFine := &models.Fine{}
+	if err := r.db.QueryRowxContext(ctx, settleByIdQuery, fineID, status, librarianID, note).StructScan(settledFine); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.Wrap(grpc_errors.ErrInvalidStatus, "fine is not outstanding")
+		}
+		return nil, errors.Wrap(err, "FinePGRepository.SettleById.QueryRowxContext")
+	}
+
+	return settledFine, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+)
+
+var fineColumns = []string{"fine_id", "order_id", "user_id", "librarian_id", "kind", "amount", "status", "note", "settled_at", "created_at", "updated_at"}
+
+func TestFineRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	finePGRepository := NewFinePGRepository(sqlxDB)
+
+	fineUUID := uuid.New()
+	mockFine := &models.Fine{OrderID: uuid.New(), UserID: uuid.New(), Kind: models.FineKindOverdue, Amount: 1000, Status: models.FineStatusOutstanding}
+
+	// the second charge of the order lands on the outstanding fine of the first
+	for _, amount := range []int64{1000, 2000} {
+		rows := sqlmock.NewRows(fineColumns).AddRow(
+			fineUUID, mockFine.OrderID, mockFine.UserID, nil, mockFine.Kind, amount, mockFine.Status, nil, nil, time.Now(), time.Now(),
+		)
+		mock.ExpectQuery(createFineQuery).WithArgs(mockFine.OrderID, mockFine.UserID, mockFine.Kind, mockFine.Amount, mockFine.Status).WillReturnRows(rows)
+
+		createdFine, err := finePGRepository.Create(context.Background(), mockFine)
+		require.NoError(t, err)
+		require.Equal(t, fineUUID, createdFine.FineID)
+		require.Equal(t, amount, createdFine.Amount)
+	}
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFineRepository_SumOutstandingByUserId(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	finePGRepository := NewFinePGRepository(sqlxDB)
+
+	userUUID := uuid.New()
+	mock.ExpectQuery(sumOutstandingByUserIdQuery).WithArgs(userUUID).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(4500)))
+
+	balance, err := finePGRepository.SumOutstandingByUserId(context.Background(), userUUID)
+	require.NoError(t, err)
+	require.Equal(t, int64(4500), balance)
+}
+
+func TestFineRepository_SettleById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	finePGRepository := NewFinePGRepository(sqlxDB)
+
+	fineUUID := uuid.New()
+	librarianUUID := uuid.New()
+
+	t.Run("Outstanding", func(t *testing.T) {
+		rows := sqlmock.NewRows(fineColumns).AddRow(
+			fineUUID, uuid.New(), uuid.New(), librarianUUID, models.FineKindOverdue, int64(1000), models.FineStatusPaid, nil, time.Now(), time.Now(), time.Now(),
+		)
+		mock.ExpectQuery(settleByIdQuery).WithArgs(fineUUID, models.FineStatusPaid, librarianUUID, nil).WillReturnRows(rows)
+
+		fine, err := finePGRepository.SettleById(context.Background(), fineUUID, models.FineStatusPaid, librarianUUID, nil)
+		require.NoError(t, err)
+		require.Equal(t, models.FineStatusPaid, fine.Status)
+	})
+
+	t.Run("Not outstanding", func(t *testing.T) {
+		mock.ExpectQuery(settleByIdQuery).WithArgs(fineUUID, models.FineStatusPaid, librarianUUID, nil).WillReturnRows(sqlmock.NewRows(fineColumns))
+
+		_, err := finePGRepository.SettleById(context.Background(), fineUUID, models.FineStatusPaid, librarianUUID, nil)
+		require.True(t, errors.Is(err, grpc_errors.ErrInvalidStatus))
+	})
+}
@@ -0,0 +1,27 @@
+package repository
+
+const (
+	createFineQuery = `INSERT INTO fines (order_id, user_id, kind, amount, status) 
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (order_id, kind) WHERE status = 'outstanding'
+		DO UPDATE SET amount = fines.amount + EXCLUDED.amount, updated_at = CURRENT_TIMESTAMP
+		RETURNING fine_id, order_id, user_id, librarian_id, kind, amount, status, note, settled_at, created_at, updated_at`
+
+	findByIdQuery = `SELECT fine_id, order_id, user_id, librarian_id, kind, amount, status, note, settled_at, created_at, updated_at FROM fines WHERE fine_id = $1`
+
+	findAllQuery = `SELECT fine_id, order_id, user_id, librarian_id, kind, amount, status, note, settled_at, created_at, updated_at FROM fines ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+
+	findAllByUserIdQuery = `SELECT fine_id, order_id, user_id, librarian_id, kind, amount, status, note, settled_at, created_at, updated_at FROM fines WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
+
+	findAllByOrderIdQuery = `SELECT fine_id, order_id, user_id, librarian_id, kind, amount, status, note, settled_at, created_at, updated_at FROM fines WHERE order_id = $1 ORDER BY created_at`
+
+	sumOutstandingByUserIdQuery = `SELECT COALESCE(SUM(amount), 0) FROM fines WHERE user_id = $1 AND status = 'outstanding'`
+
+	settleByIdQuery = `UPDATE fines SET status = $2, librarian_id = $3, note = $4, settled_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP 
+		WHERE fine_id = $1 AND status = 'outstanding'
+		RETURNING fine_id, order_id, user_id, librarian_id, kind, amount, status, note, settled_at, created_at, updated_at`
+
+	countAllQuery = `SELECT COUNT(*) FROM fines`
+
+	countAllByUserIdQuery = `SELECT COUNT(*) FROM fines WHERE user_id = $1`
+)
@@ -0,0 +1,26 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package fine
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+// Fine UseCase interface
+type FineUseCase interface {
+	ChargeOverdue(ctx context.Context, order *models.Order) (*models.Fine, error)
+	ChargeLost(ctx context.Context, order *models.Order) (*models.Fine, error)
+	ChargeReturnedLost(ctx context.Context, order *models.Order, librarianID uuid.UUID) (*models.Fine, error)
+	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Fine, error)
+	CountAll(ctx context.Context) (int, error)
+	FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Fine, error)
+	CountAllByUserId(ctx context.Context, userID uuid.UUID) (int, error)
+	FindById(ctx context.Context, fineID uuid.UUID) (*models.Fine, error)
+	BalanceByUserId(ctx context.Context, userID uuid.UUID) (int64, error)
+	WaiveById(ctx context.Context, fineID uuid.UUID, librarianID uuid.UUID, note string) (*models.Fine, error)
+	PayById(ctx context.Context, fineID uuid.UUID, librarianID uuid.UUID, note *string) (*models.Fine, error)
+}
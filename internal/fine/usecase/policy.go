@@ -0,0 +1,44 @@
+package usecase
+
+import "time"
+
+const (
+	defaultOverduePerDay = 1000
+	defaultLostCharge    = 150000
+)
+
+// overdueDays count every started day between due and return
+func overdueDays(dueAt time.Time, returnedAt time.Time) int64 {
+	late := returnedAt.Sub(dueAt)
+	if late <= 0 {
+		return 0
+	}
+
+	days := int64(late / (24 * time.Hour))
+	if late%(24*time.Hour) != 0 {
+		days++
+	}
+	return days
+}
+
+// overdueAmount per-day fine for a late return, capped at cfg.Fine.OverdueCap when set
+func (u *fineUseCase) overdueAmount(dueAt time.Time, returnedAt time.Time) int64 {
+	perDay := u.cfg.Fine.OverduePerDay
+	if perDay <= 0 {
+		perDay = defaultOverduePerDay
+	}
+
+	amount := overdueDays(dueAt, returnedAt) * perDay
+	if u.cfg.Fine.OverdueCap > 0 && amount > u.cfg.Fine.OverdueCap {
+		amount = u.cfg.Fine.OverdueCap
+	}
+	return amount
+}
+
+// lostAmount flat charge for a lost item
+func (u *fineUseCase) lostAmount() int64 {
+	if u.cfg.Fine.LostCharge > 0 {
+		return u.cfg.Fine.LostCharge
+	}
+	return defaultLostCharge
+}
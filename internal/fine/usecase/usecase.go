@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/fine"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+const lostReturnedNote = "lost item returned"
+
+// Fine UseCase
+type fineUseCase struct {
+	cfg        *config.Config
+	logger     logger.Logger
+	finePgRepo fine.FinePGRepository
+}
+
+var _ fine.FineUseCase = (*fineUseCase)(nil)
+
+// New Fine UseCase
+func NewFineUseCase(cfg *config.Config, logger logger.Logger, fineRepo fine.FinePGRepository) *fineUseCase {
+	return &fineUseCase{cfg: cfg, logger: logger, finePgRepo: fineRepo}
+}
+
+// ChargeOverdue fine order returned after its due date, returns nil when order was on time. Charges for the same
+// order add up while its overdue fine is outstanding
+func (u *fineUseCase) ChargeOverdue(ctx context.Context, order *models.Order) (*models.Fine, error) {
+	if order.DueAt == nil || order.ReturnedAt == nil {
+		return nil, nil
+	}
+
+	amount := u.overdueAmount(*order.DueAt, *order.ReturnedAt)
+	if amount <= 0 {
+		return nil, nil
+	}
+
+	return u.create(ctx, order, models.FineKindOverdue, amount)
+}
+
+// ChargeLost create fine for order whose item was lost
+func (u *fineUseCase) ChargeLost(ctx context.Context, order *models.Order) (*models.Fine, error) {
+	return u.create(ctx, order, models.FineKindLost, u.lostAmount())
+}
+
+// ChargeReturnedLost replace the lost fine of order which turned up after all with an overdue fine. Outstanding lost
+// fines are waived, a lost fine already paid covers the late return
+func (u *fineUseCase) ChargeReturnedLost(ctx context.Context, order *models.Order, librarianID uuid.UUID) (*models.Fine, error) {
+	fines, err := u.finePgRepo.FindAllByOrderId(ctx, order.OrderID)
+	if err != nil {
+		return nil, errors.Wrap(err, "finePgRepo.FindAllByOrderId")
+	}
+
+	lostPaid := false
+	for _, orderFine := range fines {
+		if orderFine.Kind != models.FineKindLost {
+			continue
+		}
+
+		switch orderFine.Status {
+		case models.FineStatusPaid:
+			lostPaid = true
+		case models.FineStatusOutstanding:
+			note := lostReturnedNote
+			if _, err := u.finePgRepo.SettleById(ctx, orderFine.FineID, models.FineStatusWaived, librarianID, &note); err != nil {
+				return nil, errors.Wrap(err, "finePgRepo.SettleById")
+			}
+		}
+	}
+
+	if lostPaid {
+		return nil, nil
+	}
+
+	return u.ChargeOverdue(ctx, order)
+}
+
+// FindAll find fines
+func (u *fineUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Fine, error) {
+	fines, err := u.finePgRepo.FindAll(ctx, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "finePgRepo.FindAll")
+	}
+
+	return fines, nil
+}
+
+// CountAll count fines
+func (u *fineUseCase) CountAll(ctx context.Context) (int, error) {
+	count, err := u.finePgRepo.CountAll(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "finePgRepo.CountAll")
+	}
+
+	return count, nil
+}
+
+// FindAllByUserId find fines of user
+func (u *fineUseCase) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Fine, error) {
+	fines, err := u.finePgRepo.FindAllByUserId(ctx, userID, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "finePgRepo.FindAllByUserId")
+	}
+
+	return fines, nil
+}
+
+// CountAllByUserId count fines of user
+func (u *fineUseCase) CountAllByUserId(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := u.finePgRepo.CountAllByUserId(ctx, userID)
+	if err != nil {
+		return 0, errors.Wrap(err, "finePgRepo.CountAllByUserId")
+	}
+
+	return count, nil
+}
+
+// FindById find fine by uuid
+func (u *fineUseCase) FindById(ctx context.Context, fineID uuid.UUID) (*models.Fine, error) {
+	foundFine, err := u.finePgRepo.FindById(ctx, fineID)
+	if err != nil {
+		return nil, errors.Wrap(err, "finePgRepo.FindById")
+	}
+
+	return foundFine, nil
+}
+
+// BalanceByUserId sum outstanding fines of user
+func (u *fineUseCase) BalanceByUserId(ctx context.Context, userID uuid.UUID) (int64, error) {
+	balance, err := u.finePgRepo.SumOutstandingByUserId(ctx, userID)
+	if err != nil {
+		return 0, errors.Wrap(err, "finePgRepo.SumOutstandingByUserId")
+	}
+
+	return balance, nil
+}
+
+// WaiveById librarian waive outstanding fine
+func (u *fineUseCase) WaiveById(ctx context.Context, fineID uuid.UUID, librarianID uuid.UUID, note string) (*models.Fine, error) {
+	return u.settle(ctx, fineID, models.FineStatusWaived, librarianID, &note)
+}
+
+// PayById librarian record payment of outstanding fine
+func (u *fineUseCase) PayById(ctx context.Context, fineID uuid.UUID, librarianID uuid.UUID, note *string) (*models.Fine, error) {
+	return u.settle(ctx, fineID, models.FineStatusPaid, librarianID, note)
+}
+
+func (u *fineUseCase) create(ctx context.Context, order *models.Order, kind string, amount int64) (*models.Fine, error) {
+	createdFine, err := u.finePgRepo.Create(ctx, &models.Fine{
+		OrderID: order.OrderID,
+		UserID:  order.UserID,
+		Kind:    kind,
+		Amount:  amount,
+		Status:  models.FineStatusOutstanding,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "finePgRepo.Create")
+	}
+
+	return createdFine, nil
+}
+
+func (u *fineUseCase) settle(ctx context.Context, fineID uuid.UUID, status string, librarianID uuid.UUID, note *string) (*models.Fine, error) {
+	if _, err := u.finePgRepo.FindById(ctx, fineID); err != nil {
+		return nil, errors.Wrap(err, "finePgRepo.FindById")
+	}
+
+	settledFine, err := u.finePgRepo.SettleById(ctx, fineID, status, librarianID, note)
+	if err != nil {
+		return nil, errors.Wrap(err, "finePgRepo.SettleById")
+	}
+
+	return settledFine, nil
+}
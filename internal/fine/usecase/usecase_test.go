@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/fine/mock"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+)
+
+func TestOverdueDays(t *testing.T) {
+	t.Parallel()
+
+	dueAt := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, int64(0), overdueDays(dueAt, dueAt.Add(-time.Hour)))
+	require.Equal(t, int64(0), overdueDays(dueAt, dueAt))
+	require.Equal(t, int64(1), overdueDays(dueAt, dueAt.Add(time.Minute)))
+	require.Equal(t, int64(1), overdueDays(dueAt, dueAt.Add(24*time.Hour)))
+	require.Equal(t, int64(2), overdueDays(dueAt, dueAt.Add(25*time.Hour)))
+}
+
+func TestFineUseCase_ChargeOverdue(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	finePGRepository := mock.NewMockFinePGRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+	cfg := &config.Config{Fine: config.Fine{OverduePerDay: 500, OverdueCap: 2000}}
+
+	fineUC := NewFineUseCase(cfg, apiLogger, finePGRepository)
+
+	ctx := context.Background()
+	dueAt := time.Now().AddDate(0, 0, -10)
+
+	t.Run("On time", func(t *testing.T) {
+		returnedAt := dueAt.Add(-time.Hour)
+		fine, err := fineUC.ChargeOverdue(ctx, &models.Order{OrderID: uuid.New(), DueAt: &dueAt, ReturnedAt: &returnedAt})
+		require.NoError(t, err)
+		require.Nil(t, fine)
+	})
+
+	t.Run("Late", func(t *testing.T) {
+		returnedAt := dueAt.AddDate(0, 0, 3)
+		order := &models.Order{OrderID: uuid.New(), UserID: uuid.New(), DueAt: &dueAt, ReturnedAt: &returnedAt}
+		expected := &models.Fine{OrderID: order.OrderID, UserID: order.UserID, Kind: models.FineKindOverdue, Amount: 1500, Status: models.FineStatusOutstanding}
+		finePGRepository.EXPECT().Create(gomock.Any(), expected).Return(expected, nil)
+
+		fine, err := fineUC.ChargeOverdue(ctx, order)
+		require.NoError(t, err)
+		require.Equal(t, int64(1500), fine.Amount)
+	})
+
+	t.Run("Capped", func(t *testing.T) {
+		returnedAt := dueAt.AddDate(0, 0, 9)
+		order := &models.Order{OrderID: uuid.New(), UserID: uuid.New(), DueAt: &dueAt, ReturnedAt: &returnedAt}
+		expected := &models.Fine{OrderID: order.OrderID, UserID: order.UserID, Kind: models.FineKindOverdue, Amount: 2000, Status: models.FineStatusOutstanding}
+		finePGRepository.EXPECT().Create(gomock.Any(), expected).Return(expected, nil)
+
+		fine, err := fineUC.ChargeOverdue(ctx, order)
+		require.NoError(t, err)
+		require.Equal(t, int64(2000), fine.Amount)
+	})
+}
+
+func TestFineUseCase_ChargeReturnedLost(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	finePGRepository := mock.NewMockFinePGRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	fineUC := NewFineUseCase(&config.Config{Fine: config.Fine{OverduePerDay: 500, OverdueCap: 2000}}, apiLogger, finePGRepository)
+
+	ctx := context.Background()
+	librarianUUID := uuid.New()
+	dueAt := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	returnedAt := dueAt.AddDate(0, 0, 2)
+	note := lostReturnedNote
+
+	t.Run("Outstanding lost fine", func(t *testing.T) {
+		order := &models.Order{OrderID: uuid.New(), UserID: uuid.New(), DueAt: &dueAt, ReturnedAt: &returnedAt}
+		lostFine := models.Fine{FineID: uuid.New(), OrderID: order.OrderID, Kind: models.FineKindLost, Status: models.FineStatusOutstanding}
+		finePGRepository.EXPECT().FindAllByOrderId(gomock.Any(), order.OrderID).Return([]models.Fine{lostFine}, nil)
+		finePGRepository.EXPECT().SettleById(gomock.Any(), lostFine.FineID, models.FineStatusWaived, librarianUUID, &note).Return(&lostFine, nil)
+		expected := &models.Fine{OrderID: order.OrderID, UserID: order.UserID, Kind: models.FineKindOverdue, Amount: 1000, Status: models.FineStatusOutstanding}
+		finePGRepository.EXPECT().Create(gomock.Any(), expected).Return(expected, nil)
+
+		fine, err := fineUC.ChargeReturnedLost(ctx, order, librarianUUID)
+		require.NoError(t, err)
+		require.Equal(t, models.FineKindOverdue, fine.Kind)
+	})
+
+	t.Run("Paid lost fine", func(t *testing.T) {
+		order := &models.Order{OrderID: uuid.New(), UserID: uuid.New(), DueAt: &dueAt, ReturnedAt: &returnedAt}
+		lostFine := models.Fine{FineID: uuid.New(), OrderID: order.OrderID, Kind: models.FineKindLost, Status: models.FineStatusPaid}
+		finePGRepository.EXPECT().FindAllByOrderId(gomock.Any(), order.OrderID).Return([]models.Fine{lostFine}, nil)
+
+		fine, err := fineUC.ChargeReturnedLost(ctx, order, librarianUUID)
+		require.NoError(t, err)
+		require.Nil(t, fine)
+	})
+}
+
+func TestFineUseCase_WaiveById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	finePGRepository := mock.NewMockFinePGRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	fineUC := NewFineUseCase(&config.Config{}, apiLogger, finePGRepository)
+
+	ctx := context.Background()
+	librarianUUID := uuid.New()
+	note := "first offence"
+
+	t.Run("Outstanding", func(t *testing.T) {
+		mockFine := &models.Fine{FineID: uuid.New(), Status: models.FineStatusOutstanding}
+		finePGRepository.EXPECT().FindById(gomock.Any(), mockFine.FineID).Return(mockFine, nil)
+		finePGRepository.EXPECT().SettleById(gomock.Any(), mockFine.FineID, models.FineStatusWaived, librarianUUID, &note).
+			Return(&models.Fine{FineID: mockFine.FineID, Status: models.FineStatusWaived, LibrarianID: &librarianUUID, Note: &note}, nil)
+
+		fine, err := fineUC.WaiveById(ctx, mockFine.FineID, librarianUUID, note)
+		require.NoError(t, err)
+		require.Equal(t, models.FineStatusWaived, fine.Status)
+	})
+
+	t.Run("Already paid", func(t *testing.T) {
+		mockFine := &models.Fine{FineID: uuid.New(), Status: models.FineStatusPaid}
+		finePGRepository.EXPECT().FindById(gomock.Any(), mockFine.FineID).Return(mockFine, nil)
+		finePGRepository.EXPECT().SettleById(gomock.Any(), mockFine.FineID, models.FineStatusWaived, librarianUUID, &note).
+			Return(nil, errors.Wrap(grpc_errors.ErrInvalidStatus, "fine is not outstanding"))
+
+		_, err := fineUC.WaiveById(ctx, mockFine.FineID, librarianUUID, note)
+		require.True(t, errors.Is(err, grpc_errors.ErrInvalidStatus))
+	})
+}
@@ -0,0 +1,5 @@
+package dto
+
+type HoldCreateRequestDto struct {
+	BookKey string `json:"key" validate:"required,lte=64"`
+}
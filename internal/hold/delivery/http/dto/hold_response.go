@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+)
+
+type HoldResponseDto struct {
+	HoldID     uuid.UUID  `json:"hold_id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	BookKey    string     `json:"key"`
+	CopyID     *uuid.UUID `json:"copy_id"`
+	Status     string     `json:"status"`
+	ReadyUntil *time.Time `json:"ready_until"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func HoldResponseFromModel(hold *models.Hold) *HoldResponseDto {
+	return &HoldResponseDto{
+		HoldID:     hold.HoldID,
+		UserID:     hold.UserID,
+		BookKey:    hold.BookKey,
+		CopyID:     hold.CopyID,
+		Status:     hold.Status,
+		ReadyUntil: hold.ReadyUntil,
+		CreatedAt:  hold.CreatedAt,
+		UpdatedAt:  hold.UpdatedAt,
+	}
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/hold"
+	"github.com/dinorain/pinjembuku/internal/hold/delivery/http/dto"
+	"github.com/dinorain/pinjembuku/internal/middlewares"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/constants"
+	httpErrors "github.com/dinorain/pinjembuku/pkg/http_errors"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+type holdHandlersHTTP struct {
+	group  *echo.Group
+	logger logger.Logger
+	cfg    *config.Config
+	mw     middlewares.MiddlewareManager
+	v      *validator.Validate
+	holdUC hold.HoldUseCase
+}
+
+var _ hold.HoldHandlers = (*holdHandlersHTTP)(nil)
+
+func NewHoldHandlersHTTP(
+	group *echo.Group,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	v *validator.Validate,
+	holdUC hold.HoldUseCase,
+) *holdHandlersHTTP {
+	return &holdHandlersHTTP{group: group, logger: logger, cfg: cfg, mw: mw, v: v, holdUC: holdUC}
+}
+
+// Create
+// @Tags Holds
+// @Summary Place hold
+// @Description User join the queue of a book with no copy available
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param payload body dto.HoldCreateRequestDto true "Payload"
+// @Success 201 {object} dto.HoldResponseDto
+// @Router /hold [post]
+func (h *holdHandlersHTTP) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		createDto := &dto.HoldCreateRequestDto{}
+		if err := c.Bind(createDto); err != nil {
+			h.logger.WarnMsg("bind", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.v.StructCtx(ctx, createDto); err != nil {
+			h.logger.WarnMsg("validate", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		createdHold, err := h.holdUC.Place(ctx, principal.ID, createDto.BookKey)
+		if err != nil {
+			h.logger.Errorf("holdUC.Place: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusCreated, dto.HoldResponseFromModel(createdHold))
+	}
+}
+
+// FindAll
+// @Tags Holds
+// @Summary Find all holds
+// @Description Users find their own holds, staff find all holds or the queue of one book
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param key query string false "book key, staff only"
+// @Param size query string false "pagination size"
+// @Param page query string false "pagination page"
+// @Success 200 {object} dto.HoldFindResponseDto
+// @Router /hold [get]
+func (h *holdHandlersHTTP) FindAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
+
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		var holds []models.Hold
+		var totalCount int
+		if principal.Role == models.UserRoleUser {
+			if res, err := h.holdUC.FindAllByUserId(ctx, principal.ID, pq); err != nil {
+				h.logger.Errorf("holdUC.FindAllByUserId: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			} else {
+				holds = res
+			}
+			if count, err := h.holdUC.CountAllByUserId(ctx, principal.ID); err != nil {
+				h.logger.Errorf("holdUC.CountAllByUserId: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			} else {
+				totalCount = count
+			}
+		} else if bookKey := c.QueryParam(constants.Key); bookKey != "" {
+			if res, err := h.holdUC.FindAllActiveByBookKey(ctx, bookKey, pq); err != nil {
+				h.logger.Errorf("holdUC.FindAllActiveByBookKey: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			} else {
+				holds = res
+			}
+			if count, err := h.holdUC.CountAllActiveByBookKey(ctx, bookKey); err != nil {
+				h.logger.Errorf("holdUC.CountAllActiveByBookKey: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			} else {
+				totalCount = count
+			}
+		} else {
+			if res, err := h.holdUC.FindAll(ctx, pq); err != nil {
+				h.logger.Errorf("holdUC.FindAll: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			} else {
+				holds = res
+			}
+			if count, err := h.holdUC.CountAll(ctx); err != nil {
+				h.logger.Errorf("holdUC.CountAll: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			} else {
+				totalCount = count
+			}
+		}
+
+		return c.JSON(http.StatusOK, dto.HoldFindResponseDto{
+			Data: holds,
+			Meta: utils.NewPaginationMetaDto(pq, totalCount),
+		})
+	}
+}
+
+// CancelById
+// @Tags Holds
+// @Summary Cancel hold
+// @Description User leave the queue, a copy set aside for the hold goes to the next holder
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Hold ID"
+// @Success 200 {object} dto.HoldResponseDto
+// @Router /hold/{id} [delete]
+func (h *holdHandlersHTTP) CancelById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		holdUUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		cancelledHold, err := h.holdUC.CancelById(ctx, holdUUID, principal.ID, principal.Role)
+		if err != nil {
+			h.logger.Errorf("holdUC.CancelById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, dto.HoldResponseFromModel(cancelledHold))
+	}
+}
@@ -0,0 +1,9 @@
+package handlers
+
+func (h *holdHandlersHTTP) HoldMapRoutes() {
+	h.group.Use(h.mw.IsLoggedIn())
+	h.group.GET("", h.FindAll())
+	h.group.POST("", h.Create(), h.mw.IsUser)
+
+	h.group.DELETE("/:id", h.CancelById())
+}
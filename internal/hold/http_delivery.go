@@ -0,0 +1,10 @@
+package hold
+
+import "github.com/labstack/echo/v4"
+
+// Hold HTTP Handlers interface
+type HoldHandlers interface {
+	Create() echo.HandlerFunc
+	FindAll() echo.HandlerFunc
+	CancelById() echo.HandlerFunc
+}
@@ -0,0 +1,233 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/dinorain/pinjembuku/internal/models"
+	utils "github.com/dinorain/pinjembuku/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockHoldPGRepository is a mock of HoldPGRepository interface.
+type MockHoldPGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldPGRepositoryMockRecorder
+}
+
+// MockHoldPGRepositoryMockRecorder is the mock recorder for MockHoldPGRepository.
+type MockHoldPGRepositoryMockRecorder struct {
+	mock *MockHoldPGRepository
+}
+
+// NewMockHoldPGRepository creates a new mock instance.
+func NewMockHoldPGRepository(ctrl *gomock.Controller) *MockHoldPGRepository {
+	mock := &MockHoldPGRepository{ctrl: ctrl}
+	mock.recorder = &MockHoldPGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldPGRepository) EXPECT() *MockHoldPGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AllocateNextByBookKey mocks base method.
+func (m *MockHoldPGRepository) AllocateNextByBookKey(ctx context.Context, bookKey string, copyID uuid.UUID, readyUntil time.Time) (*models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateNextByBookKey", ctx, bookKey, copyID, readyUntil)
+	ret0, _ := ret[0].(*models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllocateNextByBookKey indicates an expected call of AllocateNextByBookKey.
+func (mr *MockHoldPGRepositoryMockRecorder) AllocateNextByBookKey(ctx, bookKey, copyID, readyUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateNextByBookKey", reflect.TypeOf((*MockHoldPGRepository)(nil).AllocateNextByBookKey), ctx, bookKey, copyID, readyUntil)
+}
+
+// CountAll mocks base method.
+func (m *MockHoldPGRepository) CountAll(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockHoldPGRepositoryMockRecorder) CountAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockHoldPGRepository)(nil).CountAll), ctx)
+}
+
+// CountAllActiveByBookKey mocks base method.
+func (m *MockHoldPGRepository) CountAllActiveByBookKey(ctx context.Context, bookKey string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllActiveByBookKey", ctx, bookKey)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllActiveByBookKey indicates an expected call of CountAllActiveByBookKey.
+func (mr *MockHoldPGRepositoryMockRecorder) CountAllActiveByBookKey(ctx, bookKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllActiveByBookKey", reflect.TypeOf((*MockHoldPGRepository)(nil).CountAllActiveByBookKey), ctx, bookKey)
+}
+
+// CountAllByUserId mocks base method.
+func (m *MockHoldPGRepository) CountAllByUserId(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllByUserId", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllByUserId indicates an expected call of CountAllByUserId.
+func (mr *MockHoldPGRepositoryMockRecorder) CountAllByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllByUserId", reflect.TypeOf((*MockHoldPGRepository)(nil).CountAllByUserId), ctx, userID)
+}
+
+// CountWaitingByBookKey mocks base method.
+func (m *MockHoldPGRepository) CountWaitingByBookKey(ctx context.Context, bookKey string, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountWaitingByBookKey", ctx, bookKey, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountWaitingByBookKey indicates an expected call of CountWaitingByBookKey.
+func (mr *MockHoldPGRepositoryMockRecorder) CountWaitingByBookKey(ctx, bookKey, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountWaitingByBookKey", reflect.TypeOf((*MockHoldPGRepository)(nil).CountWaitingByBookKey), ctx, bookKey, userID)
+}
+
+// Create mocks base method.
+func (m *MockHoldPGRepository) Create(ctx context.Context, hold *models.Hold) (*models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, hold)
+	ret0, _ := ret[0].(*models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHoldPGRepositoryMockRecorder) Create(ctx, hold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHoldPGRepository)(nil).Create), ctx, hold)
+}
+
+// FindActiveByUserIdBookKey mocks base method.
+func (m *MockHoldPGRepository) FindActiveByUserIdBookKey(ctx context.Context, userID uuid.UUID, bookKey string) (*models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveByUserIdBookKey", ctx, userID, bookKey)
+	ret0, _ := ret[0].(*models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveByUserIdBookKey indicates an expected call of FindActiveByUserIdBookKey.
+func (mr *MockHoldPGRepositoryMockRecorder) FindActiveByUserIdBookKey(ctx, userID, bookKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveByUserIdBookKey", reflect.TypeOf((*MockHoldPGRepository)(nil).FindActiveByUserIdBookKey), ctx, userID, bookKey)
+}
+
+// FindAll mocks base method.
+func (m *MockHoldPGRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret0, _ := ret[0].([]models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockHoldPGRepositoryMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockHoldPGRepository)(nil).FindAll), ctx, pagination)
+}
+
+// FindAllActiveByBookKey mocks base method.
+func (m *MockHoldPGRepository) FindAllActiveByBookKey(ctx context.Context, bookKey string, pagination *utils.Pagination) ([]models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllActiveByBookKey", ctx, bookKey, pagination)
+	ret0, _ := ret[0].([]models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllActiveByBookKey indicates an expected call of FindAllActiveByBookKey.
+func (mr *MockHoldPGRepositoryMockRecorder) FindAllActiveByBookKey(ctx, bookKey, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllActiveByBookKey", reflect.TypeOf((*MockHoldPGRepository)(nil).FindAllActiveByBookKey), ctx, bookKey, pagination)
+}
+
+// FindAllByUserId mocks base method.
+func (m *MockHoldPGRepository) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByUserId", ctx, userID, pagination)
+	ret0, _ := ret[0].([]models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByUserId indicates an expected call of FindAllByUserId.
+func (mr *MockHoldPGRepositoryMockRecorder) FindAllByUserId(ctx, userID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserId", reflect.TypeOf((*MockHoldPGRepository)(nil).FindAllByUserId), ctx, userID, pagination)
+}
+
+// FindAllExpired mocks base method.
+func (m *MockHoldPGRepository) FindAllExpired(ctx context.Context, now time.Time) ([]models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllExpired", ctx, now)
+	ret0, _ := ret[0].([]models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllExpired indicates an expected call of FindAllExpired.
+func (mr *MockHoldPGRepositoryMockRecorder) FindAllExpired(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllExpired", reflect.TypeOf((*MockHoldPGRepository)(nil).FindAllExpired), ctx, now)
+}
+
+// FindById mocks base method.
+func (m *MockHoldPGRepository) FindById(ctx context.Context, holdID uuid.UUID) (*models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, holdID)
+	ret0, _ := ret[0].(*models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockHoldPGRepositoryMockRecorder) FindById(ctx, holdID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockHoldPGRepository)(nil).FindById), ctx, holdID)
+}
+
+// UpdateStatusById mocks base method.
+func (m *MockHoldPGRepository) UpdateStatusById(ctx context.Context, holdID uuid.UUID, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusById", ctx, holdID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatusById indicates an expected call of UpdateStatusById.
+func (mr *MockHoldPGRepositoryMockRecorder) UpdateStatusById(ctx, holdID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusById", reflect.TypeOf((*MockHoldPGRepository)(nil).UpdateStatusById), ctx, holdID, status)
+}
@@ -0,0 +1,232 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/pinjembuku/internal/models"
+	utils "github.com/dinorain/pinjembuku/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockHoldUseCase is a mock of HoldUseCase interface.
+type MockHoldUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldUseCaseMockRecorder
+}
+
+// MockHoldUseCaseMockRecorder is the mock recorder for MockHoldUseCase.
+type MockHoldUseCaseMockRecorder struct {
+	mock *MockHoldUseCase
+}
+
+// NewMockHoldUseCase creates a new mock instance.
+func NewMockHoldUseCase(ctrl *gomock.Controller) *MockHoldUseCase {
+	mock := &MockHoldUseCase{ctrl: ctrl}
+	mock.recorder = &MockHoldUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldUseCase) EXPECT() *MockHoldUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Allocate mocks base method.
+func (m *MockHoldUseCase) Allocate(ctx context.Context, bookKey string, copyID uuid.UUID) (*models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allocate", ctx, bookKey, copyID)
+	ret0, _ := ret[0].(*models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Allocate indicates an expected call of Allocate.
+func (mr *MockHoldUseCaseMockRecorder) Allocate(ctx, bookKey, copyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allocate", reflect.TypeOf((*MockHoldUseCase)(nil).Allocate), ctx, bookKey, copyID)
+}
+
+// CancelById mocks base method.
+func (m *MockHoldUseCase) CancelById(ctx context.Context, holdID, actorID uuid.UUID, actorRole string) (*models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelById", ctx, holdID, actorID, actorRole)
+	ret0, _ := ret[0].(*models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelById indicates an expected call of CancelById.
+func (mr *MockHoldUseCaseMockRecorder) CancelById(ctx, holdID, actorID, actorRole interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelById", reflect.TypeOf((*MockHoldUseCase)(nil).CancelById), ctx, holdID, actorID, actorRole)
+}
+
+// Claim mocks base method.
+func (m *MockHoldUseCase) Claim(ctx context.Context, userID uuid.UUID, bookKey string) (*models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, userID, bookKey)
+	ret0, _ := ret[0].(*models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockHoldUseCaseMockRecorder) Claim(ctx, userID, bookKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockHoldUseCase)(nil).Claim), ctx, userID, bookKey)
+}
+
+// CountAll mocks base method.
+func (m *MockHoldUseCase) CountAll(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockHoldUseCaseMockRecorder) CountAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockHoldUseCase)(nil).CountAll), ctx)
+}
+
+// CountAllActiveByBookKey mocks base method.
+func (m *MockHoldUseCase) CountAllActiveByBookKey(ctx context.Context, bookKey string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllActiveByBookKey", ctx, bookKey)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllActiveByBookKey indicates an expected call of CountAllActiveByBookKey.
+func (mr *MockHoldUseCaseMockRecorder) CountAllActiveByBookKey(ctx, bookKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllActiveByBookKey", reflect.TypeOf((*MockHoldUseCase)(nil).CountAllActiveByBookKey), ctx, bookKey)
+}
+
+// CountAllByUserId mocks base method.
+func (m *MockHoldUseCase) CountAllByUserId(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllByUserId", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllByUserId indicates an expected call of CountAllByUserId.
+func (mr *MockHoldUseCaseMockRecorder) CountAllByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllByUserId", reflect.TypeOf((*MockHoldUseCase)(nil).CountAllByUserId), ctx, userID)
+}
+
+// CountWaitingByBookKey mocks base method.
+func (m *MockHoldUseCase) CountWaitingByBookKey(ctx context.Context, bookKey string, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountWaitingByBookKey", ctx, bookKey, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountWaitingByBookKey indicates an expected call of CountWaitingByBookKey.
+func (mr *MockHoldUseCaseMockRecorder) CountWaitingByBookKey(ctx, bookKey, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountWaitingByBookKey", reflect.TypeOf((*MockHoldUseCase)(nil).CountWaitingByBookKey), ctx, bookKey, userID)
+}
+
+// ExpireReady mocks base method.
+func (m *MockHoldUseCase) ExpireReady(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireReady", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireReady indicates an expected call of ExpireReady.
+func (mr *MockHoldUseCaseMockRecorder) ExpireReady(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireReady", reflect.TypeOf((*MockHoldUseCase)(nil).ExpireReady), ctx)
+}
+
+// FindAll mocks base method.
+func (m *MockHoldUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret0, _ := ret[0].([]models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockHoldUseCaseMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockHoldUseCase)(nil).FindAll), ctx, pagination)
+}
+
+// FindAllActiveByBookKey mocks base method.
+func (m *MockHoldUseCase) FindAllActiveByBookKey(ctx context.Context, bookKey string, pagination *utils.Pagination) ([]models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllActiveByBookKey", ctx, bookKey, pagination)
+	ret0, _ := ret[0].([]models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllActiveByBookKey indicates an expected call of FindAllActiveByBookKey.
+func (mr *MockHoldUseCaseMockRecorder) FindAllActiveByBookKey(ctx, bookKey, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllActiveByBookKey", reflect.TypeOf((*MockHoldUseCase)(nil).FindAllActiveByBookKey), ctx, bookKey, pagination)
+}
+
+// FindAllByUserId mocks base method.
+func (m *MockHoldUseCase) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByUserId", ctx, userID, pagination)
+	ret0, _ := ret[0].([]models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByUserId indicates an expected call of FindAllByUserId.
+func (mr *MockHoldUseCaseMockRecorder) FindAllByUserId(ctx, userID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserId", reflect.TypeOf((*MockHoldUseCase)(nil).FindAllByUserId), ctx, userID, pagination)
+}
+
+// Place mocks base method.
+func (m *MockHoldUseCase) Place(ctx context.Context, userID uuid.UUID, bookKey string) (*models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Place", ctx, userID, bookKey)
+	ret0, _ := ret[0].(*models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Place indicates an expected call of Place.
+func (mr *MockHoldUseCaseMockRecorder) Place(ctx, userID, bookKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Place", reflect.TypeOf((*MockHoldUseCase)(nil).Place), ctx, userID, bookKey)
+}
+
+// Unclaim mocks base method.
+func (m *MockHoldUseCase) Unclaim(ctx context.Context, claimedHold *models.Hold) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unclaim", ctx, claimedHold)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unclaim indicates an expected call of Unclaim.
+func (mr *MockHoldUseCaseMockRecorder) Unclaim(ctx, claimedHold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unclaim", reflect.TypeOf((*MockHoldUseCase)(nil).Unclaim), ctx, claimedHold)
+}
@@ -0,0 +1,29 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package hold
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+// Hold pg repository
+type HoldPGRepository interface {
+	Create(ctx context.Context, hold *models.Hold) (*models.Hold, error)
+	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Hold, error)
+	CountAll(ctx context.Context) (int, error)
+	FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Hold, error)
+	CountAllByUserId(ctx context.Context, userID uuid.UUID) (int, error)
+	FindAllActiveByBookKey(ctx context.Context, bookKey string, pagination *utils.Pagination) ([]models.Hold, error)
+	CountAllActiveByBookKey(ctx context.Context, bookKey string) (int, error)
+	FindAllExpired(ctx context.Context, now time.Time) ([]models.Hold, error)
+	FindActiveByUserIdBookKey(ctx context.Context, userID uuid.UUID, bookKey string) (*models.Hold, error)
+	FindById(ctx context.Context, holdID uuid.UUID) (*models.Hold, error)
+	CountWaitingByBookKey(ctx context.Context, bookKey string, userID uuid.UUID) (int, error)
+	AllocateNextByBookKey(ctx context.Context, bookKey string, copyID uuid.UUID, readyUntil time.Time) (*models.Hold, error)
+	UpdateStatusById(ctx context.Context, holdID uuid.UUID, status string) error
+}
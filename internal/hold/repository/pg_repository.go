@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/internal/hold"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+// Hold repository
+type HoldRepository struct {
+	db *sqlx.DB
+}
+
+var _ hold.HoldPGRepository = (*HoldRepository)(nil)
+
+// Hold repository constructor
+func NewHoldPGRepository(db *sqlx.DB) *HoldRepository {
+	return &HoldRepository{db: db}
+}
+
+// Create new hold at the back of the queue
+func (r *HoldRepository) Create(ctx context.Context, hold *models.Hold) (*models.Hold, error) {
+	createdHold := &models.Hold{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createHoldQuery,
+		hold.UserID,
+		hold.BookKey,
+		hold.Status,
+	).StructScan(createdHold); err != nil {
+		return nil, errors.Wrap(err, "HoldPGRepository.Create.QueryRowxContext")
+	}
+
+	return createdHold, nil
+}
+
+// FindAll Find holds, newest first
+func (r *HoldRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Hold, error) {
+	var holds []models.Hold
+	if err := r.db.SelectContext(ctx, &holds, findAllQuery, pagination.GetLimit(), pagination.GetOffset()); err != nil {
+		return nil, errors.Wrap(err, "HoldPGRepository.FindAll.SelectContext")
+	}
+
+	return holds, nil
+}
+
+// CountAll Count holds
+func (r *HoldRepository) CountAll(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, countAllQuery); err != nil {
+		return 0, errors.Wrap(err, "HoldPGRepository.CountAll.GetContext")
+	}
+
+	return count, nil
+}
+
+// FindAllByUserId Find holds of user, newest first
+func (r *HoldRepository) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Hold, error) {
+	var holds []models.Hold
+	if err := r.db.SelectContext(ctx, &holds, findAllByUserIdQuery, userID, pagination.GetLimit(), pagination.GetOffset()); err != nil {
+		return nil, errors.Wrap(err, "HoldPGRepository.FindAllByUserId.SelectContext")
+	}
+
+	return holds, nil
+}
+
+// CountAllByUserId Count holds of user
+func (r *HoldRepository) CountAllByUserId(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, countAllByUserIdQuery, userID); err != nil {
+		return 0, errors.Wrap(err, "HoldPGRepository.CountAllByUserId.GetContext")
+	}
+
+	return count, nil
+}
+
+// FindAllActiveByBookKey Find queue of book in FIFO order
+func (r *HoldRepository) FindAllActiveByBookKey(ctx context.Context, bookKey string, pagination *utils.Pagination) ([]models.Hold, error) {
+	var holds []models.Hold
+	if err := r.db.SelectContext(ctx, &holds, findAllActiveByBookKeyQuery, bookKey, pagination.GetLimit(), pagination.GetOffset()); err != nil {
+		return nil, errors.Wrap(err, "HoldPGRepository.FindAllActiveByBookKey.SelectContext")
+	}
+
+	return holds, nil
+}
+
+// CountAllActiveByBookKey Count queue of book
+func (r *HoldRepository) CountAllActiveByBookKey(ctx context.Context, bookKey string) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, countAllActiveByBookKeyQuery, bookKey); err != nil {
+		return 0, errors.Wrap(err, "HoldPGRepository.CountAllActiveByBookKey.GetContext")
+	}
+
+	return count, nil
+}
+
+// FindAllExpired Find ready holds whose pickup window closed before now
+func (r *HoldRepository) FindAllExpired(ctx context.Context, now time.Time) ([]models.Hold, error) {
+	var holds []models.Hold
+	if err := r.db.SelectContext(ctx, &holds, findAllExpiredQuery, now); err != nil {
+		return nil, errors.Wrap(err, "HoldPGRepository.FindAllExpired.SelectContext")
+	}
+
+	return holds, nil
+}
+
+// FindActiveByUserIdBookKey Find waiting or ready hold of user on book
+func (r *HoldRepository) FindActiveByUserIdBookKey(ctx context.Context, userID uuid.UUID, bookKey string) (*models.Hold, error) {
+	hold := &models.Hold{}
+	if err := r.db.GetContext(ctx, hold, findActiveByUserIdBookKeyQuery, userID, bookKey); err != nil {
+		return nil, errors.Wrap(err, "HoldPGRepository.FindActiveByUserIdBookKey.GetContext")
+	}
+
+	return hold, nil
+}
+
+// FindById Find hold by uuid
+func (r *HoldRepository) FindById(ctx context.Context, holdID uuid.UUID) (*models.Hold, error) {
+	hold := &models.Hold{}
+	if err := r.db.GetContext(ctx, hold, findByIdQuery, holdID); err != nil {
+		return nil, errors.Wrap(err, "HoldPGRepository.FindById.GetContext")
+	}
+
+	return hold, nil
+}
+
+// CountWaitingByBookKey Count active holds on book placed by users other than userID
+func (r *HoldRepository) CountWaitingByBookKey(ctx context.Context, bookKey string, userID uuid.UUID) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, countWaitingByBookKeyQuery, bookKey, userID); err != nil {
+		return 0, errors.Wrap(err, "HoldPGRepository.CountWaitingByBookKey.GetContext")
+	}
+
+	return count, nil
+}
+
+// AllocateNextByBookKey mark the oldest waiting hold on book ready with copy, returns nil when nobody is waiting
+func (r *HoldRepository) AllocateNextByBookKey(ctx context.Context, bookKey string, copyID uuid.UUID, readyUntil time.Time) (*models.Hold, error) {
+	allocatedHold := &models.Hold{}
+	if err := r.db.QueryRowxContext(ctx, allocateNextByBookKeyQuery, bookKey, copyID, readyUntil).StructScan(allocatedHold); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "HoldPGRepository.AllocateNextByBookKey.QueryRowxContext")
+	}
+
+	return allocatedHold, nil
+}
+
+// UpdateStatusById update status of existing hold
+func (r *HoldRepository) UpdateStatusById(ctx context.Context, holdID uuid.UUID, status string) error {
+	if res, err := r.db.ExecContext(ctx, updateStatusByIdQuery, holdID, status); err != nil {
+		return errors.Wrap(err, "HoldPGRepository.UpdateStatusById.ExecContext")
+	} else {
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "HoldPGRepository.UpdateStatusById.RowsAffected")
+		} else if cnt == 0 {
+			return sql.ErrNoRows
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+)
+
+var holdColumns = []string{"hold_id", "user_id", "book_key", "copy_id", "status", "ready_until", "created_at", "updated_at"}
+
+func TestHoldRepository_AllocateNextByBookKey(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	holdPGRepository := NewHoldPGRepository(sqlxDB)
+
+	bookKey := "/works/OL66554W"
+	copyUUID := uuid.New()
+	readyUntil := time.Now().Add(48 * time.Hour)
+
+	t.Run("First holder", func(t *testing.T) {
+		holdUUID := uuid.New()
+		rows := sqlmock.NewRows(holdColumns).AddRow(holdUUID, uuid.New(), bookKey, copyUUID, models.HoldStatusReady, readyUntil, time.Now(), time.Now())
+		mock.ExpectQuery(allocateNextByBookKeyQuery).WithArgs(bookKey, copyUUID, readyUntil).WillReturnRows(rows)
+
+		allocatedHold, err := holdPGRepository.AllocateNextByBookKey(context.Background(), bookKey, copyUUID, readyUntil)
+		require.NoError(t, err)
+		require.Equal(t, holdUUID, allocatedHold.HoldID)
+		require.Equal(t, copyUUID, *allocatedHold.CopyID)
+	})
+
+	t.Run("Nobody waiting", func(t *testing.T) {
+		mock.ExpectQuery(allocateNextByBookKeyQuery).WithArgs(bookKey, copyUUID, readyUntil).WillReturnRows(sqlmock.NewRows(holdColumns))
+
+		allocatedHold, err := holdPGRepository.AllocateNextByBookKey(context.Background(), bookKey, copyUUID, readyUntil)
+		require.NoError(t, err)
+		require.Nil(t, allocatedHold)
+	})
+}
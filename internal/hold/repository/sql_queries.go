@@ -0,0 +1,36 @@
+package repository
+
+const (
+	createHoldQuery = `INSERT INTO holds (user_id, book_key, status) 
+		VALUES ($1, $2, $3)
+		RETURNING hold_id, user_id, book_key, copy_id, status, ready_until, created_at, updated_at`
+
+	findByIdQuery = `SELECT hold_id, user_id, book_key, copy_id, status, ready_until, created_at, updated_at FROM holds WHERE hold_id = $1`
+
+	findAllQuery = `SELECT hold_id, user_id, book_key, copy_id, status, ready_until, created_at, updated_at FROM holds ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+
+	findAllByUserIdQuery = `SELECT hold_id, user_id, book_key, copy_id, status, ready_until, created_at, updated_at FROM holds WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
+
+	findAllActiveByBookKeyQuery = `SELECT hold_id, user_id, book_key, copy_id, status, ready_until, created_at, updated_at FROM holds 
+		WHERE book_key = $1 AND status IN ('waiting', 'ready') ORDER BY created_at LIMIT $2 OFFSET $3`
+
+	findAllExpiredQuery = `SELECT hold_id, user_id, book_key, copy_id, status, ready_until, created_at, updated_at FROM holds 
+		WHERE status = 'ready' AND ready_until < $1 ORDER BY ready_until`
+
+	findActiveByUserIdBookKeyQuery = `SELECT hold_id, user_id, book_key, copy_id, status, ready_until, created_at, updated_at FROM holds 
+		WHERE user_id = $1 AND book_key = $2 AND status IN ('waiting', 'ready')`
+
+	countWaitingByBookKeyQuery = `SELECT COUNT(*) FROM holds WHERE book_key = $1 AND user_id <> $2 AND status IN ('waiting', 'ready')`
+
+	allocateNextByBookKeyQuery = `UPDATE holds SET status = 'ready', copy_id = $2, ready_until = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE hold_id = (SELECT hold_id FROM holds WHERE book_key = $1 AND status = 'waiting' ORDER BY created_at LIMIT 1 FOR UPDATE SKIP LOCKED)
+		RETURNING hold_id, user_id, book_key, copy_id, status, ready_until, created_at, updated_at`
+
+	updateStatusByIdQuery = `UPDATE holds SET status = $2, updated_at = CURRENT_TIMESTAMP WHERE hold_id = $1`
+
+	countAllQuery = `SELECT COUNT(*) FROM holds`
+
+	countAllByUserIdQuery = `SELECT COUNT(*) FROM holds WHERE user_id = $1`
+
+	countAllActiveByBookKeyQuery = `SELECT COUNT(*) FROM holds WHERE book_key = $1 AND status IN ('waiting', 'ready')`
+)
@@ -0,0 +1,28 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package hold
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+// Hold UseCase interface
+type HoldUseCase interface {
+	Place(ctx context.Context, userID uuid.UUID, bookKey string) (*models.Hold, error)
+	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Hold, error)
+	CountAll(ctx context.Context) (int, error)
+	FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Hold, error)
+	CountAllByUserId(ctx context.Context, userID uuid.UUID) (int, error)
+	FindAllActiveByBookKey(ctx context.Context, bookKey string, pagination *utils.Pagination) ([]models.Hold, error)
+	CountAllActiveByBookKey(ctx context.Context, bookKey string) (int, error)
+	CountWaitingByBookKey(ctx context.Context, bookKey string, userID uuid.UUID) (int, error)
+	CancelById(ctx context.Context, holdID uuid.UUID, actorID uuid.UUID, actorRole string) (*models.Hold, error)
+	Allocate(ctx context.Context, bookKey string, copyID uuid.UUID) (*models.Hold, error)
+	Claim(ctx context.Context, userID uuid.UUID, bookKey string) (*models.Hold, error)
+	Unclaim(ctx context.Context, claimedHold *models.Hold) error
+	ExpireReady(ctx context.Context) (int, error)
+}
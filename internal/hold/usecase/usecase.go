@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/hold"
+	"github.com/dinorain/pinjembuku/internal/inventory"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+const (
+	defaultReadyHours = 48
+)
+
+// Hold UseCase
+type holdUseCase struct {
+	cfg         *config.Config
+	logger      logger.Logger
+	holdPgRepo  hold.HoldPGRepository
+	inventoryUC inventory.InventoryUseCase
+}
+
+var _ hold.HoldUseCase = (*holdUseCase)(nil)
+
+// New Hold UseCase
+func NewHoldUseCase(cfg *config.Config, logger logger.Logger, holdRepo hold.HoldPGRepository, inventoryUC inventory.InventoryUseCase) *holdUseCase {
+	return &holdUseCase{cfg: cfg, logger: logger, holdPgRepo: holdRepo, inventoryUC: inventoryUC}
+}
+
+// Place join the queue of book, only allowed while no copy is available
+func (u *holdUseCase) Place(ctx context.Context, userID uuid.UUID, bookKey string) (*models.Hold, error) {
+	bookKey = strings.TrimSpace(bookKey)
+
+	available, err := u.inventoryUC.CountAvailableByBookKey(ctx, bookKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "inventoryUC.CountAvailableByBookKey")
+	}
+	if available > 0 {
+		return nil, grpc_errors.ErrCopyAvailable
+	}
+
+	if _, err := u.holdPgRepo.FindActiveByUserIdBookKey(ctx, userID, bookKey); err == nil {
+		return nil, grpc_errors.ErrHoldExists
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(err, "holdPgRepo.FindActiveByUserIdBookKey")
+	}
+
+	createdHold, err := u.holdPgRepo.Create(ctx, &models.Hold{
+		UserID:  userID,
+		BookKey: bookKey,
+		Status:  models.HoldStatusWaiting,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "holdPgRepo.Create")
+	}
+
+	return createdHold, nil
+}
+
+// FindAll find holds
+func (u *holdUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Hold, error) {
+	holds, err := u.holdPgRepo.FindAll(ctx, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "holdPgRepo.FindAll")
+	}
+
+	return holds, nil
+}
+
+// CountAll count holds
+func (u *holdUseCase) CountAll(ctx context.Context) (int, error) {
+	count, err := u.holdPgRepo.CountAll(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "holdPgRepo.CountAll")
+	}
+
+	return count, nil
+}
+
+// FindAllByUserId find holds of user
+func (u *holdUseCase) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Hold, error) {
+	holds, err := u.holdPgRepo.FindAllByUserId(ctx, userID, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "holdPgRepo.FindAllByUserId")
+	}
+
+	return holds, nil
+}
+
+// CountAllByUserId count holds of user
+func (u *holdUseCase) CountAllByUserId(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := u.holdPgRepo.CountAllByUserId(ctx, userID)
+	if err != nil {
+		return 0, errors.Wrap(err, "holdPgRepo.CountAllByUserId")
+	}
+
+	return count, nil
+}
+
+// FindAllActiveByBookKey find queue of book
+func (u *holdUseCase) FindAllActiveByBookKey(ctx context.Context, bookKey string, pagination *utils.Pagination) ([]models.Hold, error) {
+	holds, err := u.holdPgRepo.FindAllActiveByBookKey(ctx, bookKey, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "holdPgRepo.FindAllActiveByBookKey")
+	}
+
+	return holds, nil
+}
+
+// CountAllActiveByBookKey count queue of book
+func (u *holdUseCase) CountAllActiveByBookKey(ctx context.Context, bookKey string) (int, error) {
+	count, err := u.holdPgRepo.CountAllActiveByBookKey(ctx, bookKey)
+	if err != nil {
+		return 0, errors.Wrap(err, "holdPgRepo.CountAllActiveByBookKey")
+	}
+
+	return count, nil
+}
+
+// CountWaitingByBookKey count active holds on book of users other than userID
+func (u *holdUseCase) CountWaitingByBookKey(ctx context.Context, bookKey string, userID uuid.UUID) (int, error) {
+	count, err := u.holdPgRepo.CountWaitingByBookKey(ctx, bookKey, userID)
+	if err != nil {
+		return 0, errors.Wrap(err, "holdPgRepo.CountWaitingByBookKey")
+	}
+
+	return count, nil
+}
+
+// CancelById leave the queue, a copy set aside for the hold rolls to the next holder
+func (u *holdUseCase) CancelById(ctx context.Context, holdID uuid.UUID, actorID uuid.UUID, actorRole string) (*models.Hold, error) {
+	foundHold, err := u.holdPgRepo.FindById(ctx, holdID)
+	if err != nil {
+		return nil, errors.Wrap(err, "holdPgRepo.FindById")
+	}
+
+	if actorRole == models.UserRoleUser && foundHold.UserID != actorID {
+		return nil, errors.Wrap(grpc_errors.ErrPermissionDenied, "hold belongs to another user")
+	}
+	if !foundHold.IsActive() {
+		return nil, errors.Wrapf(grpc_errors.ErrInvalidStatus, "%s hold can not be cancelled", foundHold.Status)
+	}
+
+	if err := u.holdPgRepo.UpdateStatusById(ctx, foundHold.HoldID, models.HoldStatusCancelled); err != nil {
+		return nil, errors.Wrap(err, "holdPgRepo.UpdateStatusById")
+	}
+
+	if foundHold.Status == models.HoldStatusReady && foundHold.CopyID != nil {
+		if _, err := u.Allocate(ctx, foundHold.BookKey, *foundHold.CopyID); err != nil {
+			u.logger.Errorf("holdUC.Allocate", err)
+		}
+	}
+	foundHold.Status = models.HoldStatusCancelled
+
+	return foundHold, nil
+}
+
+// Allocate set copy aside for the first waiting holder of book, putting it back in stock when nobody is waiting.
+// Returns the hold which became ready, nil when the copy was put back in stock
+func (u *holdUseCase) Allocate(ctx context.Context, bookKey string, copyID uuid.UUID) (*models.Hold, error) {
+	readyUntil := time.Now().Add(time.Duration(u.readyHours()) * time.Hour)
+	allocatedHold, err := u.holdPgRepo.AllocateNextByBookKey(ctx, bookKey, copyID, readyUntil)
+	if err != nil {
+		return nil, errors.Wrap(err, "holdPgRepo.AllocateNextByBookKey")
+	}
+
+	if allocatedHold == nil {
+		if err := u.inventoryUC.ReleaseById(ctx, copyID); err != nil {
+			return nil, errors.Wrap(err, "inventoryUC.ReleaseById")
+		}
+		return nil, nil
+	}
+
+	if err := u.inventoryUC.HoldById(ctx, copyID); err != nil {
+		return nil, errors.Wrap(err, "inventoryUC.HoldById")
+	}
+
+	return allocatedHold, nil
+}
+
+// Claim fulfil ready hold of user on book, returns the fulfilled hold with the copy set aside for it or nil when user has no ready hold
+func (u *holdUseCase) Claim(ctx context.Context, userID uuid.UUID, bookKey string) (*models.Hold, error) {
+	foundHold, err := u.holdPgRepo.FindActiveByUserIdBookKey(ctx, userID, bookKey)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "holdPgRepo.FindActiveByUserIdBookKey")
+	}
+
+	if foundHold.Status != models.HoldStatusReady || foundHold.CopyID == nil {
+		return nil, nil
+	}
+
+	if err := u.inventoryUC.LoanById(ctx, *foundHold.CopyID); err != nil {
+		return nil, errors.Wrap(err, "inventoryUC.LoanById")
+	}
+
+	if err := u.holdPgRepo.UpdateStatusById(ctx, foundHold.HoldID, models.HoldStatusFulfilled); err != nil {
+		if err := u.inventoryUC.HoldById(ctx, *foundHold.CopyID); err != nil {
+			u.logger.Errorf("inventoryUC.HoldById: %v", err)
+		}
+		return nil, errors.Wrap(err, "holdPgRepo.UpdateStatusById")
+	}
+	foundHold.Status = models.HoldStatusFulfilled
+
+	return foundHold, nil
+}
+
+// Unclaim undo Claim of hold whose order could not be accepted, the hold is ready again with its copy set aside
+func (u *holdUseCase) Unclaim(ctx context.Context, claimedHold *models.Hold) error {
+	if err := u.holdPgRepo.UpdateStatusById(ctx, claimedHold.HoldID, models.HoldStatusReady); err != nil {
+		return errors.Wrap(err, "holdPgRepo.UpdateStatusById")
+	}
+	claimedHold.Status = models.HoldStatusReady
+
+	if claimedHold.CopyID != nil {
+		if err := u.inventoryUC.HoldById(ctx, *claimedHold.CopyID); err != nil {
+			return errors.Wrap(err, "inventoryUC.HoldById")
+		}
+	}
+
+	return nil
+}
+
+// ExpireReady expire ready holds not picked up in time, rolling their copies to the next holder
+func (u *holdUseCase) ExpireReady(ctx context.Context) (int, error) {
+	expiredHolds, err := u.holdPgRepo.FindAllExpired(ctx, time.Now())
+	if err != nil {
+		return 0, errors.Wrap(err, "holdPgRepo.FindAllExpired")
+	}
+
+	expired := 0
+	for _, expiredHold := range expiredHolds {
+		if err := u.holdPgRepo.UpdateStatusById(ctx, expiredHold.HoldID, models.HoldStatusExpired); err != nil {
+			return expired, errors.Wrap(err, "holdPgRepo.UpdateStatusById")
+		}
+		expired++
+
+		if expiredHold.CopyID != nil {
+			if _, err := u.Allocate(ctx, expiredHold.BookKey, *expiredHold.CopyID); err != nil {
+				return expired, err
+			}
+		}
+	}
+
+	return expired, nil
+}
+
+func (u *holdUseCase) readyHours() int {
+	if u.cfg.Hold.ReadyHours > 0 {
+		return u.cfg.Hold.ReadyHours
+	}
+	return defaultReadyHours
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/hold/mock"
+	mockInventory "github.com/dinorain/pinjembuku/internal/inventory/mock"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+)
+
+const bookKey = "/works/OL66554W"
+
+func TestHoldUseCase_Place(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	holdPGRepository := mock.NewMockHoldPGRepository(ctrl)
+	inventoryUC := mockInventory.NewMockInventoryUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	holdUC := NewHoldUseCase(&config.Config{}, apiLogger, holdPGRepository, inventoryUC)
+
+	ctx := context.Background()
+	userUUID := uuid.New()
+
+	t.Run("Join queue", func(t *testing.T) {
+		inventoryUC.EXPECT().CountAvailableByBookKey(gomock.Any(), bookKey).Return(0, nil)
+		holdPGRepository.EXPECT().FindActiveByUserIdBookKey(gomock.Any(), userUUID, bookKey).Return(nil, sql.ErrNoRows)
+		holdPGRepository.EXPECT().Create(gomock.Any(), &models.Hold{UserID: userUUID, BookKey: bookKey, Status: models.HoldStatusWaiting}).
+			Return(&models.Hold{HoldID: uuid.New(), UserID: userUUID, BookKey: bookKey, Status: models.HoldStatusWaiting}, nil)
+
+		createdHold, err := holdUC.Place(ctx, userUUID, bookKey)
+		require.NoError(t, err)
+		require.Equal(t, models.HoldStatusWaiting, createdHold.Status)
+	})
+
+	t.Run("Copy available", func(t *testing.T) {
+		inventoryUC.EXPECT().CountAvailableByBookKey(gomock.Any(), bookKey).Return(1, nil)
+
+		_, err := holdUC.Place(ctx, userUUID, bookKey)
+		require.True(t, errors.Is(err, grpc_errors.ErrCopyAvailable))
+	})
+
+	t.Run("Already queued", func(t *testing.T) {
+		inventoryUC.EXPECT().CountAvailableByBookKey(gomock.Any(), bookKey).Return(0, nil)
+		holdPGRepository.EXPECT().FindActiveByUserIdBookKey(gomock.Any(), userUUID, bookKey).Return(&models.Hold{Status: models.HoldStatusWaiting}, nil)
+
+		_, err := holdUC.Place(ctx, userUUID, bookKey)
+		require.True(t, errors.Is(err, grpc_errors.ErrHoldExists))
+	})
+}
+
+func TestHoldUseCase_Allocate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	holdPGRepository := mock.NewMockHoldPGRepository(ctrl)
+	inventoryUC := mockInventory.NewMockInventoryUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	holdUC := NewHoldUseCase(&config.Config{Hold: config.Hold{ReadyHours: 24}}, apiLogger, holdPGRepository, inventoryUC)
+
+	ctx := context.Background()
+	copyUUID := uuid.New()
+
+	t.Run("First holder", func(t *testing.T) {
+		readyHold := &models.Hold{HoldID: uuid.New(), BookKey: bookKey, CopyID: &copyUUID, Status: models.HoldStatusReady}
+		holdPGRepository.EXPECT().AllocateNextByBookKey(gomock.Any(), bookKey, copyUUID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ uuid.UUID, readyUntil time.Time) (*models.Hold, error) {
+				require.WithinDuration(t, time.Now().Add(24*time.Hour), readyUntil, time.Minute)
+				return readyHold, nil
+			})
+		inventoryUC.EXPECT().HoldById(gomock.Any(), copyUUID).Return(nil)
+
+		allocatedHold, err := holdUC.Allocate(ctx, bookKey, copyUUID)
+		require.NoError(t, err)
+		require.Equal(t, readyHold.HoldID, allocatedHold.HoldID)
+	})
+
+	t.Run("Nobody waiting", func(t *testing.T) {
+		holdPGRepository.EXPECT().AllocateNextByBookKey(gomock.Any(), bookKey, copyUUID, gomock.Any()).Return(nil, nil)
+		inventoryUC.EXPECT().ReleaseById(gomock.Any(), copyUUID).Return(nil)
+
+		allocatedHold, err := holdUC.Allocate(ctx, bookKey, copyUUID)
+		require.NoError(t, err)
+		require.Nil(t, allocatedHold)
+	})
+}
+
+func TestHoldUseCase_Claim(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	holdPGRepository := mock.NewMockHoldPGRepository(ctrl)
+	inventoryUC := mockInventory.NewMockInventoryUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	holdUC := NewHoldUseCase(&config.Config{}, apiLogger, holdPGRepository, inventoryUC)
+
+	ctx := context.Background()
+	userUUID := uuid.New()
+
+	t.Run("Ready", func(t *testing.T) {
+		copyUUID := uuid.New()
+		readyHold := &models.Hold{HoldID: uuid.New(), UserID: userUUID, BookKey: bookKey, CopyID: &copyUUID, Status: models.HoldStatusReady}
+		holdPGRepository.EXPECT().FindActiveByUserIdBookKey(gomock.Any(), userUUID, bookKey).Return(readyHold, nil)
+		inventoryUC.EXPECT().LoanById(gomock.Any(), copyUUID).Return(nil)
+		holdPGRepository.EXPECT().UpdateStatusById(gomock.Any(), readyHold.HoldID, models.HoldStatusFulfilled).Return(nil)
+
+		claimedHold, err := holdUC.Claim(ctx, userUUID, bookKey)
+		require.NoError(t, err)
+		require.Equal(t, copyUUID, *claimedHold.CopyID)
+		require.Equal(t, models.HoldStatusFulfilled, claimedHold.Status)
+	})
+
+	t.Run("Hold update fails", func(t *testing.T) {
+		copyUUID := uuid.New()
+		readyHold := &models.Hold{HoldID: uuid.New(), UserID: userUUID, BookKey: bookKey, CopyID: &copyUUID, Status: models.HoldStatusReady}
+		holdPGRepository.EXPECT().FindActiveByUserIdBookKey(gomock.Any(), userUUID, bookKey).Return(readyHold, nil)
+		inventoryUC.EXPECT().LoanById(gomock.Any(), copyUUID).Return(nil)
+		holdPGRepository.EXPECT().UpdateStatusById(gomock.Any(), readyHold.HoldID, models.HoldStatusFulfilled).Return(errors.New("db down"))
+		inventoryUC.EXPECT().HoldById(gomock.Any(), copyUUID).Return(nil)
+
+		_, err := holdUC.Claim(ctx, userUUID, bookKey)
+		require.Error(t, err)
+	})
+
+	t.Run("Still waiting", func(t *testing.T) {
+		holdPGRepository.EXPECT().FindActiveByUserIdBookKey(gomock.Any(), userUUID, bookKey).Return(&models.Hold{Status: models.HoldStatusWaiting}, nil)
+
+		claimedHold, err := holdUC.Claim(ctx, userUUID, bookKey)
+		require.NoError(t, err)
+		require.Nil(t, claimedHold)
+	})
+
+	t.Run("No hold", func(t *testing.T) {
+		holdPGRepository.EXPECT().FindActiveByUserIdBookKey(gomock.Any(), userUUID, bookKey).Return(nil, sql.ErrNoRows)
+
+		claimedHold, err := holdUC.Claim(ctx, userUUID, bookKey)
+		require.NoError(t, err)
+		require.Nil(t, claimedHold)
+	})
+}
+
+func TestHoldUseCase_Unclaim(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	holdPGRepository := mock.NewMockHoldPGRepository(ctrl)
+	inventoryUC := mockInventory.NewMockInventoryUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	holdUC := NewHoldUseCase(&config.Config{}, apiLogger, holdPGRepository, inventoryUC)
+
+	copyUUID := uuid.New()
+	claimedHold := &models.Hold{HoldID: uuid.New(), UserID: uuid.New(), BookKey: bookKey, CopyID: &copyUUID, Status: models.HoldStatusFulfilled}
+	holdPGRepository.EXPECT().UpdateStatusById(gomock.Any(), claimedHold.HoldID, models.HoldStatusReady).Return(nil)
+	inventoryUC.EXPECT().HoldById(gomock.Any(), copyUUID).Return(nil)
+
+	err := holdUC.Unclaim(context.Background(), claimedHold)
+	require.NoError(t, err)
+	require.Equal(t, models.HoldStatusReady, claimedHold.Status)
+}
+
+func TestHoldUseCase_ExpireReady(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	holdPGRepository := mock.NewMockHoldPGRepository(ctrl)
+	inventoryUC := mockInventory.NewMockInventoryUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	holdUC := NewHoldUseCase(&config.Config{}, apiLogger, holdPGRepository, inventoryUC)
+
+	copyUUID := uuid.New()
+	expiredHold := models.Hold{HoldID: uuid.New(), BookKey: bookKey, CopyID: &copyUUID, Status: models.HoldStatusReady}
+	nextHold := &models.Hold{HoldID: uuid.New(), BookKey: bookKey, CopyID: &copyUUID, Status: models.HoldStatusReady}
+
+	holdPGRepository.EXPECT().FindAllExpired(gomock.Any(), gomock.Any()).Return([]models.Hold{expiredHold}, nil)
+	holdPGRepository.EXPECT().UpdateStatusById(gomock.Any(), expiredHold.HoldID, models.HoldStatusExpired).Return(nil)
+	holdPGRepository.EXPECT().AllocateNextByBookKey(gomock.Any(), bookKey, copyUUID, gomock.Any()).Return(nextHold, nil)
+	inventoryUC.EXPECT().HoldById(gomock.Any(), copyUUID).Return(nil)
+
+	expired, err := holdUC.ExpireReady(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, expired)
+}
@@ -0,0 +1,168 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "bearer "
+)
+
+// Health checks and reflection never require a token
+var infrastructureServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+// Used when the grpc config section does not list any public method
+var defaultPublicMethods = []string{
+	"/userService.UserService/Login",
+	"/librarianService.LibrarianService/Login",
+}
+
+// Always applied, grpc config entries override the roles of a listed method
+var defaultMethodRoles = []config.MethodRoles{
+	{Method: "/userService.UserService/Register", Roles: []string{models.UserRoleAdmin}},
+	{Method: "/librarianService.LibrarianService/Register", Roles: []string{models.UserRoleAdmin}},
+	{Method: "/librarianService.LibrarianService/FindAll", Roles: []string{models.UserRoleAdmin}},
+	{Method: "/orderService.OrderService/Create", Roles: []string{models.UserRoleUser, models.UserRoleAdmin}},
+}
+
+type principalCtxKey struct{}
+
+// PrincipalFromCtx returns the principal injected by the auth interceptors
+func PrincipalFromCtx(ctx context.Context) (*models.Principal, bool) {
+	principal, ok := ctx.Value(principalCtxKey{}).(*models.Principal)
+	return principal, ok
+}
+
+// ContextWithPrincipal returns a copy of ctx carrying principal
+func ContextWithPrincipal(ctx context.Context, principal *models.Principal) context.Context {
+	return context.WithValue(ctx, principalCtxKey{}, principal)
+}
+
+// Auth unary interceptor validates bearer token and session, and checks method roles
+func (im *InterceptorManager) Auth(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	ctx, err = im.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// StreamAuth stream interceptor validates bearer token and session, and checks method roles
+func (im *InterceptorManager) StreamAuth(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := im.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authServerStream{ServerStream: ss, ctx: ctx})
+}
+
+func (im *InterceptorManager) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if im.isPublicMethod(fullMethod) {
+		return ctx, nil
+	}
+
+	principal, err := im.principalFromMetadata(ctx)
+	if err != nil {
+		im.logger.Warnf("principalFromMetadata: %v", err)
+		return nil, status.Errorf(codes.Unauthenticated, "principalFromMetadata: %v", err)
+	}
+
+	session, err := im.sessUC.GetSessionById(ctx, principal.SessionID)
+	if err != nil {
+		im.logger.Warnf("sessUC.GetSessionById: %v", err)
+		return nil, status.Errorf(codes.Unauthenticated, "sessUC.GetSessionById: %v", grpc_errors.ErrInvalidSessionId)
+	}
+
+	if session.UserID != principal.ID {
+		im.logger.Warnf("session %s does not belong to %s", principal.SessionID, principal.ID)
+		return nil, status.Errorf(codes.Unauthenticated, "session: %v", grpc_errors.ErrInvalidSessionId)
+	}
+
+	if roles, ok := im.methodRoles[fullMethod]; ok && !principal.HasRole(roles...) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s: %v", fullMethod, grpc_errors.ErrPermissionDenied)
+	}
+
+	return ContextWithPrincipal(ctx, principal), nil
+}
+
+func (im *InterceptorManager) principalFromMetadata(ctx context.Context) (*models.Principal, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, grpc_errors.ErrNoCtxMetaData
+	}
+
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 || !strings.HasPrefix(strings.ToLower(values[0]), bearerPrefix) {
+		return nil, errors.Wrap(grpc_errors.ErrInvalidToken, "missing bearer token")
+	}
+
+	token, err := im.tokens.ParseAccessToken(values[0][len(bearerPrefix):])
+	if err != nil {
+		return nil, errors.Wrap(err, "tokens.ParseAccessToken")
+	}
+	claims := token.Claims.(jwt.MapClaims)
+
+	return models.NewPrincipalFromClaims(claims)
+}
+
+func (im *InterceptorManager) isPublicMethod(fullMethod string) bool {
+	for _, prefix := range infrastructureServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return im.publicMethods[fullMethod]
+}
+
+func newPublicMethods(cfg *config.Config) map[string]bool {
+	methods := cfg.Grpc.PublicMethods
+	if len(methods) == 0 {
+		methods = defaultPublicMethods
+	}
+
+	publicMethods := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		publicMethods[method] = true
+	}
+	return publicMethods
+}
+
+// Configured method roles override the default roles of the same method, other defaults stay in place
+func newMethodRoles(cfg *config.Config) map[string][]string {
+	methodRoles := make(map[string][]string, len(defaultMethodRoles)+len(cfg.Grpc.MethodRoles))
+	for _, entry := range defaultMethodRoles {
+		methodRoles[entry.Method] = entry.Roles
+	}
+	for _, entry := range cfg.Grpc.MethodRoles {
+		methodRoles[entry.Method] = entry.Roles
+	}
+	return methodRoles
+}
+
+// authServerStream overrides the stream context with the one carrying the principal
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,228 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/internal/session/mock"
+	"github.com/dinorain/pinjembuku/pkg/jwtkeys"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
+)
+
+const testSecret = "secretkey"
+
+func newTestInterceptorManager(t *testing.T, ctrl *gomock.Controller, cfg *config.Config) (*InterceptorManager, *mock.MockSessUseCase) {
+	cfg.Server.JwtSecretKey = testSecret
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+
+	sessUC := mock.NewMockSessUseCase(ctrl)
+	return NewInterceptorManager(appLogger, cfg, tokenService, sessUC), sessUC
+}
+
+func signToken(t *testing.T, secret string, claims jwt.MapClaims) string {
+	keys, err := jwtkeys.NewKeyring(jwtkeys.NewHMACKey("", []byte(secret)))
+	require.NoError(t, err)
+
+	subject, _ := claims["user_id"].(string)
+	if librarianID, ok := claims["librarian_id"].(string); ok {
+		subject = librarianID
+	}
+
+	access, _, err := tokens.NewService(&config.Config{}, keys).GenerateTokenPair(subject, claims["session_id"].(string), uuid.New().String(), claims)
+	require.NoError(t, err)
+	return access
+}
+
+func bearerCtx(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func callAuth(im *InterceptorManager, ctx context.Context, method string) (*models.Principal, error) {
+	var principal *models.Principal
+	_, err := im.Auth(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		principal, _ = PrincipalFromCtx(ctx)
+		return nil, nil
+	})
+	return principal, err
+}
+
+func TestInterceptorManager_Auth(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	im, sessUC := newTestInterceptorManager(t, ctrl, &config.Config{})
+
+	userUUID := uuid.New()
+	librarianUUID := uuid.New()
+	userToken := signToken(t, testSecret, jwt.MapClaims{"session_id": "s1", "user_id": userUUID.String(), "email": "user@gmail.com", "role": models.UserRoleUser})
+	librarianToken := signToken(t, testSecret, jwt.MapClaims{"session_id": "s2", "librarian_id": librarianUUID.String(), "email": "librarian@gmail.com"})
+
+	t.Run("Public method needs no token", func(t *testing.T) {
+		principal, err := callAuth(im, context.Background(), "/userService.UserService/Login")
+		require.NoError(t, err)
+		require.Nil(t, principal)
+
+		_, err = callAuth(im, context.Background(), "/grpc.health.v1.Health/Check")
+		require.NoError(t, err)
+	})
+
+	t.Run("Missing token", func(t *testing.T) {
+		_, err := callAuth(im, context.Background(), "/userService.UserService/GetMe")
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", userToken))
+		_, err = callAuth(im, ctx, "/userService.UserService/GetMe")
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("Token signed with another secret", func(t *testing.T) {
+		token := signToken(t, "other", jwt.MapClaims{"session_id": "s1", "user_id": userUUID.String(), "role": models.UserRoleUser})
+		_, err := callAuth(im, bearerCtx(token), "/userService.UserService/GetMe")
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("Refresh token carries no principal", func(t *testing.T) {
+		token := signToken(t, testSecret, jwt.MapClaims{"session_id": "s1"})
+		_, err := callAuth(im, bearerCtx(token), "/userService.UserService/GetMe")
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("Session revoked", func(t *testing.T) {
+		sessUC.EXPECT().GetSessionById(gomock.Any(), "s1").Return(nil, redis.Nil)
+
+		_, err := callAuth(im, bearerCtx(userToken), "/userService.UserService/GetMe")
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("Session of another principal", func(t *testing.T) {
+		sessUC.EXPECT().GetSessionById(gomock.Any(), "s1").Return(&models.Session{SessionID: "s1", UserID: uuid.New()}, nil)
+
+		_, err := callAuth(im, bearerCtx(userToken), "/userService.UserService/GetMe")
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("User principal injected", func(t *testing.T) {
+		sessUC.EXPECT().GetSessionById(gomock.Any(), "s1").Return(&models.Session{SessionID: "s1", UserID: userUUID}, nil)
+
+		principal, err := callAuth(im, bearerCtx(userToken), "/orderService.OrderService/Create")
+		require.NoError(t, err)
+		require.Equal(t, &models.Principal{ID: userUUID, Kind: models.PrincipalKindUser, Role: models.UserRoleUser, Email: "user@gmail.com", SessionID: "s1"}, principal)
+	})
+
+	t.Run("Librarian principal injected", func(t *testing.T) {
+		sessUC.EXPECT().GetSessionById(gomock.Any(), "s2").Return(&models.Session{SessionID: "s2", UserID: librarianUUID}, nil)
+
+		principal, err := callAuth(im, bearerCtx(librarianToken), "/orderService.OrderService/FindAll")
+		require.NoError(t, err)
+		require.Equal(t, librarianUUID, principal.ID)
+		require.Equal(t, models.LibrarianRole, principal.Role)
+	})
+
+	t.Run("Role not allowed for method", func(t *testing.T) {
+		sessUC.EXPECT().GetSessionById(gomock.Any(), "s2").Return(&models.Session{SessionID: "s2", UserID: librarianUUID}, nil)
+
+		_, err := callAuth(im, bearerCtx(librarianToken), "/orderService.OrderService/Create")
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Register needs admin", func(t *testing.T) {
+		_, err := callAuth(im, context.Background(), "/userService.UserService/Register")
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		sessUC.EXPECT().GetSessionById(gomock.Any(), "s1").Return(&models.Session{SessionID: "s1", UserID: userUUID}, nil)
+
+		_, err = callAuth(im, bearerCtx(userToken), "/userService.UserService/Register")
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestInterceptorManager_AuthConfiguredMethods(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	im, sessUC := newTestInterceptorManager(t, ctrl, &config.Config{Grpc: config.Grpc{
+		PublicMethods: []string{"/bookService.BookService/FindByWork"},
+		MethodRoles: []config.MethodRoles{
+			{Method: "/orderService.OrderService/Create", Roles: []string{models.LibrarianRole}},
+		},
+	}})
+
+	_, err := callAuth(im, context.Background(), "/bookService.BookService/FindByWork")
+	require.NoError(t, err)
+
+	_, err = callAuth(im, context.Background(), "/userService.UserService/Login")
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	librarianUUID := uuid.New()
+	librarianToken := signToken(t, testSecret, jwt.MapClaims{"session_id": "s2", "librarian_id": librarianUUID.String()})
+	sessUC.EXPECT().GetSessionById(gomock.Any(), "s2").Return(&models.Session{SessionID: "s2", UserID: librarianUUID}, nil).Times(3)
+
+	_, err = callAuth(im, bearerCtx(librarianToken), "/orderService.OrderService/Create")
+	require.NoError(t, err)
+
+	// configured method roles extend the defaults, admin gates not listed in config stay in place
+	_, err = callAuth(im, bearerCtx(librarianToken), "/librarianService.LibrarianService/Register")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = callAuth(im, bearerCtx(librarianToken), "/userService.UserService/Register")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestInterceptorManager_StreamAuth(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	im, sessUC := newTestInterceptorManager(t, ctrl, &config.Config{})
+
+	userUUID := uuid.New()
+	userToken := signToken(t, testSecret, jwt.MapClaims{"session_id": "s1", "user_id": userUUID.String(), "role": models.UserRoleUser})
+	sessUC.EXPECT().GetSessionById(gomock.Any(), "s1").Return(&models.Session{SessionID: "s1", UserID: userUUID}, nil)
+
+	info := &grpc.StreamServerInfo{FullMethod: "/orderService.OrderService/WatchOrders", IsServerStream: true}
+	err := im.StreamAuth(nil, &testServerStream{ctx: bearerCtx(userToken)}, info, func(srv interface{}, stream grpc.ServerStream) error {
+		principal, ok := PrincipalFromCtx(stream.Context())
+		require.True(t, ok)
+		require.Equal(t, userUUID, principal.ID)
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = im.StreamAuth(nil, &testServerStream{ctx: context.Background()}, info, func(interface{}, grpc.ServerStream) error {
+		t.Fatal("handler called without token")
+		return nil
+	})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -8,30 +8,59 @@ import (
 	"google.golang.org/grpc/metadata"
 
 	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/session"
 	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
 )
 
 // InterceptorManager
 type InterceptorManager struct {
-	logger logger.Logger
-	cfg    *config.Config
+	logger        logger.Logger
+	cfg           *config.Config
+	tokens        *tokens.Service
+	sessUC        session.SessUseCase
+	publicMethods map[string]bool
+	methodRoles   map[string][]string
 }
 
 // InterceptorManager constructor
-func NewInterceptorManager(logger logger.Logger, cfg *config.Config) *InterceptorManager {
+func NewInterceptorManager(logger logger.Logger, cfg *config.Config, tokens *tokens.Service, sessUC session.SessUseCase) *InterceptorManager {
 	return &InterceptorManager{
-		logger: logger,
-		cfg: cfg,
+		logger:        logger,
+		cfg:           cfg,
+		tokens:        tokens,
+		sessUC:        sessUC,
+		publicMethods: newPublicMethods(cfg),
+		methodRoles:   newMethodRoles(cfg),
 	}
 }
 
 // Logger Interceptor
 func (im *InterceptorManager) Logger(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 	start := time.Now()
-	md, _ := metadata.FromIncomingContext(ctx)
+	md := redactedMetadata(ctx)
 	reply, err := handler(ctx, req)
 	im.logger.Infof("Method: %s, Time: %v, Metadata: %v, Err: %v", info.FullMethod, time.Since(start), md, err)
 
 	return reply, err
 }
 
+// StreamLogger Interceptor
+func (im *InterceptorManager) StreamLogger(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	md := redactedMetadata(ss.Context())
+	err := handler(srv, ss)
+	im.logger.Infof("Method: %s, Time: %v, Metadata: %v, Err: %v", info.FullMethod, time.Since(start), md, err)
+
+	return err
+}
+
+// redactedMetadata copy incoming metadata without credentials, for logging
+func redactedMetadata(ctx context.Context) metadata.MD {
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	if len(md.Get(authorizationHeader)) > 0 {
+		md.Set(authorizationHeader, "[REDACTED]")
+	}
+	return md
+}
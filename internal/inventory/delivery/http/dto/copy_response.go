@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+)
+
+type CopyResponseDto struct {
+	CopyID    uuid.UUID `json:"copy_id"`
+	Barcode   string    `json:"barcode"`
+	BookKey   string    `json:"key"`
+	Condition string    `json:"condition"`
+	Location  *string   `json:"location"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func CopyResponseFromModel(copy *models.Copy) *CopyResponseDto {
+	return &CopyResponseDto{
+		CopyID:    copy.CopyID,
+		Barcode:   copy.Barcode,
+		BookKey:   copy.BookKey,
+		Condition: copy.Condition,
+		Location:  copy.Location,
+		Status:    copy.Status,
+		CreatedAt: copy.CreatedAt,
+		UpdatedAt: copy.UpdatedAt,
+	}
+}
+
+func CopyResponsesFromModels(copies []models.Copy) []*CopyResponseDto {
+	res := make([]*CopyResponseDto, 0, len(copies))
+	for i := range copies {
+		res = append(res, CopyResponseFromModel(&copies[i]))
+	}
+	return res
+}
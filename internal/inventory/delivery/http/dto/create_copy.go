@@ -0,0 +1,16 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+type CopyCreateRequestDto struct {
+	Barcode   string  `json:"barcode" validate:"required,lte=64"`
+	BookKey   string  `json:"key" validate:"required,lte=64"`
+	Condition string  `json:"condition" validate:"omitempty,oneof=new good fair poor damaged"`
+	Location  *string `json:"location" validate:"omitempty,lte=128"`
+}
+
+type CopyCreateResponseDto struct {
+	CopyID uuid.UUID `json:"copy_id" validate:"required"`
+}
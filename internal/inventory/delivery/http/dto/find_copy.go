@@ -0,0 +1,8 @@
+package dto
+
+import "github.com/dinorain/pinjembuku/pkg/utils"
+
+type CopyFindResponseDto struct {
+	Meta utils.PaginationMetaDto `json:"meta"`
+	Data interface{}             `json:"data"`
+}
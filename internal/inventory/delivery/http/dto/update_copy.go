@@ -0,0 +1,8 @@
+package dto
+
+type CopyUpdateRequestDto struct {
+	Barcode   *string `json:"barcode" validate:"omitempty,lte=64"`
+	Condition *string `json:"condition" validate:"omitempty,oneof=new good fair poor damaged"`
+	Location  *string `json:"location" validate:"omitempty,lte=128"`
+	Status    *string `json:"status" validate:"omitempty,oneof=available lost withdrawn"`
+}
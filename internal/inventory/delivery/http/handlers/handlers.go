@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/inventory"
+	"github.com/dinorain/pinjembuku/internal/inventory/delivery/http/dto"
+	"github.com/dinorain/pinjembuku/internal/middlewares"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/constants"
+	httpErrors "github.com/dinorain/pinjembuku/pkg/http_errors"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+type inventoryHandlersHTTP struct {
+	group       *echo.Group
+	logger      logger.Logger
+	cfg         *config.Config
+	mw          middlewares.MiddlewareManager
+	v           *validator.Validate
+	inventoryUC inventory.InventoryUseCase
+}
+
+var _ inventory.InventoryHandlers = (*inventoryHandlersHTTP)(nil)
+
+func NewInventoryHandlersHTTP(
+	group *echo.Group,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	v *validator.Validate,
+	inventoryUC inventory.InventoryUseCase,
+) *inventoryHandlersHTTP {
+	return &inventoryHandlersHTTP{group: group, logger: logger, cfg: cfg, mw: mw, v: v, inventoryUC: inventoryUC}
+}
+
+// Create
+// @Tags Inventory
+// @Summary To register copy
+// @Description Librarian register a physical copy of a book
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param payload body dto.CopyCreateRequestDto true "Payload"
+// @Success 200 {object} dto.CopyCreateResponseDto
+// @Router /inventory [post]
+func (h *inventoryHandlersHTTP) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		createDto := &dto.CopyCreateRequestDto{}
+		if err := c.Bind(createDto); err != nil {
+			h.logger.WarnMsg("bind", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.v.StructCtx(ctx, createDto); err != nil {
+			h.logger.WarnMsg("validate", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		createdCopy, err := h.inventoryUC.Create(ctx, h.registerReqToCopyModel(createDto))
+		if err != nil {
+			h.logger.Errorf("inventoryUC.Create: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusCreated, dto.CopyCreateResponseDto{CopyID: createdCopy.CopyID})
+	}
+}
+
+// FindAll
+// @Tags Inventory
+// @Summary Find all copies
+// @Description Find all copies, optionally of one book
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param key query string false "book key"
+// @Param size query string false "pagination size"
+// @Param page query string false "pagination page"
+// @Success 200 {object} dto.CopyFindResponseDto
+// @Router /inventory [get]
+func (h *inventoryHandlersHTTP) FindAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
+
+		var copies []models.Copy
+		var totalCount int
+		if key := c.QueryParam(constants.Key); key != "" {
+			bookKey, err := utils.NormalizeBookKey(key)
+			if err != nil {
+				h.logger.WarnMsg("utils.NormalizeBookKey", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			}
+			if res, err := h.inventoryUC.FindAllByBookKey(ctx, bookKey, pq); err != nil {
+				h.logger.Errorf("inventoryUC.FindAllByBookKey: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			} else {
+				copies = res
+			}
+			if count, err := h.inventoryUC.CountAllByBookKey(ctx, bookKey); err != nil {
+				h.logger.Errorf("inventoryUC.CountAllByBookKey: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			} else {
+				totalCount = count
+			}
+		} else {
+			if res, err := h.inventoryUC.FindAll(ctx, pq); err != nil {
+				h.logger.Errorf("inventoryUC.FindAll: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			} else {
+				copies = res
+			}
+			if count, err := h.inventoryUC.CountAll(ctx); err != nil {
+				h.logger.Errorf("inventoryUC.CountAll: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			} else {
+				totalCount = count
+			}
+		}
+
+		return c.JSON(http.StatusOK, dto.CopyFindResponseDto{
+			Data: dto.CopyResponsesFromModels(copies),
+			Meta: utils.NewPaginationMetaDto(pq, totalCount),
+		})
+	}
+}
+
+// FindById
+// @Tags Inventory
+// @Summary Find copy
+// @Description Find existing copy by id
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Copy ID"
+// @Success 200 {object} dto.CopyResponseDto
+// @Router /inventory/{id} [get]
+func (h *inventoryHandlersHTTP) FindById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		copyUUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		copy, err := h.inventoryUC.FindById(ctx, copyUUID)
+		if err != nil {
+			h.logger.Errorf("inventoryUC.FindById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, dto.CopyResponseFromModel(copy))
+	}
+}
+
+// UpdateById
+// @Tags Inventory
+// @Summary Update copy
+// @Description Librarian update barcode, condition, location or status of copy. Status moves between available, lost and withdrawn only, copies on loan or on hold follow their order or hold
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Copy ID"
+// @Param payload body dto.CopyUpdateRequestDto true "Payload"
+// @Success 200 {object} dto.CopyResponseDto
+// @Router /inventory/{id} [put]
+func (h *inventoryHandlersHTTP) UpdateById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		copyUUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		updateDto := &dto.CopyUpdateRequestDto{}
+		if err := c.Bind(updateDto); err != nil {
+			h.logger.WarnMsg("bind", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.v.StructCtx(ctx, updateDto); err != nil {
+			h.logger.WarnMsg("validate", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		copy, err := h.inventoryUC.FindById(ctx, copyUUID)
+		if err != nil {
+			h.logger.Errorf("inventoryUC.FindById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		copy, err = h.inventoryUC.UpdateById(ctx, h.updateReqToCopyModel(copy, updateDto))
+		if err != nil {
+			h.logger.Errorf("inventoryUC.UpdateById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, dto.CopyResponseFromModel(copy))
+	}
+}
+
+// DeleteById
+// @Tags Inventory
+// @Summary Delete copy
+// @Description Librarian delete existing copy, copies on loan, on hold or referenced by orders or holds answer 409
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} nil
+// @Param id path string true "Copy ID"
+// @Router /inventory/{id} [delete]
+func (h *inventoryHandlersHTTP) DeleteById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		copyUUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.inventoryUC.DeleteById(ctx, copyUUID); err != nil {
+			h.logger.Errorf("inventoryUC.DeleteById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, nil)
+	}
+}
+
+func (h *inventoryHandlersHTTP) registerReqToCopyModel(r *dto.CopyCreateRequestDto) *models.Copy {
+	condition := r.Condition
+	if condition == "" {
+		condition = models.CopyConditionGood
+	}
+
+	return &models.Copy{
+		Barcode:   strings.TrimSpace(r.Barcode),
+		BookKey:   r.BookKey,
+		Condition: condition,
+		Location:  r.Location,
+		Status:    models.CopyStatusAvailable,
+	}
+}
+
+func (h *inventoryHandlersHTTP) updateReqToCopyModel(updateCandidate *models.Copy, r *dto.CopyUpdateRequestDto) *models.Copy {
+	if r.Barcode != nil {
+		updateCandidate.Barcode = strings.TrimSpace(*r.Barcode)
+	}
+	if r.Condition != nil {
+		updateCandidate.Condition = *r.Condition
+	}
+	if r.Location != nil {
+		location := strings.TrimSpace(*r.Location)
+		updateCandidate.Location = &location
+	}
+	if r.Status != nil {
+		updateCandidate.Status = *r.Status
+	}
+
+	return updateCandidate
+}
@@ -0,0 +1,11 @@
+package handlers
+
+func (h *inventoryHandlersHTTP) InventoryMapRoutes() {
+	h.group.Use(h.mw.IsLoggedIn())
+	h.group.GET("", h.FindAll())
+	h.group.POST("", h.Create(), h.mw.IsLibrarian)
+
+	h.group.GET("/:id", h.FindById())
+	h.group.PUT("/:id", h.UpdateById(), h.mw.IsLibrarian)
+	h.group.DELETE("/:id", h.DeleteById(), h.mw.IsLibrarian)
+}
@@ -0,0 +1,12 @@
+package inventory
+
+import "github.com/labstack/echo/v4"
+
+// Inventory HTTP Handlers interface
+type InventoryHandlers interface {
+	Create() echo.HandlerFunc
+	FindAll() echo.HandlerFunc
+	FindById() echo.HandlerFunc
+	UpdateById() echo.HandlerFunc
+	DeleteById() echo.HandlerFunc
+}
@@ -0,0 +1,216 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/pinjembuku/internal/models"
+	utils "github.com/dinorain/pinjembuku/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockInventoryPGRepository is a mock of InventoryPGRepository interface.
+type MockInventoryPGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryPGRepositoryMockRecorder
+}
+
+// MockInventoryPGRepositoryMockRecorder is the mock recorder for MockInventoryPGRepository.
+type MockInventoryPGRepositoryMockRecorder struct {
+	mock *MockInventoryPGRepository
+}
+
+// NewMockInventoryPGRepository creates a new mock instance.
+func NewMockInventoryPGRepository(ctrl *gomock.Controller) *MockInventoryPGRepository {
+	mock := &MockInventoryPGRepository{ctrl: ctrl}
+	mock.recorder = &MockInventoryPGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryPGRepository) EXPECT() *MockInventoryPGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountAll mocks base method.
+func (m *MockInventoryPGRepository) CountAll(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockInventoryPGRepositoryMockRecorder) CountAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockInventoryPGRepository)(nil).CountAll), ctx)
+}
+
+// CountAllByBookKey mocks base method.
+func (m *MockInventoryPGRepository) CountAllByBookKey(ctx context.Context, bookKey string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllByBookKey", ctx, bookKey)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllByBookKey indicates an expected call of CountAllByBookKey.
+func (mr *MockInventoryPGRepositoryMockRecorder) CountAllByBookKey(ctx, bookKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllByBookKey", reflect.TypeOf((*MockInventoryPGRepository)(nil).CountAllByBookKey), ctx, bookKey)
+}
+
+// CountAvailableByBookKey mocks base method.
+func (m *MockInventoryPGRepository) CountAvailableByBookKey(ctx context.Context, bookKey string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAvailableByBookKey", ctx, bookKey)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAvailableByBookKey indicates an expected call of CountAvailableByBookKey.
+func (mr *MockInventoryPGRepositoryMockRecorder) CountAvailableByBookKey(ctx, bookKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAvailableByBookKey", reflect.TypeOf((*MockInventoryPGRepository)(nil).CountAvailableByBookKey), ctx, bookKey)
+}
+
+// Create mocks base method.
+func (m *MockInventoryPGRepository) Create(ctx context.Context, copy *models.Copy) (*models.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, copy)
+	ret0, _ := ret[0].(*models.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInventoryPGRepositoryMockRecorder) Create(ctx, copy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInventoryPGRepository)(nil).Create), ctx, copy)
+}
+
+// DeleteById mocks base method.
+func (m *MockInventoryPGRepository) DeleteById(ctx context.Context, copyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, copyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockInventoryPGRepositoryMockRecorder) DeleteById(ctx, copyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockInventoryPGRepository)(nil).DeleteById), ctx, copyID)
+}
+
+// FindAll mocks base method.
+func (m *MockInventoryPGRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret0, _ := ret[0].([]models.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockInventoryPGRepositoryMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockInventoryPGRepository)(nil).FindAll), ctx, pagination)
+}
+
+// FindAllByBookKey mocks base method.
+func (m *MockInventoryPGRepository) FindAllByBookKey(ctx context.Context, bookKey string, pagination *utils.Pagination) ([]models.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBookKey", ctx, bookKey, pagination)
+	ret0, _ := ret[0].([]models.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBookKey indicates an expected call of FindAllByBookKey.
+func (mr *MockInventoryPGRepositoryMockRecorder) FindAllByBookKey(ctx, bookKey, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBookKey", reflect.TypeOf((*MockInventoryPGRepository)(nil).FindAllByBookKey), ctx, bookKey, pagination)
+}
+
+// FindByBarcode mocks base method.
+func (m *MockInventoryPGRepository) FindByBarcode(ctx context.Context, barcode string) (*models.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBarcode", ctx, barcode)
+	ret0, _ := ret[0].(*models.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBarcode indicates an expected call of FindByBarcode.
+func (mr *MockInventoryPGRepositoryMockRecorder) FindByBarcode(ctx, barcode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBarcode", reflect.TypeOf((*MockInventoryPGRepository)(nil).FindByBarcode), ctx, barcode)
+}
+
+// FindById mocks base method.
+func (m *MockInventoryPGRepository) FindById(ctx context.Context, copyID uuid.UUID) (*models.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, copyID)
+	ret0, _ := ret[0].(*models.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockInventoryPGRepositoryMockRecorder) FindById(ctx, copyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockInventoryPGRepository)(nil).FindById), ctx, copyID)
+}
+
+// ReserveByBookKey mocks base method.
+func (m *MockInventoryPGRepository) ReserveByBookKey(ctx context.Context, bookKey string) (*models.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveByBookKey", ctx, bookKey)
+	ret0, _ := ret[0].(*models.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveByBookKey indicates an expected call of ReserveByBookKey.
+func (mr *MockInventoryPGRepositoryMockRecorder) ReserveByBookKey(ctx, bookKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveByBookKey", reflect.TypeOf((*MockInventoryPGRepository)(nil).ReserveByBookKey), ctx, bookKey)
+}
+
+// UpdateById mocks base method.
+func (m *MockInventoryPGRepository) UpdateById(ctx context.Context, copy *models.Copy) (*models.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, copy)
+	ret0, _ := ret[0].(*models.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockInventoryPGRepositoryMockRecorder) UpdateById(ctx, copy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockInventoryPGRepository)(nil).UpdateById), ctx, copy)
+}
+
+// UpdateStatusById mocks base method.
+func (m *MockInventoryPGRepository) UpdateStatusById(ctx context.Context, copyID uuid.UUID, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusById", ctx, copyID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatusById indicates an expected call of UpdateStatusById.
+func (mr *MockInventoryPGRepositoryMockRecorder) UpdateStatusById(ctx, copyID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusById", reflect.TypeOf((*MockInventoryPGRepository)(nil).UpdateStatusById), ctx, copyID, status)
+}
@@ -0,0 +1,258 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/pinjembuku/internal/models"
+	utils "github.com/dinorain/pinjembuku/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockInventoryUseCase is a mock of InventoryUseCase interface.
+type MockInventoryUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryUseCaseMockRecorder
+}
+
+// MockInventoryUseCaseMockRecorder is the mock recorder for MockInventoryUseCase.
+type MockInventoryUseCaseMockRecorder struct {
+	mock *MockInventoryUseCase
+}
+
+// NewMockInventoryUseCase creates a new mock instance.
+func NewMockInventoryUseCase(ctrl *gomock.Controller) *MockInventoryUseCase {
+	mock := &MockInventoryUseCase{ctrl: ctrl}
+	mock.recorder = &MockInventoryUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryUseCase) EXPECT() *MockInventoryUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CountAll mocks base method.
+func (m *MockInventoryUseCase) CountAll(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockInventoryUseCaseMockRecorder) CountAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockInventoryUseCase)(nil).CountAll), ctx)
+}
+
+// CountAllByBookKey mocks base method.
+func (m *MockInventoryUseCase) CountAllByBookKey(ctx context.Context, bookKey string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllByBookKey", ctx, bookKey)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllByBookKey indicates an expected call of CountAllByBookKey.
+func (mr *MockInventoryUseCaseMockRecorder) CountAllByBookKey(ctx, bookKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllByBookKey", reflect.TypeOf((*MockInventoryUseCase)(nil).CountAllByBookKey), ctx, bookKey)
+}
+
+// CountAvailableByBookKey mocks base method.
+func (m *MockInventoryUseCase) CountAvailableByBookKey(ctx context.Context, bookKey string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAvailableByBookKey", ctx, bookKey)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAvailableByBookKey indicates an expected call of CountAvailableByBookKey.
+func (mr *MockInventoryUseCaseMockRecorder) CountAvailableByBookKey(ctx, bookKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAvailableByBookKey", reflect.TypeOf((*MockInventoryUseCase)(nil).CountAvailableByBookKey), ctx, bookKey)
+}
+
+// Create mocks base method.
+func (m *MockInventoryUseCase) Create(ctx context.Context, copy *models.Copy) (*models.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, copy)
+	ret0, _ := ret[0].(*models.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInventoryUseCaseMockRecorder) Create(ctx, copy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInventoryUseCase)(nil).Create), ctx, copy)
+}
+
+// DeleteById mocks base method.
+func (m *MockInventoryUseCase) DeleteById(ctx context.Context, copyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, copyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockInventoryUseCaseMockRecorder) DeleteById(ctx, copyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockInventoryUseCase)(nil).DeleteById), ctx, copyID)
+}
+
+// FindAll mocks base method.
+func (m *MockInventoryUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret0, _ := ret[0].([]models.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockInventoryUseCaseMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockInventoryUseCase)(nil).FindAll), ctx, pagination)
+}
+
+// FindAllByBookKey mocks base method.
+func (m *MockInventoryUseCase) FindAllByBookKey(ctx context.Context, bookKey string, pagination *utils.Pagination) ([]models.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBookKey", ctx, bookKey, pagination)
+	ret0, _ := ret[0].([]models.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBookKey indicates an expected call of FindAllByBookKey.
+func (mr *MockInventoryUseCaseMockRecorder) FindAllByBookKey(ctx, bookKey, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBookKey", reflect.TypeOf((*MockInventoryUseCase)(nil).FindAllByBookKey), ctx, bookKey, pagination)
+}
+
+// FindByBarcode mocks base method.
+func (m *MockInventoryUseCase) FindByBarcode(ctx context.Context, barcode string) (*models.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBarcode", ctx, barcode)
+	ret0, _ := ret[0].(*models.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBarcode indicates an expected call of FindByBarcode.
+func (mr *MockInventoryUseCaseMockRecorder) FindByBarcode(ctx, barcode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBarcode", reflect.TypeOf((*MockInventoryUseCase)(nil).FindByBarcode), ctx, barcode)
+}
+
+// FindById mocks base method.
+func (m *MockInventoryUseCase) FindById(ctx context.Context, copyID uuid.UUID) (*models.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, copyID)
+	ret0, _ := ret[0].(*models.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockInventoryUseCaseMockRecorder) FindById(ctx, copyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockInventoryUseCase)(nil).FindById), ctx, copyID)
+}
+
+// HoldById mocks base method.
+func (m *MockInventoryUseCase) HoldById(ctx context.Context, copyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HoldById", ctx, copyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HoldById indicates an expected call of HoldById.
+func (mr *MockInventoryUseCaseMockRecorder) HoldById(ctx, copyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldById", reflect.TypeOf((*MockInventoryUseCase)(nil).HoldById), ctx, copyID)
+}
+
+// LoanById mocks base method.
+func (m *MockInventoryUseCase) LoanById(ctx context.Context, copyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoanById", ctx, copyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoanById indicates an expected call of LoanById.
+func (mr *MockInventoryUseCaseMockRecorder) LoanById(ctx, copyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoanById", reflect.TypeOf((*MockInventoryUseCase)(nil).LoanById), ctx, copyID)
+}
+
+// MarkLostById mocks base method.
+func (m *MockInventoryUseCase) MarkLostById(ctx context.Context, copyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkLostById", ctx, copyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkLostById indicates an expected call of MarkLostById.
+func (mr *MockInventoryUseCaseMockRecorder) MarkLostById(ctx, copyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkLostById", reflect.TypeOf((*MockInventoryUseCase)(nil).MarkLostById), ctx, copyID)
+}
+
+// ReleaseById mocks base method.
+func (m *MockInventoryUseCase) ReleaseById(ctx context.Context, copyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseById", ctx, copyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseById indicates an expected call of ReleaseById.
+func (mr *MockInventoryUseCaseMockRecorder) ReleaseById(ctx, copyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseById", reflect.TypeOf((*MockInventoryUseCase)(nil).ReleaseById), ctx, copyID)
+}
+
+// ReserveByBookKey mocks base method.
+func (m *MockInventoryUseCase) ReserveByBookKey(ctx context.Context, bookKey string) (*models.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveByBookKey", ctx, bookKey)
+	ret0, _ := ret[0].(*models.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveByBookKey indicates an expected call of ReserveByBookKey.
+func (mr *MockInventoryUseCaseMockRecorder) ReserveByBookKey(ctx, bookKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveByBookKey", reflect.TypeOf((*MockInventoryUseCase)(nil).ReserveByBookKey), ctx, bookKey)
+}
+
+// UpdateById mocks base method.
+func (m *MockInventoryUseCase) UpdateById(ctx context.Context, copy *models.Copy) (*models.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, copy)
+	ret0, _ := ret[0].(*models.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockInventoryUseCaseMockRecorder) UpdateById(ctx, copy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockInventoryUseCase)(nil).UpdateById), ctx, copy)
+}
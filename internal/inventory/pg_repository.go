@@ -0,0 +1,27 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package inventory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+// Inventory pg repository
+type InventoryPGRepository interface {
+	Create(ctx context.Context, copy *models.Copy) (*models.Copy, error)
+	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Copy, error)
+	CountAll(ctx context.Context) (int, error)
+	FindAllByBookKey(ctx context.Context, bookKey string, pagination *utils.Pagination) ([]models.Copy, error)
+	CountAllByBookKey(ctx context.Context, bookKey string) (int, error)
+	CountAvailableByBookKey(ctx context.Context, bookKey string) (int, error)
+	FindById(ctx context.Context, copyID uuid.UUID) (*models.Copy, error)
+	FindByBarcode(ctx context.Context, barcode string) (*models.Copy, error)
+	UpdateById(ctx context.Context, copy *models.Copy) (*models.Copy, error)
+	UpdateStatusById(ctx context.Context, copyID uuid.UUID, status string) error
+	ReserveByBookKey(ctx context.Context, bookKey string) (*models.Copy, error)
+	DeleteById(ctx context.Context, copyID uuid.UUID) error
+}
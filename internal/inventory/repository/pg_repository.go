@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/internal/inventory"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+// Inventory repository
+type InventoryRepository struct {
+	db *sqlx.DB
+}
+
+var _ inventory.InventoryPGRepository = (*InventoryRepository)(nil)
+
+// Inventory repository constructor
+func NewInventoryPGRepository(db *sqlx.DB) *InventoryRepository {
+	return &InventoryRepository{db: db}
+}
+
+// Create new copy
+func (r *InventoryRepository) Create(ctx context.Context, copy *models.Copy) (*models.Copy, error) {
+	createdCopy := &models.Copy{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createCopyQuery,
+		copy.Barcode,
+		copy.BookKey,
+		copy.Condition,
+		copy.Location,
+		copy.Status,
+	).StructScan(createdCopy); err != nil {
+		return nil, errors.Wrap(err, "InventoryPGRepository.Create.QueryRowxContext")
+	}
+
+	return createdCopy, nil
+}
+
+// UpdateById update existing copy
+func (r *InventoryRepository) UpdateById(ctx context.Context, copy *models.Copy) (*models.Copy, error) {
+	updatedCopy := &models.Copy{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		updateByIdQuery,
+		copy.CopyID,
+		copy.Barcode,
+		copy.BookKey,
+		copy.Condition,
+		copy.Location,
+		copy.Status,
+	).StructScan(updatedCopy); err != nil {
+		return nil, errors.Wrap(err, "InventoryPGRepository.UpdateById.QueryRowxContext")
+	}
+
+	return updatedCopy, nil
+}
+
+// UpdateStatusById update status of existing copy
+func (r *InventoryRepository) UpdateStatusById(ctx context.Context, copyID uuid.UUID, status string) error {
+	if res, err := r.db.ExecContext(ctx, updateStatusByIdQuery, copyID, status); err != nil {
+		return errors.Wrap(err, "InventoryPGRepository.UpdateStatusById.ExecContext")
+	} else {
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "InventoryPGRepository.UpdateStatusById.RowsAffected")
+		} else if cnt == 0 {
+			return sql.ErrNoRows
+		}
+	}
+
+	return nil
+}
+
+// ReserveByBookKey mark the oldest available copy of book as on loan
+func (r *InventoryRepository) ReserveByBookKey(ctx context.Context, bookKey string) (*models.Copy, error) {
+	reservedCopy := &models.Copy{}
+	if err := r.db.QueryRowxContext(ctx, reserveByBookKeyQuery, bookKey).StructScan(reservedCopy); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, grpc_errors.ErrNoCopyAvailable
+		}
+		return nil, errors.Wrap(err, "InventoryPGRepository.ReserveByBookKey.QueryRowxContext")
+	}
+
+	return reservedCopy, nil
+}
+
+// FindAll Find copies
+func (r *InventoryRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Copy, error) {
+	var copies []models.Copy
+	if err := r.db.SelectContext(ctx, &copies, findAllQuery, pagination.GetLimit(), pagination.GetOffset()); err != nil {
+		return nil, errors.Wrap(err, "InventoryPGRepository.FindAll.SelectContext")
+	}
+
+	return copies, nil
+}
+
+// CountAll Count copies
+func (r *InventoryRepository) CountAll(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, countAllQuery); err != nil {
+		return 0, errors.Wrap(err, "InventoryPGRepository.CountAll.GetContext")
+	}
+
+	return count, nil
+}
+
+// FindAllByBookKey Find copies of book
+func (r *InventoryRepository) FindAllByBookKey(ctx context.Context, bookKey string, pagination *utils.Pagination) ([]models.Copy, error) {
+	var copies []models.Copy
+	if err := r.db.SelectContext(ctx, &copies, findAllByBookKeyQuery, bookKey, pagination.GetLimit(), pagination.GetOffset()); err != nil {
+		return nil, errors.Wrap(err, "InventoryPGRepository.FindAllByBookKey.SelectContext")
+	}
+
+	return copies, nil
+}
+
+// CountAllByBookKey Count copies of book
+func (r *InventoryRepository) CountAllByBookKey(ctx context.Context, bookKey string) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, countAllByBookKeyQuery, bookKey); err != nil {
+		return 0, errors.Wrap(err, "InventoryPGRepository.CountAllByBookKey.GetContext")
+	}
+
+	return count, nil
+}
+
+// CountAvailableByBookKey Count available copies of book
+func (r *InventoryRepository) CountAvailableByBookKey(ctx context.Context, bookKey string) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, countAvailableByBookKeyQuery, bookKey); err != nil {
+		return 0, errors.Wrap(err, "InventoryPGRepository.CountAvailableByBookKey.GetContext")
+	}
+
+	return count, nil
+}
+
+// FindById Find copy by uuid
+func (r *InventoryRepository) FindById(ctx context.Context, copyID uuid.UUID) (*models.Copy, error) {
+	copy := &models.Copy{}
+	if err := r.db.GetContext(ctx, copy, findByIdQuery, copyID); err != nil {
+		return nil, errors.Wrap(err, "InventoryPGRepository.FindById.GetContext")
+	}
+
+	return copy, nil
+}
+
+// FindByBarcode Find copy by barcode
+func (r *InventoryRepository) FindByBarcode(ctx context.Context, barcode string) (*models.Copy, error) {
+	copy := &models.Copy{}
+	if err := r.db.GetContext(ctx, copy, findByBarcodeQuery, barcode); err != nil {
+		return nil, errors.Wrap(err, "InventoryPGRepository.FindByBarcode.GetContext")
+	}
+
+	return copy, nil
+}
+
+// DeleteById Delete copy by uuid, a copy still referenced by orders or holds is refused with grpc_errors.ErrCopyInUse
+func (r *InventoryRepository) DeleteById(ctx context.Context, copyID uuid.UUID) error {
+	if res, err := r.db.ExecContext(ctx, deleteByIdQuery, copyID); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == "foreign_key_violation" {
+			return errors.Wrap(grpc_errors.ErrCopyInUse, "copy is referenced by orders or holds")
+		}
+		return errors.Wrap(err, "InventoryPGRepository.DeleteById.ExecContext")
+	} else {
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "InventoryPGRepository.DeleteById.RowsAffected")
+		} else if cnt == 0 {
+			return sql.ErrNoRows
+		}
+	}
+
+	return nil
+}
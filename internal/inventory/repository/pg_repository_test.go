@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+var copyColumns = []string{"copy_id", "barcode", "book_key", "condition", "location", "status", "created_at", "updated_at"}
+
+func newMockCopy() *models.Copy {
+	location := "Shelf A1"
+	return &models.Copy{
+		CopyID:    uuid.New(),
+		Barcode:   "PJB-000001",
+		BookKey:   "/works/OL66554W",
+		Condition: models.CopyConditionGood,
+		Location:  &location,
+		Status:    models.CopyStatusAvailable,
+	}
+}
+
+func TestInventoryRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	inventoryPGRepository := NewInventoryPGRepository(sqlxDB)
+
+	mockCopy := newMockCopy()
+	rows := sqlmock.NewRows(copyColumns).AddRow(
+		mockCopy.CopyID,
+		mockCopy.Barcode,
+		mockCopy.BookKey,
+		mockCopy.Condition,
+		mockCopy.Location,
+		mockCopy.Status,
+		time.Now(),
+		time.Now(),
+	)
+
+	mock.ExpectQuery(createCopyQuery).WithArgs(
+		mockCopy.Barcode,
+		mockCopy.BookKey,
+		mockCopy.Condition,
+		mockCopy.Location,
+		mockCopy.Status,
+	).WillReturnRows(rows)
+
+	createdCopy, err := inventoryPGRepository.Create(context.Background(), mockCopy)
+	require.NoError(t, err)
+	require.Equal(t, mockCopy.CopyID, createdCopy.CopyID)
+}
+
+func TestInventoryRepository_FindAllByBookKey(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	inventoryPGRepository := NewInventoryPGRepository(sqlxDB)
+
+	mockCopy := newMockCopy()
+	rows := sqlmock.NewRows(copyColumns).AddRow(
+		mockCopy.CopyID,
+		mockCopy.Barcode,
+		mockCopy.BookKey,
+		mockCopy.Condition,
+		mockCopy.Location,
+		mockCopy.Status,
+		time.Now(),
+		time.Now(),
+	)
+
+	size := 10
+	mock.ExpectQuery(findAllByBookKeyQuery).WithArgs(mockCopy.BookKey, size, 0).WillReturnRows(rows)
+
+	copies, err := inventoryPGRepository.FindAllByBookKey(context.Background(), mockCopy.BookKey, utils.NewPaginationQuery(size, 1))
+	require.NoError(t, err)
+	require.Len(t, copies, 1)
+}
+
+func TestInventoryRepository_ReserveByBookKey(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	inventoryPGRepository := NewInventoryPGRepository(sqlxDB)
+
+	mockCopy := newMockCopy()
+	rows := sqlmock.NewRows(copyColumns).AddRow(
+		mockCopy.CopyID,
+		mockCopy.Barcode,
+		mockCopy.BookKey,
+		mockCopy.Condition,
+		mockCopy.Location,
+		models.CopyStatusOnLoan,
+		time.Now(),
+		time.Now(),
+	)
+
+	mock.ExpectQuery(reserveByBookKeyQuery).WithArgs(mockCopy.BookKey).WillReturnRows(rows)
+
+	reservedCopy, err := inventoryPGRepository.ReserveByBookKey(context.Background(), mockCopy.BookKey)
+	require.NoError(t, err)
+	require.Equal(t, models.CopyStatusOnLoan, reservedCopy.Status)
+
+	mock.ExpectQuery(reserveByBookKeyQuery).WithArgs(mockCopy.BookKey).WillReturnRows(sqlmock.NewRows(copyColumns))
+
+	_, err = inventoryPGRepository.ReserveByBookKey(context.Background(), mockCopy.BookKey)
+	require.True(t, errors.Is(err, grpc_errors.ErrNoCopyAvailable))
+}
+
+func TestInventoryRepository_UpdateStatusById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	inventoryPGRepository := NewInventoryPGRepository(sqlxDB)
+
+	copyUUID := uuid.New()
+	mock.ExpectExec(updateStatusByIdQuery).WithArgs(copyUUID, models.CopyStatusAvailable).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = inventoryPGRepository.UpdateStatusById(context.Background(), copyUUID, models.CopyStatusAvailable)
+	require.NoError(t, err)
+}
+
+func TestInventoryRepository_DeleteById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	inventoryPGRepository := NewInventoryPGRepository(sqlxDB)
+
+	copyUUID := uuid.New()
+	mock.ExpectExec(deleteByIdQuery).WithArgs(copyUUID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = inventoryPGRepository.DeleteById(context.Background(), copyUUID)
+	require.NoError(t, err)
+
+	mock.ExpectExec(deleteByIdQuery).WithArgs(copyUUID).WillReturnError(&pq.Error{Code: "23503"})
+
+	err = inventoryPGRepository.DeleteById(context.Background(), copyUUID)
+	require.True(t, errors.Is(err, grpc_errors.ErrCopyInUse))
+}
@@ -0,0 +1,32 @@
+package repository
+
+const (
+	createCopyQuery = `INSERT INTO copies (barcode, book_key, condition, location, status) 
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING copy_id, barcode, book_key, condition, location, status, created_at, updated_at`
+
+	findByIdQuery = `SELECT copy_id, barcode, book_key, condition, location, status, created_at, updated_at FROM copies WHERE copy_id = $1`
+
+	findByBarcodeQuery = `SELECT copy_id, barcode, book_key, condition, location, status, created_at, updated_at FROM copies WHERE barcode = $1`
+
+	findAllQuery = `SELECT copy_id, barcode, book_key, condition, location, status, created_at, updated_at FROM copies LIMIT $1 OFFSET $2`
+
+	findAllByBookKeyQuery = `SELECT copy_id, barcode, book_key, condition, location, status, created_at, updated_at FROM copies WHERE book_key = $1 LIMIT $2 OFFSET $3`
+
+	countAvailableByBookKeyQuery = `SELECT COUNT(*) FROM copies WHERE book_key = $1 AND status = 'available'`
+
+	updateByIdQuery = `UPDATE copies SET barcode = $2, book_key = $3, condition = $4, location = $5, status = $6, updated_at = CURRENT_TIMESTAMP WHERE copy_id = $1
+		RETURNING copy_id, barcode, book_key, condition, location, status, created_at, updated_at`
+
+	updateStatusByIdQuery = `UPDATE copies SET status = $2, updated_at = CURRENT_TIMESTAMP WHERE copy_id = $1`
+
+	reserveByBookKeyQuery = `UPDATE copies SET status = 'on_loan', updated_at = CURRENT_TIMESTAMP
+		WHERE copy_id = (SELECT copy_id FROM copies WHERE book_key = $1 AND status = 'available' ORDER BY created_at LIMIT 1 FOR UPDATE SKIP LOCKED)
+		RETURNING copy_id, barcode, book_key, condition, location, status, created_at, updated_at`
+
+	deleteByIdQuery = `DELETE FROM copies WHERE copy_id = $1`
+
+	countAllQuery = `SELECT COUNT(*) FROM copies`
+
+	countAllByBookKeyQuery = `SELECT COUNT(*) FROM copies WHERE book_key = $1`
+)
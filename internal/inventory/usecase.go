@@ -0,0 +1,30 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package inventory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+//  Inventory UseCase interface
+type InventoryUseCase interface {
+	Create(ctx context.Context, copy *models.Copy) (*models.Copy, error)
+	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Copy, error)
+	CountAll(ctx context.Context) (int, error)
+	FindAllByBookKey(ctx context.Context, bookKey string, pagination *utils.Pagination) ([]models.Copy, error)
+	CountAllByBookKey(ctx context.Context, bookKey string) (int, error)
+	CountAvailableByBookKey(ctx context.Context, bookKey string) (int, error)
+	FindById(ctx context.Context, copyID uuid.UUID) (*models.Copy, error)
+	FindByBarcode(ctx context.Context, barcode string) (*models.Copy, error)
+	UpdateById(ctx context.Context, copy *models.Copy) (*models.Copy, error)
+	ReserveByBookKey(ctx context.Context, bookKey string) (*models.Copy, error)
+	ReleaseById(ctx context.Context, copyID uuid.UUID) error
+	MarkLostById(ctx context.Context, copyID uuid.UUID) error
+	HoldById(ctx context.Context, copyID uuid.UUID) error
+	LoanById(ctx context.Context, copyID uuid.UUID) error
+	DeleteById(ctx context.Context, copyID uuid.UUID) error
+}
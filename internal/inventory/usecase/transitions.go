@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+)
+
+// copyTransitions maps every status to the statuses a librarian may set by hand.
+// Copies on loan or on hold are moved by their order or hold only.
+var copyTransitions = map[string][]string{
+	models.CopyStatusAvailable: {models.CopyStatusLost, models.CopyStatusWithdrawn},
+	models.CopyStatusLost:      {models.CopyStatusAvailable, models.CopyStatusWithdrawn},
+	models.CopyStatusWithdrawn: {models.CopyStatusAvailable},
+}
+
+// checkTransition verify that copy may be moved from status to next by hand
+func checkTransition(status string, next string) error {
+	if status == next {
+		return nil
+	}
+
+	for _, allowed := range copyTransitions[status] {
+		if allowed == next {
+			return nil
+		}
+	}
+
+	return errors.Wrapf(grpc_errors.ErrInvalidStatus, "%s -> %s", status, next)
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/inventory"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+// Inventory UseCase
+type inventoryUseCase struct {
+	cfg             *config.Config
+	logger          logger.Logger
+	inventoryPgRepo inventory.InventoryPGRepository
+}
+
+var _ inventory.InventoryUseCase = (*inventoryUseCase)(nil)
+
+// New Inventory UseCase
+func NewInventoryUseCase(cfg *config.Config, logger logger.Logger, inventoryRepo inventory.InventoryPGRepository) *inventoryUseCase {
+	return &inventoryUseCase{cfg: cfg, logger: logger, inventoryPgRepo: inventoryRepo}
+}
+
+// Create new copy
+func (u *inventoryUseCase) Create(ctx context.Context, copy *models.Copy) (*models.Copy, error) {
+	bookKey, err := utils.NormalizeBookKey(copy.BookKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "utils.NormalizeBookKey")
+	}
+	copy.BookKey = bookKey
+
+	createdCopy, err := u.inventoryPgRepo.Create(ctx, copy)
+	if err != nil {
+		return nil, errors.Wrap(err, "inventoryPgRepo.Create")
+	}
+
+	return createdCopy, nil
+}
+
+// FindAll find copies
+func (u *inventoryUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Copy, error) {
+	copies, err := u.inventoryPgRepo.FindAll(ctx, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "inventoryPgRepo.FindAll")
+	}
+
+	return copies, nil
+}
+
+// CountAll count copies
+func (u *inventoryUseCase) CountAll(ctx context.Context) (int, error) {
+	count, err := u.inventoryPgRepo.CountAll(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "inventoryPgRepo.CountAll")
+	}
+
+	return count, nil
+}
+
+// FindAllByBookKey find copies of book
+func (u *inventoryUseCase) FindAllByBookKey(ctx context.Context, bookKey string, pagination *utils.Pagination) ([]models.Copy, error) {
+	copies, err := u.inventoryPgRepo.FindAllByBookKey(ctx, bookKey, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "inventoryPgRepo.FindAllByBookKey")
+	}
+
+	return copies, nil
+}
+
+// CountAllByBookKey count copies of book
+func (u *inventoryUseCase) CountAllByBookKey(ctx context.Context, bookKey string) (int, error) {
+	count, err := u.inventoryPgRepo.CountAllByBookKey(ctx, bookKey)
+	if err != nil {
+		return 0, errors.Wrap(err, "inventoryPgRepo.CountAllByBookKey")
+	}
+
+	return count, nil
+}
+
+// CountAvailableByBookKey count available copies of book
+func (u *inventoryUseCase) CountAvailableByBookKey(ctx context.Context, bookKey string) (int, error) {
+	count, err := u.inventoryPgRepo.CountAvailableByBookKey(ctx, bookKey)
+	if err != nil {
+		return 0, errors.Wrap(err, "inventoryPgRepo.CountAvailableByBookKey")
+	}
+
+	return count, nil
+}
+
+// FindById find copy by uuid
+func (u *inventoryUseCase) FindById(ctx context.Context, copyID uuid.UUID) (*models.Copy, error) {
+	foundCopy, err := u.inventoryPgRepo.FindById(ctx, copyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "inventoryPgRepo.FindById")
+	}
+
+	return foundCopy, nil
+}
+
+// FindByBarcode find copy by barcode
+func (u *inventoryUseCase) FindByBarcode(ctx context.Context, barcode string) (*models.Copy, error) {
+	foundCopy, err := u.inventoryPgRepo.FindByBarcode(ctx, barcode)
+	if err != nil {
+		return nil, errors.Wrap(err, "inventoryPgRepo.FindByBarcode")
+	}
+
+	return foundCopy, nil
+}
+
+// UpdateById update copy by uuid, status may only change between available, lost and withdrawn
+func (u *inventoryUseCase) UpdateById(ctx context.Context, copy *models.Copy) (*models.Copy, error) {
+	foundCopy, err := u.inventoryPgRepo.FindById(ctx, copy.CopyID)
+	if err != nil {
+		return nil, errors.Wrap(err, "inventoryPgRepo.FindById")
+	}
+
+	if err := checkTransition(foundCopy.Status, copy.Status); err != nil {
+		return nil, errors.Wrap(err, "checkTransition")
+	}
+
+	updatedCopy, err := u.inventoryPgRepo.UpdateById(ctx, copy)
+	if err != nil {
+		return nil, errors.Wrap(err, "inventoryPgRepo.UpdateById")
+	}
+
+	return updatedCopy, nil
+}
+
+// ReserveByBookKey take an available copy of book out of stock
+func (u *inventoryUseCase) ReserveByBookKey(ctx context.Context, bookKey string) (*models.Copy, error) {
+	reservedCopy, err := u.inventoryPgRepo.ReserveByBookKey(ctx, bookKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "inventoryPgRepo.ReserveByBookKey")
+	}
+
+	return reservedCopy, nil
+}
+
+// ReleaseById put copy back in stock
+func (u *inventoryUseCase) ReleaseById(ctx context.Context, copyID uuid.UUID) error {
+	if err := u.inventoryPgRepo.UpdateStatusById(ctx, copyID, models.CopyStatusAvailable); err != nil {
+		return errors.Wrap(err, "inventoryPgRepo.UpdateStatusById")
+	}
+
+	return nil
+}
+
+// MarkLostById take copy out of circulation as lost
+func (u *inventoryUseCase) MarkLostById(ctx context.Context, copyID uuid.UUID) error {
+	if err := u.inventoryPgRepo.UpdateStatusById(ctx, copyID, models.CopyStatusLost); err != nil {
+		return errors.Wrap(err, "inventoryPgRepo.UpdateStatusById")
+	}
+
+	return nil
+}
+
+// HoldById set copy aside for the holder it was allocated to
+func (u *inventoryUseCase) HoldById(ctx context.Context, copyID uuid.UUID) error {
+	if err := u.inventoryPgRepo.UpdateStatusById(ctx, copyID, models.CopyStatusOnHold); err != nil {
+		return errors.Wrap(err, "inventoryPgRepo.UpdateStatusById")
+	}
+
+	return nil
+}
+
+// LoanById take copy out of stock for a loan
+func (u *inventoryUseCase) LoanById(ctx context.Context, copyID uuid.UUID) error {
+	if err := u.inventoryPgRepo.UpdateStatusById(ctx, copyID, models.CopyStatusOnLoan); err != nil {
+		return errors.Wrap(err, "inventoryPgRepo.UpdateStatusById")
+	}
+
+	return nil
+}
+
+// DeleteById delete copy by uuid, copies on loan or on hold are refused
+func (u *inventoryUseCase) DeleteById(ctx context.Context, copyID uuid.UUID) error {
+	foundCopy, err := u.inventoryPgRepo.FindById(ctx, copyID)
+	if err != nil {
+		return errors.Wrap(err, "inventoryPgRepo.FindById")
+	}
+
+	if foundCopy.Status == models.CopyStatusOnLoan || foundCopy.Status == models.CopyStatusOnHold {
+		return errors.Wrapf(grpc_errors.ErrCopyInUse, "copy is %s", foundCopy.Status)
+	}
+
+	if err := u.inventoryPgRepo.DeleteById(ctx, copyID); err != nil {
+		return errors.Wrap(err, "inventoryPgRepo.DeleteById")
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/inventory/mock"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+)
+
+func TestInventoryUseCase_ReserveByBookKey(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inventoryPGRepository := mock.NewMockInventoryPGRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	inventoryUC := NewInventoryUseCase(&config.Config{}, apiLogger, inventoryPGRepository)
+
+	ctx := context.Background()
+	bookKey := "/works/OL66554W"
+	mockCopy := &models.Copy{CopyID: uuid.New(), BookKey: bookKey, Status: models.CopyStatusOnLoan}
+
+	inventoryPGRepository.EXPECT().ReserveByBookKey(gomock.Any(), bookKey).Return(mockCopy, nil)
+
+	reservedCopy, err := inventoryUC.ReserveByBookKey(ctx, bookKey)
+	require.NoError(t, err)
+	require.Equal(t, mockCopy.CopyID, reservedCopy.CopyID)
+
+	inventoryPGRepository.EXPECT().ReserveByBookKey(gomock.Any(), bookKey).Return(nil, grpc_errors.ErrNoCopyAvailable)
+
+	_, err = inventoryUC.ReserveByBookKey(ctx, bookKey)
+	require.True(t, errors.Is(err, grpc_errors.ErrNoCopyAvailable))
+}
+
+func TestInventoryUseCase_ReleaseById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inventoryPGRepository := mock.NewMockInventoryPGRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	inventoryUC := NewInventoryUseCase(&config.Config{}, apiLogger, inventoryPGRepository)
+
+	copyUUID := uuid.New()
+	inventoryPGRepository.EXPECT().UpdateStatusById(gomock.Any(), copyUUID, models.CopyStatusAvailable).Return(nil)
+
+	err := inventoryUC.ReleaseById(context.Background(), copyUUID)
+	require.NoError(t, err)
+}
+
+func TestInventoryUseCase_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inventoryPGRepository := mock.NewMockInventoryPGRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	inventoryUC := NewInventoryUseCase(&config.Config{}, apiLogger, inventoryPGRepository)
+
+	ctx := context.Background()
+	mockCopy := &models.Copy{Barcode: "BC-1", BookKey: "ol66554w", Status: models.CopyStatusAvailable}
+
+	inventoryPGRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, copy *models.Copy) (*models.Copy, error) {
+		require.Equal(t, "/works/OL66554W", copy.BookKey)
+		return copy, nil
+	})
+
+	_, err := inventoryUC.Create(ctx, mockCopy)
+	require.NoError(t, err)
+
+	_, err = inventoryUC.Create(ctx, &models.Copy{Barcode: "BC-2", BookKey: "/books/OL7353617M"})
+	require.True(t, errors.Is(err, grpc_errors.ErrInvalidBookKey))
+}
+
+func TestInventoryUseCase_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inventoryPGRepository := mock.NewMockInventoryPGRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	inventoryUC := NewInventoryUseCase(&config.Config{}, apiLogger, inventoryPGRepository)
+
+	ctx := context.Background()
+	copyUUID := uuid.New()
+
+	for _, tc := range []struct {
+		from    string
+		to      string
+		allowed bool
+	}{
+		{models.CopyStatusAvailable, models.CopyStatusAvailable, true},
+		{models.CopyStatusAvailable, models.CopyStatusWithdrawn, true},
+		{models.CopyStatusLost, models.CopyStatusAvailable, true},
+		{models.CopyStatusWithdrawn, models.CopyStatusAvailable, true},
+		{models.CopyStatusAvailable, models.CopyStatusOnLoan, false},
+		{models.CopyStatusOnLoan, models.CopyStatusAvailable, false},
+		{models.CopyStatusOnHold, models.CopyStatusWithdrawn, false},
+		{models.CopyStatusWithdrawn, models.CopyStatusLost, false},
+	} {
+		inventoryPGRepository.EXPECT().FindById(gomock.Any(), copyUUID).Return(&models.Copy{CopyID: copyUUID, Status: tc.from}, nil)
+		updateCandidate := &models.Copy{CopyID: copyUUID, Status: tc.to}
+		if tc.allowed {
+			inventoryPGRepository.EXPECT().UpdateById(gomock.Any(), updateCandidate).Return(updateCandidate, nil)
+		}
+
+		_, err := inventoryUC.UpdateById(ctx, updateCandidate)
+		if tc.allowed {
+			require.NoError(t, err, "%s -> %s", tc.from, tc.to)
+		} else {
+			require.True(t, errors.Is(err, grpc_errors.ErrInvalidStatus), "%s -> %s", tc.from, tc.to)
+		}
+	}
+}
+
+func TestInventoryUseCase_DeleteById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inventoryPGRepository := mock.NewMockInventoryPGRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	inventoryUC := NewInventoryUseCase(&config.Config{}, apiLogger, inventoryPGRepository)
+
+	ctx := context.Background()
+
+	t.Run("Withdrawn", func(t *testing.T) {
+		copyUUID := uuid.New()
+		inventoryPGRepository.EXPECT().FindById(gomock.Any(), copyUUID).Return(&models.Copy{CopyID: copyUUID, Status: models.CopyStatusWithdrawn}, nil)
+		inventoryPGRepository.EXPECT().DeleteById(gomock.Any(), copyUUID).Return(nil)
+
+		err := inventoryUC.DeleteById(ctx, copyUUID)
+		require.NoError(t, err)
+	})
+
+	t.Run("On loan", func(t *testing.T) {
+		copyUUID := uuid.New()
+		inventoryPGRepository.EXPECT().FindById(gomock.Any(), copyUUID).Return(&models.Copy{CopyID: copyUUID, Status: models.CopyStatusOnLoan}, nil)
+
+		err := inventoryUC.DeleteById(ctx, copyUUID)
+		require.True(t, errors.Is(err, grpc_errors.ErrCopyInUse))
+	})
+}
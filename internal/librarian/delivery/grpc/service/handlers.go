@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+	librarianService "github.com/dinorain/pinjembuku/proto/librarian"
+)
+
+// Register new librarian
+func (l *librariansServiceGRPC) Register(ctx context.Context, r *librarianService.RegisterRequest) (*librarianService.RegisterResponse, error) {
+	librarian, err := l.registerReqToLibrarianModel(r)
+	if err != nil {
+		l.logger.Errorf("registerReqToLibrarianModel: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "registerReqToLibrarianModel: %v", err)
+	}
+
+	if err := utils.ValidateStruct(ctx, librarian); err != nil {
+		l.logger.Errorf("ValidateStruct: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "ValidateStruct: %v", err)
+	}
+
+	createdLibrarian, err := l.librarianUC.Register(ctx, librarian)
+	if err != nil {
+		l.logger.Errorf("librarianUC.Register: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "Register: %v", err)
+	}
+
+	return &librarianService.RegisterResponse{Librarian: l.librarianModelToProto(createdLibrarian)}, nil
+}
+
+// Login librarian with email and password
+func (l *librariansServiceGRPC) Login(ctx context.Context, r *librarianService.LoginRequest) (*librarianService.LoginResponse, error) {
+	email := r.GetEmail()
+	if !utils.ValidateEmail(email) {
+		l.logger.Errorf("ValidateEmail: %v", email)
+		return nil, status.Errorf(codes.InvalidArgument, "ValidateEmail: %v", email)
+	}
+
+	ip, userAgent := utils.GetGRPCClientInfo(ctx)
+	librarian, err := l.librarianUC.Login(ctx, email, r.GetPassword(), ip)
+	if err != nil {
+		l.logger.Errorf("librarianUC.Login: %v", err)
+		grpc_errors.SetRetryAfterHeader(ctx, err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "Login: %v", err)
+	}
+
+	session, err := l.sessUC.CreateSession(ctx, &models.Session{
+		UserID:    librarian.LibrarianID,
+		IP:        ip,
+		UserAgent: userAgent,
+	}, l.tokens.RefreshTokenExpire())
+	if err != nil {
+		l.logger.Errorf("sessUC.CreateSession: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "sessUC.CreateSession: %v", err)
+	}
+
+	refreshTokenID, err := l.sessUC.IssueRefreshToken(ctx, session, l.tokens.RefreshTokenExpire())
+	if err != nil {
+		l.logger.Errorf("sessUC.IssueRefreshToken: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "sessUC.IssueRefreshToken: %v", err)
+	}
+
+	accessToken, refreshToken, err := l.librarianUC.GenerateTokenPair(librarian, session, refreshTokenID)
+	if err != nil {
+		l.logger.Errorf("librarianUC.GenerateTokenPair: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "librarianUC.GenerateTokenPair: %v", err)
+	}
+
+	return &librarianService.LoginResponse{
+		Librarian:    l.librarianModelToProto(librarian),
+		SessionId:    session,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// FindByEmail find librarian by email address
+func (l *librariansServiceGRPC) FindByEmail(ctx context.Context, r *librarianService.FindByEmailRequest) (*librarianService.FindByEmailResponse, error) {
+	email := r.GetEmail()
+	if !utils.ValidateEmail(email) {
+		l.logger.Errorf("ValidateEmail: %v", email)
+		return nil, status.Errorf(codes.InvalidArgument, "ValidateEmail: %v", email)
+	}
+
+	librarian, err := l.librarianUC.FindByEmail(ctx, email)
+	if err != nil {
+		l.logger.Errorf("librarianUC.FindByEmail: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "librarianUC.FindByEmail: %v", err)
+	}
+
+	return &librarianService.FindByEmailResponse{Librarian: l.librarianModelToProto(librarian)}, nil
+}
+
+// FindById find librarian by uuid
+func (l *librariansServiceGRPC) FindById(ctx context.Context, r *librarianService.FindByIdRequest) (*librarianService.FindByIdResponse, error) {
+	librarianUUID, err := utils.ParseUUID(r.GetUuid())
+	if err != nil {
+		l.logger.Errorf("utils.ParseUUID: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "utils.ParseUUID: %v", err)
+	}
+
+	librarian, err := l.librarianUC.CachedFindById(ctx, librarianUUID)
+	if err != nil {
+		l.logger.Errorf("librarianUC.CachedFindById: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "librarianUC.CachedFindById: %v", err)
+	}
+
+	return &librarianService.FindByIdResponse{Librarian: l.librarianModelToProto(librarian)}, nil
+}
+
+// FindAll find librarians page by page
+func (l *librariansServiceGRPC) FindAll(ctx context.Context, r *librarianService.FindAllRequest) (*librarianService.FindAllResponse, error) {
+	pq := utils.NewPaginationFromRequest(r.GetSize(), r.GetPage())
+
+	librarians, err := l.librarianUC.FindAll(ctx, pq)
+	if err != nil {
+		l.logger.Errorf("librarianUC.FindAll: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "librarianUC.FindAll: %v", err)
+	}
+
+	totalCount, err := l.librarianUC.CountAll(ctx)
+	if err != nil {
+		l.logger.Errorf("librarianUC.CountAll: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "librarianUC.CountAll: %v", err)
+	}
+
+	librariansProto := make([]*librarianService.Librarian, 0, len(librarians))
+	for i := range librarians {
+		librariansProto = append(librariansProto, l.librarianModelToProto(&librarians[i]))
+	}
+
+	return &librarianService.FindAllResponse{Librarians: librariansProto, TotalCount: int64(totalCount)}, nil
+}
+
+func (l *librariansServiceGRPC) registerReqToLibrarianModel(r *librarianService.RegisterRequest) (*models.Librarian, error) {
+	avatar := r.GetAvatar()
+	librarianCandidate := &models.Librarian{
+		Email:     r.GetEmail(),
+		FirstName: r.GetFirstName(),
+		LastName:  r.GetLastName(),
+		Avatar:    &avatar,
+		Password:  r.GetPassword(),
+	}
+
+	if err := librarianCandidate.PrepareCreate(); err != nil {
+		return nil, err
+	}
+
+	return librarianCandidate, nil
+}
+
+func (l *librariansServiceGRPC) librarianModelToProto(librarian *models.Librarian) *librarianService.Librarian {
+	librarianProto := &librarianService.Librarian{
+		Uuid:      librarian.LibrarianID.String(),
+		FirstName: librarian.FirstName,
+		LastName:  librarian.LastName,
+		Email:     librarian.Email,
+		Avatar:    librarian.GetAvatar(),
+		CreatedAt: timestamppb.New(librarian.CreatedAt),
+		UpdatedAt: timestamppb.New(librarian.UpdatedAt),
+	}
+	return librarianProto
+}
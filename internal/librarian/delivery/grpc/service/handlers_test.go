@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/librarian/mock"
+	"github.com/dinorain/pinjembuku/internal/models"
+	mockSessUC "github.com/dinorain/pinjembuku/internal/session/mock"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/grpc_test_utils"
+	"github.com/dinorain/pinjembuku/pkg/jwtkeys"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+	librarianService "github.com/dinorain/pinjembuku/proto/librarian"
+)
+
+func newLibrarianServiceClient(t *testing.T, ctrl *gomock.Controller) (librarianService.LibrarianServiceClient, *mock.MockLibrarianUseCase, *mockSessUC.MockSessUseCase) {
+	librarianUC := mock.NewMockLibrarianUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+
+	conn := grpc_test_utils.NewBufconnClientConn(t, func(grpcServer *grpc.Server) {
+		librarianService.RegisterLibrarianServiceServer(grpcServer, NewLibrarianServerGRPC(appLogger, cfg, tokens.NewService(cfg, keys), librarianUC, sessUC))
+	})
+
+	return librarianService.NewLibrarianServiceClient(conn), librarianUC, sessUC
+}
+
+func TestLibrariansService_Register(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client, librarianUC, _ := newLibrarianServiceClient(t, ctrl)
+
+	librarianUUID := uuid.New()
+	librarianUC.EXPECT().Register(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, librarian *models.Librarian) (*models.Librarian, error) {
+		require.Equal(t, "email@gmail.com", librarian.Email)
+		require.NotEqual(t, "123456", librarian.Password)
+		librarian.LibrarianID = librarianUUID
+		return librarian, nil
+	})
+
+	res, err := client.Register(context.Background(), &librarianService.RegisterRequest{
+		Email:     "email@gmail.com",
+		FirstName: "FirstName",
+		LastName:  "LastName",
+		Password:  "123456",
+	})
+	require.NoError(t, err)
+	require.Equal(t, librarianUUID.String(), res.GetLibrarian().GetUuid())
+	require.Equal(t, "email@gmail.com", res.GetLibrarian().GetEmail())
+}
+
+func TestLibrariansService_Login(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client, librarianUC, sessUC := newLibrarianServiceClient(t, ctrl)
+
+	librarianUUID := uuid.New()
+	librarianUC.EXPECT().Login(gomock.Any(), "email@gmail.com", "123456", gomock.Any()).Return(&models.Librarian{LibrarianID: librarianUUID, Email: "email@gmail.com"}, nil)
+	sessUC.EXPECT().CreateSession(gomock.Any(), gomock.Any(), 1234).DoAndReturn(func(_ context.Context, session *models.Session, _ int) (string, error) {
+		require.Equal(t, librarianUUID, session.UserID)
+		require.Contains(t, session.UserAgent, "grpc-go")
+		return "s", nil
+	})
+	sessUC.EXPECT().IssueRefreshToken(gomock.Any(), "s", 1234).Return("rt-id", nil)
+	librarianUC.EXPECT().GenerateTokenPair(gomock.Any(), "s", "rt-id").Return("access", "refresh", nil)
+
+	res, err := client.Login(context.Background(), &librarianService.LoginRequest{Email: "email@gmail.com", Password: "123456"})
+	require.NoError(t, err)
+	require.Equal(t, "s", res.GetSessionId())
+	require.Equal(t, "access", res.GetAccessToken())
+	require.Equal(t, "refresh", res.GetRefreshToken())
+	require.Equal(t, librarianUUID.String(), res.GetLibrarian().GetUuid())
+
+	_, err = client.Login(context.Background(), &librarianService.LoginRequest{Email: "email", Password: "123456"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	librarianUC.EXPECT().Login(gomock.Any(), "email@gmail.com", "123456", gomock.Any()).Return(nil, &grpc_errors.RetryError{Err: grpc_errors.ErrAccountLocked, RetryAfter: time.Minute})
+
+	var header metadata.MD
+	_, err = client.Login(context.Background(), &librarianService.LoginRequest{Email: "email@gmail.com", Password: "123456"}, grpc.Header(&header))
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, []string{"60"}, header.Get("retry-after"))
+}
+
+func TestLibrariansService_FindById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client, librarianUC, _ := newLibrarianServiceClient(t, ctrl)
+
+	librarianUUID := uuid.New()
+	librarianUC.EXPECT().CachedFindById(gomock.Any(), librarianUUID).Return(&models.Librarian{LibrarianID: librarianUUID, CreatedAt: time.Now()}, nil)
+
+	res, err := client.FindById(context.Background(), &librarianService.FindByIdRequest{Uuid: librarianUUID.String()})
+	require.NoError(t, err)
+	require.Equal(t, librarianUUID.String(), res.GetLibrarian().GetUuid())
+
+	missingUUID := uuid.New()
+	librarianUC.EXPECT().CachedFindById(gomock.Any(), missingUUID).Return(nil, grpc_errors.ErrNotFound)
+
+	_, err = client.FindById(context.Background(), &librarianService.FindByIdRequest{Uuid: missingUUID.String()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.FindById(context.Background(), &librarianService.FindByIdRequest{Uuid: "invalid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestLibrariansService_FindAll(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client, librarianUC, _ := newLibrarianServiceClient(t, ctrl)
+
+	librarianUC.EXPECT().FindAll(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, pq *utils.Pagination) ([]models.Librarian, error) {
+		require.Equal(t, 2, pq.GetSize())
+		return []models.Librarian{{LibrarianID: uuid.New()}, {LibrarianID: uuid.New()}}, nil
+	})
+	librarianUC.EXPECT().CountAll(gomock.Any()).Return(5, nil)
+
+	res, err := client.FindAll(context.Background(), &librarianService.FindAllRequest{Page: 1, Size: 2})
+	require.NoError(t, err)
+	require.Len(t, res.GetLibrarians(), 2)
+	require.Equal(t, int64(5), res.GetTotalCount())
+}
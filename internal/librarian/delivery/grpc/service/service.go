@@ -0,0 +1,22 @@
+package service
+
+import (
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/librarian"
+	"github.com/dinorain/pinjembuku/internal/session"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
+)
+
+type librariansServiceGRPC struct {
+	logger      logger.Logger
+	cfg         *config.Config
+	tokens      *tokens.Service
+	librarianUC librarian.LibrarianUseCase
+	sessUC      session.SessUseCase
+}
+
+// Librarian service constructor
+func NewLibrarianServerGRPC(logger logger.Logger, cfg *config.Config, tokens *tokens.Service, librarianUC librarian.LibrarianUseCase, sessUC session.SessUseCase) *librariansServiceGRPC {
+	return &librariansServiceGRPC{logger: logger, cfg: cfg, tokens: tokens, librarianUC: librarianUC, sessUC: sessUC}
+}
@@ -0,0 +1,10 @@
+package dto
+
+type LibrarianForgotPasswordRequestDto struct {
+	Email string `json:"email" validate:"required,lte=60,email"`
+}
+
+type LibrarianResetPasswordRequestDto struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+)
+
+type LibrarianSessionResponseDto struct {
+	SessionID string    `json:"session_id"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	Current   bool      `json:"current"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type LibrarianSessionFindResponseDto struct {
+	Data []*LibrarianSessionResponseDto `json:"data"`
+}
+
+func LibrarianSessionResponseFromModel(session *models.Session, currentSessionID string) *LibrarianSessionResponseDto {
+	return &LibrarianSessionResponseDto{
+		SessionID: session.SessionID,
+		IP:        session.IP,
+		UserAgent: session.UserAgent,
+		Current:   session.SessionID == currentSessionID,
+		CreatedAt: session.CreatedAt,
+	}
+}
+
+func LibrarianSessionFindResponseFromModels(sessions []*models.Session, currentSessionID string) *LibrarianSessionFindResponseDto {
+	data := make([]*LibrarianSessionResponseDto, 0, len(sessions))
+	for _, session := range sessions {
+		data = append(data, LibrarianSessionResponseFromModel(session, currentSessionID))
+	}
+	return &LibrarianSessionFindResponseDto{Data: data}
+}
@@ -1,8 +1,8 @@
 package handlers
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"net/http"
 	"strings"
 
@@ -19,8 +19,10 @@ import (
 	"github.com/dinorain/pinjembuku/internal/models"
 	"github.com/dinorain/pinjembuku/internal/session"
 	"github.com/dinorain/pinjembuku/pkg/constants"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
 	httpErrors "github.com/dinorain/pinjembuku/pkg/http_errors"
 	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
 	"github.com/dinorain/pinjembuku/pkg/utils"
 )
 
@@ -30,6 +32,7 @@ type librarianHandlersHTTP struct {
 	cfg      *config.Config
 	mw       middlewares.MiddlewareManager
 	v        *validator.Validate
+	tokens   *tokens.Service
 	librarianUC librarian.LibrarianUseCase
 	sessUC   session.SessUseCase
 }
@@ -42,10 +45,11 @@ func NewLibrarianHandlersHTTP(
 	cfg *config.Config,
 	mw middlewares.MiddlewareManager,
 	v *validator.Validate,
+	tokens *tokens.Service,
 	librarianUC librarian.LibrarianUseCase,
 	sessUC session.SessUseCase,
 ) *librarianHandlersHTTP {
-	return &librarianHandlersHTTP{group: group, logger: logger, cfg: cfg, mw: mw, v: v, librarianUC: librarianUC, sessUC: sessUC}
+	return &librarianHandlersHTTP{group: group, logger: logger, cfg: cfg, mw: mw, v: v, tokens: tokens, librarianUC: librarianUC, sessUC: sessUC}
 }
 
 // Register
@@ -119,21 +123,29 @@ func (h *librarianHandlersHTTP) Login() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, errors.New("invalid email"), h.cfg.Http.DebugErrorsResponse)
 		}
 
-		librarian, err := h.librarianUC.Login(ctx, email, loginDto.Password)
+		librarian, err := h.librarianUC.Login(ctx, email, loginDto.Password, c.RealIP())
 		if err != nil {
 			h.logger.Errorf("librarianUC.Login: %v", email)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
 		session, err := h.sessUC.CreateSession(ctx, &models.Session{
-			UserID: librarian.LibrarianID,
-		}, h.cfg.Session.Expire)
+			UserID:    librarian.LibrarianID,
+			IP:        c.RealIP(),
+			UserAgent: c.Request().UserAgent(),
+		}, h.tokens.RefreshTokenExpire())
 		if err != nil {
 			h.logger.Errorf("sessUC.CreateSession: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		accessToken, refreshToken, err := h.librarianUC.GenerateTokenPair(librarian, session)
+		refreshTokenID, err := h.sessUC.IssueRefreshToken(ctx, session, h.tokens.RefreshTokenExpire())
+		if err != nil {
+			h.logger.Errorf("sessUC.IssueRefreshToken: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		accessToken, refreshToken, err := h.librarianUC.GenerateTokenPair(librarian, session, refreshTokenID)
 		if err != nil {
 			return err
 		}
@@ -145,6 +157,78 @@ func (h *librarianHandlersHTTP) Login() echo.HandlerFunc {
 	}
 }
 
+// ForgotPassword
+// @Tags Librarians
+// @Summary Forgot password
+// @Description Email a password reset link, answers the same whether the email is registered or not
+// @Accept json
+// @Produce json
+// @Param payload body dto.LibrarianForgotPasswordRequestDto true "Payload"
+// @Success 202 {object} nil
+// @Router /librarian/password/forgot [post]
+func (h *librarianHandlersHTTP) ForgotPassword() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		forgotDto := &dto.LibrarianForgotPasswordRequestDto{}
+		if err := c.Bind(forgotDto); err != nil {
+			h.logger.WarnMsg("bind", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.v.StructCtx(ctx, forgotDto); err != nil {
+			h.logger.WarnMsg("validate", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.librarianUC.ForgotPassword(ctx, strings.ToLower(forgotDto.Email)); err != nil {
+			h.logger.Errorf("librarianUC.ForgotPassword: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusAccepted, nil)
+	}
+}
+
+// ResetPassword
+// @Tags Librarians
+// @Summary Reset password
+// @Description Set a new password with the token of the reset link, signs out all sessions
+// @Accept json
+// @Produce json
+// @Param payload body dto.LibrarianResetPasswordRequestDto true "Payload"
+// @Success 200 {object} nil
+// @Router /librarian/password/reset [post]
+func (h *librarianHandlersHTTP) ResetPassword() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		resetDto := &dto.LibrarianResetPasswordRequestDto{}
+		if err := c.Bind(resetDto); err != nil {
+			h.logger.WarnMsg("bind", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.v.StructCtx(ctx, resetDto); err != nil {
+			h.logger.WarnMsg("validate", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		librarian, err := h.librarianUC.ResetPassword(ctx, resetDto.Token, resetDto.Password)
+		if err != nil {
+			h.logger.Warnf("librarianUC.ResetPassword: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.revokeAllSessions(ctx, librarian.LibrarianID); err != nil {
+			h.logger.Errorf("revokeAllSessions: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, nil)
+	}
+}
+
 // FindAll
 // @Tags Librarians
 // @Summary Find all librarians
@@ -153,26 +237,39 @@ func (h *librarianHandlersHTTP) Login() echo.HandlerFunc {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param size query string false "pagination size"
-// @Param page query string false "pagination page"
+// @Param page query string false "pagination page", offset mode sorted by created_at:desc,id:desc
+// @Param cursor query string false "next_cursor of the previous page, takes precedence over page"
 // @Success 200 {object} dto.LibrarianFindResponseDto
 // @Router /librarian [get]
 func (h *librarianHandlersHTTP) FindAll() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
 		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
+		if err := pq.SetCursor(c.QueryParam(constants.Cursor)); err != nil {
+			h.logger.WarnMsg("pq.SetCursor", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
 		librarians, err := h.librarianUC.FindAll(ctx, pq)
 		if err != nil {
 			h.logger.Errorf("librarianUC.FindAll: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
+		totalCount, err := h.librarianUC.CountAll(ctx)
+		if err != nil {
+			h.logger.Errorf("librarianUC.CountAll: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		var last *utils.Cursor
+		if n := len(librarians); n > 0 {
+			last = utils.NewCursor(librarians[n-1].CreatedAt, librarians[n-1].LibrarianID)
+		}
+
 		return c.JSON(http.StatusOK, dto.LibrarianFindResponseDto{
 			Data: librarians,
-			Meta: utils.PaginationMetaDto{
-				Limit:  pq.GetLimit(),
-				Offset: pq.GetOffset(),
-				Page:   pq.GetPage(),
-			},
+			Meta: utils.NewKeysetPaginationMetaDto(pq, totalCount, len(librarians), last),
 		})
 	}
 }
@@ -227,13 +324,13 @@ func (h *librarianHandlersHTTP) UpdateById() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		_, librarianID, role, err := h.getSessionIDFromCtx(c)
+		principal, err := middlewares.PrincipalFromCtx(c)
 		if err != nil {
-			h.logger.Errorf("getSessionIDFromCtx: %v", err)
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		if role != models.UserRoleAdmin && librarianID != librarianUUID.String() {
+		if principal.Role != models.UserRoleAdmin && !(principal.IsLibrarian() && principal.ID == librarianUUID) {
 			return httpErrors.NewForbiddenError(c, nil, h.cfg.Http.DebugErrorsResponse)
 		}
 
@@ -266,6 +363,13 @@ func (h *librarianHandlersHTTP) UpdateById() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
+		if updateDto.Password != nil {
+			if err := h.revokeAllSessions(ctx, librarianUUID); err != nil {
+				h.logger.Errorf("revokeAllSessions: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			}
+		}
+
 		return c.JSON(http.StatusOK, dto.LibrarianResponseFromModel(librarian))
 	}
 }
@@ -295,6 +399,11 @@ func (h *librarianHandlersHTTP) DeleteById() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
+		if err := h.revokeAllSessions(ctx, librarianUUID); err != nil {
+			h.logger.Errorf("revokeAllSessions: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
 		return c.JSON(http.StatusOK, nil)
 	}
 }
@@ -302,7 +411,7 @@ func (h *librarianHandlersHTTP) DeleteById() echo.HandlerFunc {
 // GetMe
 // @Tags Librarians
 // @Summary Find me
-// @Description Get session id from token, find librarian by uuid and returns it
+// @Description Get librarian id from token, find librarian by uuid and returns it
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
@@ -311,22 +420,13 @@ func (h *librarianHandlersHTTP) DeleteById() echo.HandlerFunc {
 func (h *librarianHandlersHTTP) GetMe() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
-		sessID, _, _, err := h.getSessionIDFromCtx(c)
+		principal, err := middlewares.PrincipalFromCtx(c)
 		if err != nil {
-			h.logger.Errorf("getSessionIDFromCtx: %v", err)
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		session, err := h.sessUC.GetSessionById(ctx, sessID)
-		if err != nil {
-			h.logger.Errorf("sessUC.GetSessionById: %v", err)
-			if errors.Is(err, redis.Nil) {
-				return httpErrors.NewUnauthorizedError(c, nil, h.cfg.Http.DebugErrorsResponse)
-			}
-			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
-		}
-
-		librarian, err := h.librarianUC.CachedFindById(ctx, session.UserID)
+		librarian, err := h.librarianUC.CachedFindById(ctx, principal.ID)
 		if err != nil {
 			h.logger.Errorf("librarianUC.CachedFindById: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
@@ -348,16 +448,147 @@ func (h *librarianHandlersHTTP) GetMe() echo.HandlerFunc {
 func (h *librarianHandlersHTTP) Logout() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
-		sessID, _, _, err := h.getSessionIDFromCtx(c)
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.sessUC.DeleteById(ctx, principal.SessionID); err != nil {
+			h.logger.Errorf("sessUC.DeleteById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+		h.mw.ForgetSession(principal.SessionID)
+
+		return c.JSON(http.StatusOK, nil)
+	}
+}
+
+// GetMySessions
+// @Tags Librarians
+// @Summary Find my sessions
+// @Description Find all active sessions of current librarian, newest first
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.LibrarianSessionFindResponseDto
+// @Router /librarian/me/sessions [get]
+func (h *librarianHandlersHTTP) GetMySessions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		sessions, err := h.sessUC.FindAllByUserId(ctx, principal.ID)
+		if err != nil {
+			h.logger.Errorf("sessUC.FindAllByUserId: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, dto.LibrarianSessionFindResponseFromModels(sessions, principal.SessionID))
+	}
+}
+
+// DeleteMySessionById
+// @Tags Librarians
+// @Summary Revoke my session
+// @Description Delete one of sessions of current librarian
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} nil
+// @Param id path string true "Session ID"
+// @Router /librarian/me/sessions/{id} [delete]
+func (h *librarianHandlersHTTP) DeleteMySessionById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		sessID := c.Param("id")
+		session, err := h.sessUC.GetSessionById(ctx, sessID)
 		if err != nil {
-			h.logger.Errorf("getSessionIDFromCtx: %v", err)
+			h.logger.Warnf("sessUC.GetSessionById: %v", err)
+			if errors.Is(err, redis.Nil) {
+				return httpErrors.NewNotFoundError(c, nil, h.cfg.Http.DebugErrorsResponse)
+			}
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
+		if session.UserID != principal.ID {
+			return httpErrors.NewNotFoundError(c, nil, h.cfg.Http.DebugErrorsResponse)
+		}
+
 		if err := h.sessUC.DeleteById(ctx, sessID); err != nil {
 			h.logger.Errorf("sessUC.DeleteById: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
+		h.mw.ForgetSession(sessID)
+
+		return c.JSON(http.StatusOK, nil)
+	}
+}
+
+// DeleteAllSessionsById
+// @Tags Librarians
+// @Summary Revoke all sessions
+// @Description Admin delete all sessions of librarian
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} nil
+// @Param id path string true "Librarian ID"
+// @Router /librarian/{id}/sessions [delete]
+func (h *librarianHandlersHTTP) DeleteAllSessionsById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		librarianUUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.revokeAllSessions(ctx, librarianUUID); err != nil {
+			h.logger.Errorf("revokeAllSessions: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, nil)
+	}
+}
+
+// UnlockById
+// @Tags Librarians
+// @Summary Unlock login
+// @Description Admin reset failed logins of librarian, optionally of a client ip too
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} nil
+// @Param id path string true "Librarian ID"
+// @Param ip query string false "Client IP"
+// @Router /librarian/{id}/lockout [delete]
+func (h *librarianHandlersHTTP) UnlockById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		librarianUUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.librarianUC.UnlockById(ctx, librarianUUID, c.QueryParam("ip")); err != nil {
+			h.logger.Errorf("librarianUC.UnlockById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
 
 		return c.JSON(http.StatusOK, nil)
 	}
@@ -366,7 +597,7 @@ func (h *librarianHandlersHTTP) Logout() echo.HandlerFunc {
 // RefreshToken
 // @Tags Librarians
 // @Summary Refresh access token
-// @Description Refresh access token
+// @Description Refresh access token, refresh tokens are single use and reusing one revokes its session
 // @Accept json
 // @Produce json
 // @Param payload body dto.LibrarianRefreshTokenDto true "Payload"
@@ -382,40 +613,36 @@ func (h *librarianHandlersHTTP) RefreshToken() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		token, err := jwt.Parse(refreshTokenDto.RefreshToken, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				h.logger.Errorf("jwt.SigningMethodHMAC: %v", token.Header["alg"])
-				return nil, fmt.Errorf("jwt.SigningMethodHMAC: %v", token.Header["alg"])
-			}
-
-			return []byte(h.cfg.Server.JwtSecretKey), nil
-		})
-
+		token, err := h.tokens.ParseRefreshToken(refreshTokenDto.RefreshToken)
 		if err != nil {
-			h.logger.Warnf("jwt.Parse")
-			return httpErrors.ErrorCtxResponse(c, errors.New("invalid refresh token"), h.cfg.Http.DebugErrorsResponse)
-		}
-
-		if !token.Valid {
-			h.logger.Warnf("token.Valid")
+			h.logger.Warnf("tokens.ParseRefreshToken: %v", err)
 			return httpErrors.ErrorCtxResponse(c, errors.New("invalid refresh token"), h.cfg.Http.DebugErrorsResponse)
 		}
+		claims := token.Claims.(jwt.MapClaims)
+		sessID := claims["session_id"].(string)
+		refreshTokenID := claims["jti"].(string)
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			h.logger.Warnf("jwt.MapClaims: %+v", token.Claims)
-			return httpErrors.ErrorCtxResponse(c, errors.New("invalid refresh token"), h.cfg.Http.DebugErrorsResponse)
+		session, err := h.sessUC.GetSessionById(ctx, sessID)
+		if err != nil {
+			h.logger.Errorf("sessUC.GetSessionById: %v", err)
+			if errors.Is(err, redis.Nil) {
+				return httpErrors.NewUnauthorizedError(c, nil, h.cfg.Http.DebugErrorsResponse)
+			}
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
-
-		sessID, ok := claims["session_id"].(string)
-		if !ok {
-			h.logger.Warnf("session_id: %+v", claims)
-			return httpErrors.ErrorCtxResponse(c, errors.New("invalid refresh token"), h.cfg.Http.DebugErrorsResponse)
+		if session.UserID.String() != claims["sub"] {
+			h.logger.Warnf("session %s does not belong to %v", sessID, claims["sub"])
+			return httpErrors.NewUnauthorizedError(c, nil, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		session, err := h.sessUC.GetSessionById(ctx, sessID)
+		nextRefreshTokenID, err := h.sessUC.RotateRefreshToken(ctx, sessID, refreshTokenID)
 		if err != nil {
-			h.logger.Errorf("sessUC.GetSessionById: %v", err)
+			if errors.Is(err, grpc_errors.ErrRefreshTokenReused) {
+				h.logger.Warnf("security event: refresh token reuse, revoked session %s of librarian %s, ip: %s", sessID, session.UserID, c.RealIP())
+				h.mw.ForgetSession(sessID)
+				return httpErrors.NewUnauthorizedError(c, nil, h.cfg.Http.DebugErrorsResponse)
+			}
+			h.logger.Errorf("sessUC.RotateRefreshToken: %v", err)
 			if errors.Is(err, redis.Nil) {
 				return httpErrors.NewUnauthorizedError(c, nil, h.cfg.Http.DebugErrorsResponse)
 			}
@@ -428,7 +655,7 @@ func (h *librarianHandlersHTTP) RefreshToken() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		accessToken, refreshToken, err := h.librarianUC.GenerateTokenPair(librarian, sessID)
+		accessToken, refreshToken, err := h.librarianUC.GenerateTokenPair(librarian, sessID, nextRefreshTokenID)
 		if err != nil {
 			return err
 		}
@@ -440,29 +667,17 @@ func (h *librarianHandlersHTTP) RefreshToken() echo.HandlerFunc {
 	}
 }
 
-func (h *librarianHandlersHTTP) getSessionIDFromCtx(c echo.Context) (sessionID string, librarianID string, role string, err error) {
-	user, ok := c.Get("user").(*jwt.Token)
-	if !ok {
-		h.logger.Warnf("jwt.Token: %+v", c.Get("user"))
-		return "", "", "", errors.New("invalid token header")
-	}
-
-	claims, ok := user.Claims.(jwt.MapClaims)
-	if !ok {
-		h.logger.Warnf("jwt.MapClaims: %+v", c.Get("user"))
-		return "", "", "", errors.New("invalid token header")
+// revokeAllSessions deletes all sessions of librarian and drops them from the session cache of this instance
+func (h *librarianHandlersHTTP) revokeAllSessions(ctx context.Context, librarianID uuid.UUID) error {
+	sessionIDs, err := h.sessUC.DeleteAllByUserId(ctx, librarianID)
+	if err != nil {
+		return err
 	}
 
-	sessionID, ok = claims["session_id"].(string)
-	if !ok {
-		h.logger.Warnf("session_id: %+v", claims)
-		return "", "", "", errors.New("invalid token header")
+	for _, sessionID := range sessionIDs {
+		h.mw.ForgetSession(sessionID)
 	}
-
-	librarianID, _ = claims["librarian_id"].(string)
-	role, _ = claims["role"].(string)
-
-	return sessionID, librarianID, role, nil
+	return nil
 }
 
 func (h *librarianHandlersHTTP) registerReqToLibrarianModel(r *dto.LibrarianRegisterRequestDto) (*models.Librarian, error) {
@@ -7,7 +7,6 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
-	"time"
 
 	"github.com/go-playground/validator"
 	"github.com/golang-jwt/jwt"
@@ -24,7 +23,10 @@ import (
 	"github.com/dinorain/pinjembuku/internal/models"
 	mockSessUC "github.com/dinorain/pinjembuku/internal/session/mock"
 	"github.com/dinorain/pinjembuku/pkg/converter"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/jwtkeys"
 	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
 )
 
 func TestLibrariansHandler_Register(t *testing.T) {
@@ -37,12 +39,15 @@ func TestLibrariansHandler_Register(t *testing.T) {
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
 	appLogger := logger.NewAppLogger(nil)
-	mw := middlewares.NewMiddlewareManager(appLogger, nil)
+	mw := middlewares.NewMiddlewareManager(appLogger, nil, nil, nil)
 
 	e := echo.New()
 	v := validator.New()
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
-	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, librarianUC, sessUC)
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, tokenService, librarianUC, sessUC)
 
 	reqDto := &dto.LibrarianRegisterRequestDto{
 		Email:     "email@gmail.com",
@@ -71,6 +76,61 @@ func TestLibrariansHandler_Register(t *testing.T) {
 	require.Equal(t, buf.String(), res.Body.String())
 }
 
+func TestLibrariansHandler_PasswordReset(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	librarianUC := mock.NewMockLibrarianUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	e := echo.New()
+	v := validator.New()
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, nil, nil, nil)
+	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, tokenService, librarianUC, sessUC)
+
+	post := func(h echo.HandlerFunc, path string, reqDto interface{}) *httptest.ResponseRecorder {
+		buf := &bytes.Buffer{}
+		_ = json.NewEncoder(buf).Encode(reqDto)
+
+		req := httptest.NewRequest(http.MethodPost, path, buf)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		res := httptest.NewRecorder()
+		require.NoError(t, h(e.NewContext(req, res)))
+		return res
+	}
+
+	t.Run("Forgot", func(t *testing.T) {
+		librarianUC.EXPECT().ForgotPassword(gomock.Any(), "email@gmail.com").Return(nil)
+
+		res := post(handlers.ForgotPassword(), "/librarian/password/forgot", &dto.LibrarianForgotPasswordRequestDto{Email: "Email@gmail.com"})
+		require.Equal(t, http.StatusAccepted, res.Code)
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		librarianUUID := uuid.New()
+		librarianUC.EXPECT().ResetPassword(gomock.Any(), "token", "new-password").Return(&models.Librarian{LibrarianID: librarianUUID}, nil)
+		sessUC.EXPECT().DeleteAllByUserId(gomock.Any(), librarianUUID).Return([]string{"s1"}, nil)
+
+		res := post(handlers.ResetPassword(), "/librarian/password/reset", &dto.LibrarianResetPasswordRequestDto{Token: "token", Password: "new-password"})
+		require.Equal(t, http.StatusOK, res.Code)
+	})
+
+	t.Run("Reset with used token", func(t *testing.T) {
+		librarianUC.EXPECT().ResetPassword(gomock.Any(), "token", "new-password").Return(nil, grpc_errors.ErrInvalidToken)
+
+		res := post(handlers.ResetPassword(), "/librarian/password/reset", &dto.LibrarianResetPasswordRequestDto{Token: "token", Password: "new-password"})
+		require.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+}
+
 func TestLibrariansHandler_Login(t *testing.T) {
 	t.Parallel()
 
@@ -81,12 +141,15 @@ func TestLibrariansHandler_Login(t *testing.T) {
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
 	appLogger := logger.NewAppLogger(nil)
-	mw := middlewares.NewMiddlewareManager(appLogger, nil)
+	mw := middlewares.NewMiddlewareManager(appLogger, nil, nil, nil)
 
 	e := echo.New()
 	v := validator.New()
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
-	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, librarianUC, sessUC)
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, tokenService, librarianUC, sessUC)
 
 	reqDto := &dto.LibrarianLoginRequestDto{
 		Email:    "email@gmail.com",
@@ -97,6 +160,7 @@ func TestLibrariansHandler_Login(t *testing.T) {
 	_ = json.NewEncoder(&buf).Encode(reqDto)
 
 	req := httptest.NewRequest(http.MethodPost, "/librarian/login", &buf)
+	req.Header.Set("User-Agent", "pinjembuku-test")
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	res := httptest.NewRecorder()
 	ctx := e.NewContext(req, res)
@@ -109,9 +173,10 @@ func TestLibrariansHandler_Login(t *testing.T) {
 		Password:    "123456",
 	}
 
-	librarianUC.EXPECT().Login(gomock.Any(), reqDto.Email, reqDto.Password).AnyTimes().Return(mockLibrarian, nil)
-	sessUC.EXPECT().CreateSession(gomock.Any(), &models.Session{UserID: mockLibrarian.LibrarianID}, cfg.Session.Expire).AnyTimes().Return("s", nil)
-	librarianUC.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).AnyTimes().Return("rt", "at", nil)
+	librarianUC.EXPECT().Login(gomock.Any(), reqDto.Email, reqDto.Password, gomock.Any()).AnyTimes().Return(mockLibrarian, nil)
+	sessUC.EXPECT().CreateSession(gomock.Any(), &models.Session{UserID: mockLibrarian.LibrarianID, IP: "192.0.2.1", UserAgent: "pinjembuku-test"}, cfg.Jwt.RefreshTokenExpire).AnyTimes().Return("s", nil)
+	sessUC.EXPECT().IssueRefreshToken(gomock.Any(), "s", cfg.Jwt.RefreshTokenExpire).Return("rt-id", nil)
+	librarianUC.EXPECT().GenerateTokenPair(gomock.Any(), "s", "rt-id").Return("rt", "at", nil)
 	require.NoError(t, handlers.Login()(ctx))
 	require.Equal(t, http.StatusCreated, res.Code)
 }
@@ -126,12 +191,15 @@ func TestLibrariansHandler_FindAll(t *testing.T) {
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
 	appLogger := logger.NewAppLogger(nil)
-	mw := middlewares.NewMiddlewareManager(appLogger, nil)
+	mw := middlewares.NewMiddlewareManager(appLogger, nil, nil, nil)
 
 	e := echo.New()
 	v := validator.New()
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
-	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, librarianUC, sessUC)
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, tokenService, librarianUC, sessUC)
 
 	req := httptest.NewRequest(http.MethodGet, "/librarian", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -148,8 +216,15 @@ func TestLibrariansHandler_FindAll(t *testing.T) {
 	})
 
 	librarianUC.EXPECT().FindAll(gomock.Any(), gomock.Any()).AnyTimes().Return(librarians, nil)
+	librarianUC.EXPECT().CountAll(gomock.Any()).AnyTimes().Return(11, nil)
 	require.NoError(t, handlers.FindAll()(ctx))
 	require.Equal(t, http.StatusOK, res.Code)
+
+	findResponse := &dto.LibrarianFindResponseDto{}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), findResponse))
+	require.Equal(t, 11, findResponse.Meta.TotalCount)
+	require.Equal(t, 2, findResponse.Meta.TotalPages)
+	require.True(t, findResponse.Meta.HasMore)
 }
 
 func TestLibrariansHandler_FindById(t *testing.T) {
@@ -161,13 +236,16 @@ func TestLibrariansHandler_FindById(t *testing.T) {
 	librarianUC := mock.NewMockLibrarianUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
 	appLogger := logger.NewAppLogger(cfg)
-	mw := middlewares.NewMiddlewareManager(appLogger, nil)
+	mw := middlewares.NewMiddlewareManager(appLogger, nil, nil, nil)
 
 	e := echo.New()
 	v := validator.New()
-	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, librarianUC, sessUC)
+	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, tokenService, librarianUC, sessUC)
 
 	req := httptest.NewRequest(http.MethodGet, "/librarian/:id", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -191,14 +269,19 @@ func TestLibrariansHandler_UpdateById(t *testing.T) {
 	librarianUC := mock.NewMockLibrarianUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
 	appLogger := logger.NewAppLogger(cfg)
-	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+	appLogger.InitLogger()
+	sessRepo := mockSessUC.NewMockSessRepository(ctrl)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg, tokenService, sessRepo)
 
 	e := echo.New()
 	e.Use(middleware.JWT([]byte("secret")))
 	v := validator.New()
-	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, librarianUC, sessUC)
+	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, tokenService, librarianUC, sessUC)
 
 	change := "changed"
 	reqDto := &dto.LibrarianUpdateRequestDto{
@@ -212,12 +295,13 @@ func TestLibrariansHandler_UpdateById(t *testing.T) {
 	_ = json.NewEncoder(buf).Encode(reqDto)
 
 	librarianUUID := uuid.New()
-	token := jwt.New(jwt.SigningMethodHS256)
-	claims := token.Claims.(jwt.MapClaims)
+	claims := jwt.MapClaims{}
 	claims["session_id"] = uuid.New().String()
 	claims["librarian_id"] = librarianUUID.String()
-	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
-	validToken, _ := token.SignedString([]byte("secret"))
+	validToken, _, err := tokenService.GenerateTokenPair(librarianUUID.String(), claims["session_id"].(string), uuid.New().String(), claims)
+	require.NoError(t, err)
+
+	sessRepo.EXPECT().GetSessionById(gomock.Any(), claims["session_id"].(string)).AnyTimes().Return(&models.Session{UserID: librarianUUID}, nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/librarian/:id", buf)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -230,10 +314,7 @@ func TestLibrariansHandler_UpdateById(t *testing.T) {
 		ctx := e.NewContext(req, res)
 
 		handler := handlers.UpdateById()
-		h := middleware.JWTWithConfig(middleware.JWTConfig{
-			Claims:     claims,
-			SigningKey: []byte("secret"),
-		})(handler)
+		h := mw.IsLoggedIn()(handler)
 
 		ctx.SetParamNames("id")
 		ctx.SetParamValues("2ceba62a-35f4-444b-a358-4b14834837e1")
@@ -251,16 +332,14 @@ func TestLibrariansHandler_UpdateById(t *testing.T) {
 		ctx := e.NewContext(req, res)
 
 		handler := handlers.UpdateById()
-		h := middleware.JWTWithConfig(middleware.JWTConfig{
-			Claims:     claims,
-			SigningKey: []byte("secret"),
-		})(handler)
+		h := mw.IsLoggedIn()(handler)
 
 		ctx.SetParamNames("id")
 		ctx.SetParamValues(librarianUUID.String())
 
 		librarianUC.EXPECT().UpdateById(gomock.Any(), gomock.Any()).AnyTimes().Return(&models.Librarian{LibrarianID: librarianUUID}, nil)
 		librarianUC.EXPECT().FindById(gomock.Any(), librarianUUID).AnyTimes().Return(&models.Librarian{LibrarianID: librarianUUID}, nil)
+		sessUC.EXPECT().DeleteAllByUserId(gomock.Any(), librarianUUID).Return([]string{claims["session_id"].(string)}, nil)
 
 		require.NoError(t, h(ctx))
 		require.Equal(t, http.StatusOK, res.Code)
@@ -276,13 +355,16 @@ func TestLibrariansHandler_DeleteById(t *testing.T) {
 	librarianUC := mock.NewMockLibrarianUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
 	appLogger := logger.NewAppLogger(cfg)
-	mw := middlewares.NewMiddlewareManager(appLogger, nil)
+	mw := middlewares.NewMiddlewareManager(appLogger, nil, nil, nil)
 
 	e := echo.New()
 	v := validator.New()
-	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, librarianUC, sessUC)
+	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, tokenService, librarianUC, sessUC)
 
 	req := httptest.NewRequest(http.MethodDelete, "/librarian/:id", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -294,6 +376,7 @@ func TestLibrariansHandler_DeleteById(t *testing.T) {
 	ctx.SetParamValues(librarianUUID.String())
 
 	librarianUC.EXPECT().DeleteById(gomock.Any(), librarianUUID).AnyTimes().Return(nil)
+	sessUC.EXPECT().DeleteAllByUserId(gomock.Any(), librarianUUID).Return([]string{uuid.New().String()}, nil)
 	require.NoError(t, handlers.DeleteById()(ctx))
 	require.Equal(t, http.StatusOK, res.Code)
 }
@@ -307,23 +390,29 @@ func TestLibrariansHandler_GetMe(t *testing.T) {
 	librarianUC := mock.NewMockLibrarianUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
 	appLogger := logger.NewAppLogger(cfg)
-	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+	appLogger.InitLogger()
+	sessRepo := mockSessUC.NewMockSessRepository(ctrl)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg, tokenService, sessRepo)
 
 	e := echo.New()
 	e.Use(middleware.JWT([]byte("secret")))
 	v := validator.New()
-	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, librarianUC, sessUC)
+	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, tokenService, librarianUC, sessUC)
 
 	librarianUUID := uuid.New()
-	token := jwt.New(jwt.SigningMethodHS256)
-	claims := token.Claims.(jwt.MapClaims)
+	claims := jwt.MapClaims{}
 	claims["session_id"] = uuid.New().String()
 	claims["librarian_id"] = librarianUUID.String()
 	claims["role"] = "librarian"
-	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
-	validToken, _ := token.SignedString([]byte("secret"))
+	validToken, _, err := tokenService.GenerateTokenPair(librarianUUID.String(), claims["session_id"].(string), uuid.New().String(), claims)
+	require.NoError(t, err)
+
+	sessRepo.EXPECT().GetSessionById(gomock.Any(), claims["session_id"].(string)).AnyTimes().Return(&models.Session{UserID: librarianUUID}, nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/librarian/logout", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -333,12 +422,8 @@ func TestLibrariansHandler_GetMe(t *testing.T) {
 	ctx := e.NewContext(req, res)
 
 	handler := handlers.GetMe()
-	h := middleware.JWTWithConfig(middleware.JWTConfig{
-		Claims:     claims,
-		SigningKey: []byte("secret"),
-	})(handler)
+	h := mw.IsLoggedIn()(handler)
 
-	sessUC.EXPECT().GetSessionById(gomock.Any(), claims["session_id"].(string)).AnyTimes().Return(&models.Session{}, nil)
 	librarianUC.EXPECT().CachedFindById(gomock.Any(), gomock.Any()).AnyTimes().Return(&models.Librarian{}, nil)
 
 	require.NoError(t, h(ctx))
@@ -354,22 +439,28 @@ func TestLibrariansHandler_Logout(t *testing.T) {
 	librarianUC := mock.NewMockLibrarianUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
 	appLogger := logger.NewAppLogger(cfg)
-	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+	appLogger.InitLogger()
+	sessRepo := mockSessUC.NewMockSessRepository(ctrl)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg, tokenService, sessRepo)
 
 	e := echo.New()
 	e.Use(middleware.JWT([]byte("secret")))
 	v := validator.New()
-	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, librarianUC, sessUC)
+	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, tokenService, librarianUC, sessUC)
 
 	librarianUUID := uuid.New()
-	token := jwt.New(jwt.SigningMethodHS256)
-	claims := token.Claims.(jwt.MapClaims)
+	claims := jwt.MapClaims{}
 	claims["session_id"] = uuid.New().String()
 	claims["librarian_id"] = librarianUUID.String()
-	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
-	validToken, _ := token.SignedString([]byte("secret"))
+	validToken, _, err := tokenService.GenerateTokenPair(librarianUUID.String(), claims["session_id"].(string), uuid.New().String(), claims)
+	require.NoError(t, err)
+
+	sessRepo.EXPECT().GetSessionById(gomock.Any(), claims["session_id"].(string)).AnyTimes().Return(&models.Session{UserID: librarianUUID}, nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/librarian/logout", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -379,10 +470,7 @@ func TestLibrariansHandler_Logout(t *testing.T) {
 	ctx := e.NewContext(req, res)
 
 	handler := handlers.Logout()
-	h := middleware.JWTWithConfig(middleware.JWTConfig{
-		Claims:     claims,
-		SigningKey: []byte("secret"),
-	})(handler)
+	h := mw.IsLoggedIn()(handler)
 
 	sessUC.EXPECT().DeleteById(gomock.Any(), claims["session_id"].(string)).AnyTimes().Return(nil)
 
@@ -399,37 +487,66 @@ func TestLibrariansHandler_RefreshToken(t *testing.T) {
 	librarianUC := mock.NewMockLibrarianUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
-	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
 	appLogger := logger.NewAppLogger(cfg)
-	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg, tokenService, nil)
 
 	e := echo.New()
 	v := validator.New()
-	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, librarianUC, sessUC)
+	handlers := NewLibrarianHandlersHTTP(e.Group("librarian"), appLogger, cfg, mw, v, tokenService, librarianUC, sessUC)
 
-	token := jwt.New(jwt.SigningMethodHS256)
-	claims := token.Claims.(jwt.MapClaims)
-	claims["session_id"] = uuid.New().String()
-	claims["exp"] = time.Now().Add(time.Hour * 24).Unix()
-	validToken, _ := token.SignedString([]byte("secret"))
+	librarianUUID := uuid.New()
+	sessID := uuid.New().String()
+	refreshTokenID := uuid.New().String()
+	accessToken, validToken, err := tokenService.GenerateTokenPair(librarianUUID.String(), sessID, refreshTokenID, nil)
+	require.NoError(t, err)
 
 	reqDto := &dto.LibrarianRefreshTokenDto{
 		RefreshToken: validToken,
 	}
 
-	buf := &bytes.Buffer{}
-	_ = json.NewEncoder(buf).Encode(reqDto)
+	newContext := func() (echo.Context, *httptest.ResponseRecorder) {
+		buf := &bytes.Buffer{}
+		_ = json.NewEncoder(buf).Encode(reqDto)
 
-	req := httptest.NewRequest(http.MethodPost, "/librarian/refresh", buf)
-	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req := httptest.NewRequest(http.MethodPost, "/librarian/refresh", buf)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		res := httptest.NewRecorder()
+		return e.NewContext(req, res), res
+	}
 
-	res := httptest.NewRecorder()
-	ctx := e.NewContext(req, res)
+	sessUC.EXPECT().GetSessionById(gomock.Any(), sessID).AnyTimes().Return(&models.Session{UserID: librarianUUID}, nil)
+	librarianUC.EXPECT().FindById(gomock.Any(), librarianUUID).AnyTimes().Return(&models.Librarian{}, nil)
 
-	sessUC.EXPECT().GetSessionById(gomock.Any(), claims["session_id"].(string)).AnyTimes().Return(&models.Session{}, nil)
-	librarianUC.EXPECT().FindById(gomock.Any(), gomock.Any()).AnyTimes().Return(&models.Librarian{}, nil)
-	librarianUC.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).AnyTimes().Return("rt", "at", nil)
+	t.Run("Rotate", func(t *testing.T) {
+		ctx, res := newContext()
 
-	require.NoError(t, handlers.RefreshToken()(ctx))
-	require.Equal(t, http.StatusOK, res.Code)
+		sessUC.EXPECT().RotateRefreshToken(gomock.Any(), sessID, refreshTokenID).Return("next", nil)
+		librarianUC.EXPECT().GenerateTokenPair(gomock.Any(), sessID, "next").Return("rt", "at", nil)
+
+		require.NoError(t, handlers.RefreshToken()(ctx))
+		require.Equal(t, http.StatusOK, res.Code)
+	})
+
+	t.Run("Reused", func(t *testing.T) {
+		ctx, res := newContext()
+
+		sessUC.EXPECT().RotateRefreshToken(gomock.Any(), sessID, refreshTokenID).Return("", grpc_errors.ErrRefreshTokenReused)
+
+		require.NoError(t, handlers.RefreshToken()(ctx))
+		require.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("Access token", func(t *testing.T) {
+		reqDto.RefreshToken = accessToken
+		defer func() { reqDto.RefreshToken = validToken }()
+		ctx, res := newContext()
+
+		require.NoError(t, handlers.RefreshToken()(ctx))
+		require.Equal(t, http.StatusUnauthorized, res.Code)
+	})
 }
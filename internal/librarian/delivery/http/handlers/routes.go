@@ -3,6 +3,8 @@ package handlers
 func (h *librarianHandlersHTTP) LibrarianMapRoutes() {
 	h.group.POST("/refresh", h.RefreshToken())
 	h.group.POST("/login", h.Login())
+	h.group.POST("/password/forgot", h.ForgotPassword())
+	h.group.POST("/password/reset", h.ResetPassword())
 
 	h.group.Use(h.mw.IsLoggedIn())
 	h.group.PUT("/:id", h.UpdateById(), h.mw.IsLibrarian)
@@ -10,8 +12,12 @@ func (h *librarianHandlersHTTP) LibrarianMapRoutes() {
 	h.group.GET("/:id", h.FindById())
 	h.group.GET("/me", h.GetMe(), h.mw.IsLibrarian)
 	h.group.POST("/logout", h.Logout(), h.mw.IsLibrarian)
+	h.group.GET("/me/sessions", h.GetMySessions(), h.mw.IsLibrarian)
+	h.group.DELETE("/me/sessions/:id", h.DeleteMySessionById(), h.mw.IsLibrarian)
 
 	h.group.POST("", h.Register(), h.mw.IsAdmin)
 	h.group.GET("", h.FindAll(), h.mw.IsAdmin)
 	h.group.DELETE("/:id", h.DeleteById(), h.mw.IsAdmin)
+	h.group.DELETE("/:id/sessions", h.DeleteAllSessionsById(), h.mw.IsAdmin)
+	h.group.DELETE("/:id/lockout", h.UnlockById(), h.mw.IsAdmin)
 }
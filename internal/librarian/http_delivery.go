@@ -6,11 +6,17 @@ import "github.com/labstack/echo/v4"
 type LibrarianHandlers interface {
 	Register() echo.HandlerFunc
 	Login() echo.HandlerFunc
+	ForgotPassword() echo.HandlerFunc
+	ResetPassword() echo.HandlerFunc
 	GetMe() echo.HandlerFunc
 	FindAll() echo.HandlerFunc
 	FindById() echo.HandlerFunc
 	UpdateById() echo.HandlerFunc
 	DeleteById() echo.HandlerFunc
 	Logout() echo.HandlerFunc
+	GetMySessions() echo.HandlerFunc
+	DeleteMySessionById() echo.HandlerFunc
+	DeleteAllSessionsById() echo.HandlerFunc
+	UnlockById() echo.HandlerFunc
 	RefreshToken() echo.HandlerFunc
 }
@@ -37,6 +37,21 @@ func (m *MockLibrarianPGRepository) EXPECT() *MockLibrarianPGRepositoryMockRecor
 	return m.recorder
 }
 
+// CountAll mocks base method.
+func (m *MockLibrarianPGRepository) CountAll(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockLibrarianPGRepositoryMockRecorder) CountAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockLibrarianPGRepository)(nil).CountAll), ctx)
+}
+
 // Create mocks base method.
 func (m *MockLibrarianPGRepository) Create(ctx context.Context, user *models.Librarian) (*models.Librarian, error) {
 	m.ctrl.T.Helper()
@@ -10,6 +10,7 @@ import (
 
 	models "github.com/dinorain/pinjembuku/internal/models"
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockLibrarianRedisRepository is a mock of LibrarianRedisRepository interface.
@@ -49,6 +50,20 @@ func (mr *MockLibrarianRedisRepositoryMockRecorder) DeleteLibrarianCtx(ctx, key
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLibrarianCtx", reflect.TypeOf((*MockLibrarianRedisRepository)(nil).DeleteLibrarianCtx), ctx, key)
 }
 
+// DeletePasswordResetTokensCtx mocks base method.
+func (m *MockLibrarianRedisRepository) DeletePasswordResetTokensCtx(ctx context.Context, librarianID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePasswordResetTokensCtx", ctx, librarianID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePasswordResetTokensCtx indicates an expected call of DeletePasswordResetTokensCtx.
+func (mr *MockLibrarianRedisRepositoryMockRecorder) DeletePasswordResetTokensCtx(ctx, librarianID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePasswordResetTokensCtx", reflect.TypeOf((*MockLibrarianRedisRepository)(nil).DeletePasswordResetTokensCtx), ctx, librarianID)
+}
+
 // GetByIdCtx mocks base method.
 func (m *MockLibrarianRedisRepository) GetByIdCtx(ctx context.Context, key string) (*models.Librarian, error) {
 	m.ctrl.T.Helper()
@@ -77,3 +92,32 @@ func (mr *MockLibrarianRedisRepositoryMockRecorder) SetLibrarianCtx(ctx, key, se
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLibrarianCtx", reflect.TypeOf((*MockLibrarianRedisRepository)(nil).SetLibrarianCtx), ctx, key, seconds, user)
 }
+
+// SetPasswordResetTokenCtx mocks base method.
+func (m *MockLibrarianRedisRepository) SetPasswordResetTokenCtx(ctx context.Context, token string, seconds int, librarianID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPasswordResetTokenCtx", ctx, token, seconds, librarianID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPasswordResetTokenCtx indicates an expected call of SetPasswordResetTokenCtx.
+func (mr *MockLibrarianRedisRepositoryMockRecorder) SetPasswordResetTokenCtx(ctx, token, seconds, librarianID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPasswordResetTokenCtx", reflect.TypeOf((*MockLibrarianRedisRepository)(nil).SetPasswordResetTokenCtx), ctx, token, seconds, librarianID)
+}
+
+// TakePasswordResetTokenCtx mocks base method.
+func (m *MockLibrarianRedisRepository) TakePasswordResetTokenCtx(ctx context.Context, token string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakePasswordResetTokenCtx", ctx, token)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakePasswordResetTokenCtx indicates an expected call of TakePasswordResetTokenCtx.
+func (mr *MockLibrarianRedisRepositoryMockRecorder) TakePasswordResetTokenCtx(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakePasswordResetTokenCtx", reflect.TypeOf((*MockLibrarianRedisRepository)(nil).TakePasswordResetTokenCtx), ctx, token)
+}
@@ -52,6 +52,21 @@ func (mr *MockLibrarianUseCaseMockRecorder) CachedFindById(ctx, librarianID inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CachedFindById", reflect.TypeOf((*MockLibrarianUseCase)(nil).CachedFindById), ctx, librarianID)
 }
 
+// CountAll mocks base method.
+func (m *MockLibrarianUseCase) CountAll(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockLibrarianUseCaseMockRecorder) CountAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockLibrarianUseCase)(nil).CountAll), ctx)
+}
+
 // DeleteById mocks base method.
 func (m *MockLibrarianUseCase) DeleteById(ctx context.Context, librarianID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -111,10 +126,24 @@ func (mr *MockLibrarianUseCaseMockRecorder) FindById(ctx, librarianID interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockLibrarianUseCase)(nil).FindById), ctx, librarianID)
 }
 
+// ForgotPassword mocks base method.
+func (m *MockLibrarianUseCase) ForgotPassword(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockLibrarianUseCaseMockRecorder) ForgotPassword(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockLibrarianUseCase)(nil).ForgotPassword), ctx, email)
+}
+
 // GenerateTokenPair mocks base method.
-func (m *MockLibrarianUseCase) GenerateTokenPair(librarian *models.Librarian, sessionID string) (string, string, error) {
+func (m *MockLibrarianUseCase) GenerateTokenPair(librarian *models.Librarian, sessionID, refreshTokenID string) (string, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateTokenPair", librarian, sessionID)
+	ret := m.ctrl.Call(m, "GenerateTokenPair", librarian, sessionID, refreshTokenID)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(error)
@@ -122,24 +151,24 @@ func (m *MockLibrarianUseCase) GenerateTokenPair(librarian *models.Librarian, se
 }
 
 // GenerateTokenPair indicates an expected call of GenerateTokenPair.
-func (mr *MockLibrarianUseCaseMockRecorder) GenerateTokenPair(librarian, sessionID interface{}) *gomock.Call {
+func (mr *MockLibrarianUseCaseMockRecorder) GenerateTokenPair(librarian, sessionID, refreshTokenID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTokenPair", reflect.TypeOf((*MockLibrarianUseCase)(nil).GenerateTokenPair), librarian, sessionID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTokenPair", reflect.TypeOf((*MockLibrarianUseCase)(nil).GenerateTokenPair), librarian, sessionID, refreshTokenID)
 }
 
 // Login mocks base method.
-func (m *MockLibrarianUseCase) Login(ctx context.Context, email, password string) (*models.Librarian, error) {
+func (m *MockLibrarianUseCase) Login(ctx context.Context, email, password, ip string) (*models.Librarian, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", ctx, email, password)
+	ret := m.ctrl.Call(m, "Login", ctx, email, password, ip)
 	ret0, _ := ret[0].(*models.Librarian)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockLibrarianUseCaseMockRecorder) Login(ctx, email, password interface{}) *gomock.Call {
+func (mr *MockLibrarianUseCaseMockRecorder) Login(ctx, email, password, ip interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockLibrarianUseCase)(nil).Login), ctx, email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockLibrarianUseCase)(nil).Login), ctx, email, password, ip)
 }
 
 // Register mocks base method.
@@ -157,6 +186,35 @@ func (mr *MockLibrarianUseCaseMockRecorder) Register(ctx, librarian interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockLibrarianUseCase)(nil).Register), ctx, librarian)
 }
 
+// ResetPassword mocks base method.
+func (m *MockLibrarianUseCase) ResetPassword(ctx context.Context, token, password string) (*models.Librarian, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, password)
+	ret0, _ := ret[0].(*models.Librarian)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockLibrarianUseCaseMockRecorder) ResetPassword(ctx, token, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockLibrarianUseCase)(nil).ResetPassword), ctx, token, password)
+}
+
+// UnlockById mocks base method.
+func (m *MockLibrarianUseCase) UnlockById(ctx context.Context, librarianID uuid.UUID, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockById", ctx, librarianID, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockById indicates an expected call of UnlockById.
+func (mr *MockLibrarianUseCaseMockRecorder) UnlockById(ctx, librarianID, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockById", reflect.TypeOf((*MockLibrarianUseCase)(nil).UnlockById), ctx, librarianID, ip)
+}
+
 // UpdateById mocks base method.
 func (m *MockLibrarianUseCase) UpdateById(ctx context.Context, librarian *models.Librarian) (*models.Librarian, error) {
 	m.ctrl.T.Helper()
@@ -14,6 +14,7 @@ import (
 type LibrarianPGRepository interface {
 	Create(ctx context.Context, user *models.Librarian) (*models.Librarian, error)
 	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Librarian, error)
+	CountAll(ctx context.Context) (int, error)
 	FindByEmail(ctx context.Context, email string) (*models.Librarian, error)
 	FindById(ctx context.Context, userID uuid.UUID) (*models.Librarian, error)
 	UpdateById(ctx context.Context, user *models.Librarian) (*models.Librarian, error)
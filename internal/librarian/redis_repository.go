@@ -4,6 +4,8 @@ package librarian
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"github.com/dinorain/pinjembuku/internal/models"
 )
 
@@ -12,4 +14,7 @@ type LibrarianRedisRepository interface {
 	GetByIdCtx(ctx context.Context, key string) (*models.Librarian, error)
 	SetLibrarianCtx(ctx context.Context, key string, seconds int, user *models.Librarian) error
 	DeleteLibrarianCtx(ctx context.Context, key string) error
+	SetPasswordResetTokenCtx(ctx context.Context, token string, seconds int, librarianID uuid.UUID) error
+	TakePasswordResetTokenCtx(ctx context.Context, token string) (uuid.UUID, error)
+	DeletePasswordResetTokensCtx(ctx context.Context, librarianID uuid.UUID) error
 }
@@ -66,16 +66,33 @@ func (r *LibrarianRepository) UpdateById(ctx context.Context, librarian *models.
 	return librarian, nil
 }
 
-// FindAll Find librarians
+// FindAll Find librarians, newest first. Pages by keyset when pagination carries a cursor
 func (r *LibrarianRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Librarian, error) {
 	var librarians []models.Librarian
+	if cursor := pagination.GetCursor(); cursor != nil {
+		if err := r.db.SelectContext(ctx, &librarians, findAllByCursorQuery, cursor.CreatedAt, cursor.ID, pagination.GetLimit()); err != nil {
+			return nil, errors.Wrap(err, "LibrarianRepository.FindAll.SelectContext")
+		}
+		return librarians, nil
+	}
+
 	if err := r.db.SelectContext(ctx, &librarians, findAllQuery, pagination.GetLimit(), pagination.GetOffset()); err != nil {
-		return nil, errors.Wrap(err, "LibrarianRepository.FindById.SelectContext")
+		return nil, errors.Wrap(err, "LibrarianRepository.FindAll.SelectContext")
 	}
 
 	return librarians, nil
 }
 
+// CountAll Count librarians
+func (r *LibrarianRepository) CountAll(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, countAllQuery); err != nil {
+		return 0, errors.Wrap(err, "LibrarianRepository.CountAll.GetContext")
+	}
+
+	return count, nil
+}
+
 // FindByEmail Find by librarian email address
 func (r *LibrarianRepository) FindByEmail(ctx context.Context, email string) (*models.Librarian, error) {
 	librarian := &models.Librarian{}
@@ -148,6 +148,14 @@ func TestLibrarianRepository_FindAll(t *testing.T) {
 	foundLibrarians, err = librarianPGRepository.FindAll(context.Background(), utils.NewPaginationQuery(size, 2))
 	require.NoError(t, err)
 	require.Nil(t, foundLibrarians)
+
+	cursor := utils.NewCursor(time.Now(), uuid.New())
+	pagination := utils.NewPaginationQuery(size, 2)
+	require.NoError(t, pagination.SetCursor(cursor.Encode()))
+	mock.ExpectQuery(findAllByCursorQuery).WithArgs(sqlmock.AnyArg(), cursor.ID, size).WillReturnRows(sqlmock.NewRows(columns))
+	foundLibrarians, err = librarianPGRepository.FindAll(context.Background(), pagination)
+	require.NoError(t, err)
+	require.Nil(t, foundLibrarians)
 }
 
 func TestLibrarianRepository_FindById(t *testing.T) {
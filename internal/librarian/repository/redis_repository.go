@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
 
 	"github.com/dinorain/pinjembuku/internal/models"
 	"github.com/dinorain/pinjembuku/internal/librarian"
@@ -15,16 +18,18 @@ import (
 
 // Librarian redis repository
 type librarianRedisRepo struct {
-	redisClient *redis.Client
-	basePrefix  string
-	logger      logger.Logger
+	redisClient         *redis.Client
+	basePrefix          string
+	passwordResetPrefix string
+	passwordResetIndex  string
+	logger              logger.Logger
 }
 
 var _ librarian.LibrarianRedisRepository = (*librarianRedisRepo)(nil)
 
 // Librarian redis repository constructor
 func NewLibrarianRedisRepo(redisClient *redis.Client, logger logger.Logger) *librarianRedisRepo {
-	return &librarianRedisRepo{redisClient: redisClient, basePrefix: "librarian:", logger: logger}
+	return &librarianRedisRepo{redisClient: redisClient, basePrefix: "librarian:", passwordResetPrefix: "librarian-password-reset:", passwordResetIndex: "librarian-password-resets-by-librarian:", logger: logger}
 }
 
 // Get librarian by id
@@ -56,6 +61,66 @@ func (r *librarianRedisRepo) DeleteLibrarianCtx(ctx context.Context, key string)
 	return r.redisClient.Del(ctx, r.createKey(key)).Err()
 }
 
+// Store password reset token of librarian with duration in seconds, only the token hash is kept
+func (r *librarianRedisRepo) SetPasswordResetTokenCtx(ctx context.Context, token string, seconds int, librarianID uuid.UUID) error {
+	return r.setTokenCtx(ctx, r.createTokenKey(r.passwordResetPrefix, token), r.createIndexKey(r.passwordResetIndex, librarianID), seconds, librarianID)
+}
+
+// Take librarian of password reset token, the token is deleted so it can be used once
+func (r *librarianRedisRepo) TakePasswordResetTokenCtx(ctx context.Context, token string) (uuid.UUID, error) {
+	return r.takeTokenCtx(ctx, r.createTokenKey(r.passwordResetPrefix, token))
+}
+
+// Delete all password reset tokens of librarian
+func (r *librarianRedisRepo) DeletePasswordResetTokensCtx(ctx context.Context, librarianID uuid.UUID) error {
+	return r.deleteTokensCtx(ctx, r.createIndexKey(r.passwordResetIndex, librarianID))
+}
+
+func (r *librarianRedisRepo) setTokenCtx(ctx context.Context, key string, indexKey string, seconds int, librarianID uuid.UUID) error {
+	// the index lives as long as the newest token, taken tokens are dropped with the index
+	ttl := time.Second * time.Duration(seconds)
+	_, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, key, librarianID.String(), ttl)
+		if indexKey != "" {
+			pipe.SAdd(ctx, indexKey, key)
+			pipe.Expire(ctx, indexKey, ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+func (r *librarianRedisRepo) takeTokenCtx(ctx context.Context, key string) (uuid.UUID, error) {
+	var librarianIDCmd *redis.StringCmd
+	if _, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		librarianIDCmd = pipe.Get(ctx, key)
+		pipe.Del(ctx, key)
+		return nil
+	}); err != nil {
+		return uuid.Nil, err
+	}
+
+	return uuid.Parse(librarianIDCmd.Val())
+}
+
+func (r *librarianRedisRepo) deleteTokensCtx(ctx context.Context, indexKey string) error {
+	keys, err := r.redisClient.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return err
+	}
+
+	return r.redisClient.Del(ctx, append(keys, indexKey)...).Err()
+}
+
+func (r *librarianRedisRepo) createTokenKey(prefix string, token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return fmt.Sprintf("%s: %s", prefix, hex.EncodeToString(hash[:]))
+}
+
+func (r *librarianRedisRepo) createIndexKey(prefix string, librarianID uuid.UUID) string {
+	return fmt.Sprintf("%s: %s", prefix, librarianID.String())
+}
+
 func (r *librarianRedisRepo) createKey(value string) string {
 	return fmt.Sprintf("%s: %s", r.basePrefix, value)
 }
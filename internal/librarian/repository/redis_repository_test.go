@@ -74,3 +74,53 @@ func TestLibrarianRedisRepo_DeleteLibrarianCtx(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestLibrarianRedisRepo_TakePasswordResetTokenCtx(t *testing.T) {
+	t.Parallel()
+
+	redisRepo := SetupRedis()
+
+	t.Run("TakePasswordResetTokenCtx", func(t *testing.T) {
+		librarianID := uuid.New()
+
+		err := redisRepo.SetPasswordResetTokenCtx(context.Background(), "token", 10, librarianID)
+		require.NoError(t, err)
+
+		exists, err := redisRepo.redisClient.Exists(context.Background(), redisRepo.passwordResetPrefix+": token").Result()
+		require.NoError(t, err)
+		require.Zero(t, exists, "token is stored hashed")
+
+		takenID, err := redisRepo.TakePasswordResetTokenCtx(context.Background(), "token")
+		require.NoError(t, err)
+		require.Equal(t, librarianID, takenID)
+
+		_, err = redisRepo.TakePasswordResetTokenCtx(context.Background(), "token")
+		require.ErrorIs(t, err, redis.Nil)
+	})
+}
+
+func TestLibrarianRedisRepo_DeletePasswordResetTokensCtx(t *testing.T) {
+	t.Parallel()
+
+	redisRepo := SetupRedis()
+
+	t.Run("DeletePasswordResetTokensCtx", func(t *testing.T) {
+		librarianID, otherLibrarianID := uuid.New(), uuid.New()
+
+		require.NoError(t, redisRepo.SetPasswordResetTokenCtx(context.Background(), "first", 10, librarianID))
+		require.NoError(t, redisRepo.SetPasswordResetTokenCtx(context.Background(), "second", 10, librarianID))
+		require.NoError(t, redisRepo.SetPasswordResetTokenCtx(context.Background(), "other", 10, otherLibrarianID))
+
+		err := redisRepo.DeletePasswordResetTokensCtx(context.Background(), librarianID)
+		require.NoError(t, err)
+
+		_, err = redisRepo.TakePasswordResetTokenCtx(context.Background(), "first")
+		require.ErrorIs(t, err, redis.Nil)
+		_, err = redisRepo.TakePasswordResetTokenCtx(context.Background(), "second")
+		require.ErrorIs(t, err, redis.Nil)
+
+		takenID, err := redisRepo.TakePasswordResetTokenCtx(context.Background(), "other")
+		require.NoError(t, err)
+		require.Equal(t, otherLibrarianID, takenID)
+	})
+}
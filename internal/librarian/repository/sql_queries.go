@@ -9,10 +9,14 @@ const (
 
 	findByIdQuery = `SELECT librarian_id, email, first_name, last_name, avatar, password, created_at, updated_at FROM librarians WHERE librarian_id = $1`
 
-	findAllQuery = `SELECT librarian_id, email, first_name, last_name, avatar, password, created_at, updated_at FROM librarians LIMIT $1 OFFSET $2`
+	findAllQuery = `SELECT librarian_id, email, first_name, last_name, avatar, password, created_at, updated_at FROM librarians ORDER BY created_at DESC, librarian_id DESC LIMIT $1 OFFSET $2`
+
+	findAllByCursorQuery = `SELECT librarian_id, email, first_name, last_name, avatar, password, created_at, updated_at FROM librarians WHERE (created_at, librarian_id) < ($1, $2) ORDER BY created_at DESC, librarian_id DESC LIMIT $3`
 
 	updateByIdQuery = `UPDATE librarians SET first_name = $2, last_name = $3, email = $4, password = $5, avatar = $6 WHERE librarian_id = $1
 		RETURNING librarian_id, first_name, last_name, email, password, avatar, created_at, updated_at`
 
 	deleteByIdQuery = `DELETE FROM librarians WHERE librarian_id = $1`
+
+	countAllQuery = `SELECT COUNT(*) FROM librarians`
 )
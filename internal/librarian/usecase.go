@@ -13,12 +13,16 @@ import (
 //  Librarian UseCase interface
 type LibrarianUseCase interface {
 	Register(ctx context.Context, librarian *models.Librarian) (*models.Librarian, error)
-	Login(ctx context.Context, email string, password string) (*models.Librarian, error)
+	Login(ctx context.Context, email string, password string, ip string) (*models.Librarian, error)
+	UnlockById(ctx context.Context, librarianID uuid.UUID, ip string) error
 	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Librarian, error)
+	CountAll(ctx context.Context) (int, error)
 	FindByEmail(ctx context.Context, email string) (*models.Librarian, error)
 	FindById(ctx context.Context, librarianID uuid.UUID) (*models.Librarian, error)
 	CachedFindById(ctx context.Context, librarianID uuid.UUID) (*models.Librarian, error)
 	UpdateById(ctx context.Context, librarian *models.Librarian) (*models.Librarian, error)
 	DeleteById(ctx context.Context, librarianID uuid.UUID) error
-	GenerateTokenPair(librarian *models.Librarian, sessionID string) (access string, refresh string, err error)
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, password string) (*models.Librarian, error)
+	GenerateTokenPair(librarian *models.Librarian, sessionID string, refreshTokenID string) (access string, refresh string, err error)
 }
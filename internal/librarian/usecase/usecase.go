@@ -2,7 +2,8 @@ package usecase
 
 import (
 	"context"
-	"time"
+	"database/sql"
+	"fmt"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/golang-jwt/jwt"
@@ -12,19 +13,27 @@ import (
 	"github.com/dinorain/pinjembuku/config"
 	"github.com/dinorain/pinjembuku/internal/models"
 	"github.com/dinorain/pinjembuku/internal/librarian"
+	"github.com/dinorain/pinjembuku/internal/lockout"
 	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
 	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/mailer"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
 	"github.com/dinorain/pinjembuku/pkg/utils"
 )
 
 const (
 	librarianByIdCacheDuration = 3600
+
+	defaultPasswordResetExpire = 60 * 60
 )
 
 // Librarian UseCase
 type librarianUseCase struct {
 	cfg          *config.Config
 	logger       logger.Logger
+	tokens       *tokens.Service
+	mailer       mailer.Mailer
+	lockoutUC    lockout.LockoutUseCase
 	librarianPgRepo librarian.LibrarianPGRepository
 	redisRepo    librarian.LibrarianRedisRepository
 }
@@ -32,8 +41,8 @@ type librarianUseCase struct {
 var _ librarian.LibrarianUseCase = (*librarianUseCase)(nil)
 
 // New Librarian UseCase
-func NewLibrarianUseCase(cfg *config.Config, logger logger.Logger, librarianRepo librarian.LibrarianPGRepository, redisRepo librarian.LibrarianRedisRepository) *librarianUseCase {
-	return &librarianUseCase{cfg: cfg, logger: logger, librarianPgRepo: librarianRepo, redisRepo: redisRepo}
+func NewLibrarianUseCase(cfg *config.Config, logger logger.Logger, tokens *tokens.Service, mailer mailer.Mailer, lockoutUC lockout.LockoutUseCase, librarianRepo librarian.LibrarianPGRepository, redisRepo librarian.LibrarianRedisRepository) *librarianUseCase {
+	return &librarianUseCase{cfg: cfg, logger: logger, tokens: tokens, mailer: mailer, lockoutUC: lockoutUC, librarianPgRepo: librarianRepo, redisRepo: redisRepo}
 }
 
 // Register new librarian
@@ -56,6 +65,16 @@ func (u *librarianUseCase) FindAll(ctx context.Context, pagination *utils.Pagina
 	return librarians, nil
 }
 
+// CountAll count librarians
+func (u *librarianUseCase) CountAll(ctx context.Context) (int, error) {
+	count, err := u.librarianPgRepo.CountAll(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "librarianPgRepo.CountAll")
+	}
+
+	return count, nil
+}
+
 // FindByEmail find librarian by email address
 func (u *librarianUseCase) FindByEmail(ctx context.Context, email string) (*models.Librarian, error) {
 	findByEmail, err := u.librarianPgRepo.FindByEmail(ctx, email)
@@ -130,43 +149,132 @@ func (u *librarianUseCase) DeleteById(ctx context.Context, librarianID uuid.UUID
 	return nil
 }
 
-// Login librarian with email and password
-func (u *librarianUseCase) Login(ctx context.Context, email string, password string) (*models.Librarian, error) {
+// ForgotPassword email a password reset link, unknown emails and failed sends are not reported so callers cannot probe accounts
+func (u *librarianUseCase) ForgotPassword(ctx context.Context, email string) error {
+	foundLibrarian, err := u.librarianPgRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return errors.Wrap(err, "librarianPgRepo.FindByEmail")
+	}
+
+	if err := u.sendPasswordReset(ctx, foundLibrarian); err != nil {
+		u.logger.Errorf("sendPasswordReset: %v", err)
+	}
+
+	return nil
+}
+
+// ResetPassword set password of the librarian the reset token was sent to, all reset tokens of the librarian are spent
+func (u *librarianUseCase) ResetPassword(ctx context.Context, token string, password string) (*models.Librarian, error) {
+	librarianID, err := u.redisRepo.TakePasswordResetTokenCtx(ctx, token)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errors.Wrap(grpc_errors.ErrInvalidToken, "password reset token")
+		}
+		return nil, errors.Wrap(err, "redisRepo.TakePasswordResetTokenCtx")
+	}
+
+	// other reset links sent to the librarian stop working as well
+	if err := u.redisRepo.DeletePasswordResetTokensCtx(ctx, librarianID); err != nil {
+		return nil, errors.Wrap(err, "redisRepo.DeletePasswordResetTokensCtx")
+	}
+
+	foundLibrarian, err := u.librarianPgRepo.FindById(ctx, librarianID)
+	if err != nil {
+		return nil, errors.Wrap(err, "librarianPgRepo.FindById")
+	}
+
+	foundLibrarian.Password = password
+	if err := foundLibrarian.HashPassword(); err != nil {
+		return nil, errors.Wrap(err, "librarian.HashPassword")
+	}
+
+	return u.UpdateById(ctx, foundLibrarian)
+}
+
+// Login librarian with email and password, failed attempts back off and lock the account for a while
+func (u *librarianUseCase) Login(ctx context.Context, email string, password string, ip string) (*models.Librarian, error) {
+	if err := u.lockoutUC.Reserve(ctx, lockout.RealmLibrarian, email, ip); err != nil {
+		return nil, errors.Wrap(err, "lockoutUC.Reserve")
+	}
+
 	foundLibrarian, err := u.librarianPgRepo.FindByEmail(ctx, email)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			u.registerLoginFailure(ctx, ip)
+		}
 		return nil, errors.Wrap(err, "librarianPgRepo.FindByEmail")
 	}
 
 	if err := foundLibrarian.ComparePasswords(password); err != nil {
+		u.registerLoginFailure(ctx, ip)
 		return nil, errors.Wrap(err, "librarian.ComparePasswords")
 	}
 
-	return foundLibrarian, err
+	if err := u.lockoutUC.RegisterSuccess(ctx, lockout.RealmLibrarian, email); err != nil {
+		u.logger.Errorf("lockoutUC.RegisterSuccess: %v", err)
+	}
+
+	return foundLibrarian, nil
 }
 
-func (u *librarianUseCase) GenerateTokenPair(librarian *models.Librarian, sessionID string) (access string, refresh string, err error) {
-	token := jwt.New(jwt.SigningMethodHS256)
+// UnlockById reset failed logins of librarian and, when set, of ip
+func (u *librarianUseCase) UnlockById(ctx context.Context, librarianID uuid.UUID, ip string) error {
+	foundLibrarian, err := u.librarianPgRepo.FindById(ctx, librarianID)
+	if err != nil {
+		return errors.Wrap(err, "librarianPgRepo.FindById")
+	}
+
+	if err := u.lockoutUC.Unlock(ctx, lockout.RealmLibrarian, foundLibrarian.Email, ip); err != nil {
+		return errors.Wrap(err, "lockoutUC.Unlock")
+	}
+
+	return nil
+}
 
-	claims := token.Claims.(jwt.MapClaims)
-	claims["session_id"] = sessionID
-	claims["librarian_id"] = librarian.LibrarianID
-	claims["email"] = librarian.Email
-	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+func (u *librarianUseCase) GenerateTokenPair(librarian *models.Librarian, sessionID string, refreshTokenID string) (access string, refresh string, err error) {
+	return u.tokens.GenerateTokenPair(librarian.LibrarianID.String(), sessionID, refreshTokenID, jwt.MapClaims{
+		"librarian_id": librarian.LibrarianID,
+		"email":        librarian.Email,
+	})
+}
 
-	access, err = token.SignedString([]byte(u.cfg.Server.JwtSecretKey))
+func (u *librarianUseCase) sendPasswordReset(ctx context.Context, librarian *models.Librarian) error {
+	token, err := utils.NewOneTimeToken()
 	if err != nil {
-		return "", "", err
+		return err
 	}
 
-	refreshToken := jwt.New(jwt.SigningMethodHS256)
-	rtClaims := refreshToken.Claims.(jwt.MapClaims)
-	rtClaims["session_id"] = sessionID
-	rtClaims["exp"] = time.Now().Add(time.Hour * 24).Unix()
+	expire := u.cfg.PasswordReset.Expire
+	if expire <= 0 {
+		expire = defaultPasswordResetExpire
+	}
+
+	if err := u.redisRepo.SetPasswordResetTokenCtx(ctx, token, expire, librarian.LibrarianID); err != nil {
+		return errors.Wrap(err, "redisRepo.SetPasswordResetTokenCtx")
+	}
 
-	refresh, err = refreshToken.SignedString([]byte(u.cfg.Server.JwtSecretKey))
+	link, err := utils.TokenLink(u.cfg.PasswordReset.LibrarianResetURL, token)
 	if err != nil {
-		return "", "", err
+		return err
 	}
 
-	return access, refresh, nil
+	if err := u.mailer.Send(ctx, mailer.Message{
+		To:      librarian.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nchoose a new password here:\n\n%s\n\nThe link expires in %d minutes. If you did not ask for it, ignore this email.\n",
+			librarian.FirstName, link, expire/60),
+	}); err != nil {
+		return errors.Wrap(err, "mailer.Send")
+	}
+
+	return nil
+}
+
+func (u *librarianUseCase) registerLoginFailure(ctx context.Context, ip string) {
+	if err := u.lockoutUC.RegisterFailure(ctx, lockout.RealmLibrarian, ip); err != nil {
+		u.logger.Errorf("lockoutUC.RegisterFailure: %v", err)
+	}
 }
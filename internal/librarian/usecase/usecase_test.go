@@ -4,16 +4,25 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/lockout"
+	lockoutMock "github.com/dinorain/pinjembuku/internal/lockout/mock"
 	"github.com/dinorain/pinjembuku/internal/models"
 	"github.com/dinorain/pinjembuku/internal/librarian/mock"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/jwtkeys"
 	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/mailer"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
 )
 
 func TestLibrarianUseCase_Register(t *testing.T) {
@@ -27,7 +36,10 @@ func TestLibrarianUseCase_Register(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{Server: config.ServerConfig{JwtSecretKey: "secret123"}}
-	librarianUC := NewLibrarianUseCase(cfg, apiLogger, librarianPGRepository, librarianRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	librarianUC := NewLibrarianUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), librarianPGRepository, librarianRedisRepository)
 
 	librarianID := uuid.New()
 	mockLibrarian := &models.Librarian{
@@ -57,6 +69,94 @@ func TestLibrarianUseCase_Register(t *testing.T) {
 	require.Equal(t, createdLibrarian.LibrarianID, librarianID)
 }
 
+func TestLibrarianUseCase_ForgotPassword(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	librarianPGRepository := mock.NewMockLibrarianPGRepository(ctrl)
+	librarianRedisRepository := mock.NewMockLibrarianRedisRepository(ctrl)
+	outbox := mailer.NewOutbox("")
+
+	cfg := &config.Config{
+		Server:        config.ServerConfig{JwtSecretKey: "secret123"},
+		PasswordReset: config.PasswordReset{LibrarianResetURL: "http://localhost/librarian/reset", Expire: 600},
+	}
+	apiLogger := logger.NewAppLogger(cfg)
+	apiLogger.InitLogger()
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	librarianUC := NewLibrarianUseCase(cfg, apiLogger, tokenService, outbox, lockoutMock.NewMockLockoutUseCase(ctrl), librarianPGRepository, librarianRedisRepository)
+
+	ctx := context.Background()
+	mockLibrarian := &models.Librarian{LibrarianID: uuid.New(), Email: "email@gmail.com", FirstName: "FirstName"}
+
+	var token string
+	librarianPGRepository.EXPECT().FindByEmail(gomock.Any(), mockLibrarian.Email).Return(mockLibrarian, nil)
+	librarianRedisRepository.EXPECT().SetPasswordResetTokenCtx(gomock.Any(), gomock.Any(), 600, mockLibrarian.LibrarianID).DoAndReturn(func(ctx context.Context, resetToken string, seconds int, librarianID uuid.UUID) error {
+		token = resetToken
+		return nil
+	})
+
+	require.NoError(t, librarianUC.ForgotPassword(ctx, mockLibrarian.Email))
+	messages := outbox.Messages()
+	require.Len(t, messages, 1)
+	require.Equal(t, mockLibrarian.Email, messages[0].To)
+	require.Contains(t, messages[0].Body, "http://localhost/librarian/reset?token="+token)
+
+	librarianPGRepository.EXPECT().FindByEmail(gomock.Any(), "unknown@gmail.com").Return(nil, sql.ErrNoRows)
+
+	require.NoError(t, librarianUC.ForgotPassword(ctx, "unknown@gmail.com"))
+	require.Len(t, outbox.Messages(), 1)
+
+	librarianPGRepository.EXPECT().FindByEmail(gomock.Any(), mockLibrarian.Email).Return(mockLibrarian, nil)
+	librarianRedisRepository.EXPECT().SetPasswordResetTokenCtx(gomock.Any(), gomock.Any(), 600, mockLibrarian.LibrarianID).Return(errors.New("redis down"))
+
+	require.NoError(t, librarianUC.ForgotPassword(ctx, mockLibrarian.Email), "failed sends look like unknown emails")
+	require.Len(t, outbox.Messages(), 1)
+}
+
+func TestLibrarianUseCase_ResetPassword(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	librarianPGRepository := mock.NewMockLibrarianPGRepository(ctrl)
+	librarianRedisRepository := mock.NewMockLibrarianRedisRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{Server: config.ServerConfig{JwtSecretKey: "secret123"}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	librarianUC := NewLibrarianUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), librarianPGRepository, librarianRedisRepository)
+
+	ctx := context.Background()
+	mockLibrarian := &models.Librarian{LibrarianID: uuid.New(), Email: "email@gmail.com", Password: "old"}
+
+	librarianRedisRepository.EXPECT().TakePasswordResetTokenCtx(gomock.Any(), "token").Return(mockLibrarian.LibrarianID, nil)
+	librarianRedisRepository.EXPECT().DeletePasswordResetTokensCtx(gomock.Any(), mockLibrarian.LibrarianID).Return(nil)
+	librarianPGRepository.EXPECT().FindById(gomock.Any(), mockLibrarian.LibrarianID).Return(mockLibrarian, nil)
+	librarianPGRepository.EXPECT().UpdateById(gomock.Any(), mockLibrarian).DoAndReturn(func(ctx context.Context, librarian *models.Librarian) (*models.Librarian, error) {
+		require.NoError(t, librarian.ComparePasswords("new-password"))
+		return librarian, nil
+	})
+	librarianRedisRepository.EXPECT().SetLibrarianCtx(gomock.Any(), mockLibrarian.LibrarianID.String(), librarianByIdCacheDuration, mockLibrarian).Return(nil)
+
+	librarian, err := librarianUC.ResetPassword(ctx, "token", "new-password")
+	require.NoError(t, err)
+	require.Equal(t, mockLibrarian.LibrarianID, librarian.LibrarianID)
+	require.Empty(t, librarian.Password)
+
+	librarianRedisRepository.EXPECT().TakePasswordResetTokenCtx(gomock.Any(), "token").Return(uuid.Nil, redis.Nil)
+
+	_, err = librarianUC.ResetPassword(ctx, "token", "new-password")
+	require.ErrorIs(t, err, grpc_errors.ErrInvalidToken)
+}
+
 func TestLibrarianUseCase_FindByEmail(t *testing.T) {
 	t.Parallel()
 
@@ -68,7 +168,10 @@ func TestLibrarianUseCase_FindByEmail(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{Server: config.ServerConfig{JwtSecretKey: "secret123"}}
-	librarianUC := NewLibrarianUseCase(cfg, apiLogger, librarianPGRepository, librarianRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	librarianUC := NewLibrarianUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), librarianPGRepository, librarianRedisRepository)
 
 	librarianID := uuid.New()
 	mockLibrarian := &models.Librarian{
@@ -98,10 +201,14 @@ func TestLibrarianUseCase_Login(t *testing.T) {
 
 	librarianPGRepository := mock.NewMockLibrarianPGRepository(ctrl)
 	librarianRedisRepository := mock.NewMockLibrarianRedisRepository(ctrl)
+	lockoutUC := lockoutMock.NewMockLockoutUseCase(ctrl)
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{Server: config.ServerConfig{JwtSecretKey: "secret123"}}
-	librarianUC := NewLibrarianUseCase(cfg, apiLogger, librarianPGRepository, librarianRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	librarianUC := NewLibrarianUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutUC, librarianPGRepository, librarianRedisRepository)
 
 	librarianID := uuid.New()
 	mockLibrarian := &models.Librarian{
@@ -114,10 +221,71 @@ func TestLibrarianUseCase_Login(t *testing.T) {
 	}
 
 	ctx := context.Background()
+	ip := "127.0.0.1"
 
-	librarianPGRepository.EXPECT().FindByEmail(gomock.Any(), mockLibrarian.Email).Return(mockLibrarian, nil)
-	_, err := librarianUC.Login(ctx, mockLibrarian.Email, mockLibrarian.Password)
-	require.NotNil(t, err)
+	t.Run("Wrong password", func(t *testing.T) {
+		lockoutUC.EXPECT().Reserve(gomock.Any(), lockout.RealmLibrarian, mockLibrarian.Email, ip).Return(nil)
+		librarianPGRepository.EXPECT().FindByEmail(gomock.Any(), mockLibrarian.Email).Return(mockLibrarian, nil)
+		lockoutUC.EXPECT().RegisterFailure(gomock.Any(), lockout.RealmLibrarian, ip).Return(nil)
+
+		_, err := librarianUC.Login(ctx, mockLibrarian.Email, mockLibrarian.Password, ip)
+		require.NotNil(t, err)
+	})
+
+	t.Run("Unknown email", func(t *testing.T) {
+		lockoutUC.EXPECT().Reserve(gomock.Any(), lockout.RealmLibrarian, "unknown@gmail.com", ip).Return(nil)
+		librarianPGRepository.EXPECT().FindByEmail(gomock.Any(), "unknown@gmail.com").Return(nil, sql.ErrNoRows)
+		lockoutUC.EXPECT().RegisterFailure(gomock.Any(), lockout.RealmLibrarian, ip).Return(nil)
+
+		_, err := librarianUC.Login(ctx, "unknown@gmail.com", "123456", ip)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		lockoutUC.EXPECT().Reserve(gomock.Any(), lockout.RealmLibrarian, mockLibrarian.Email, ip).Return(&grpc_errors.RetryError{Err: grpc_errors.ErrAccountLocked, RetryAfter: time.Minute})
+
+		_, err := librarianUC.Login(ctx, mockLibrarian.Email, mockLibrarian.Password, ip)
+		require.ErrorIs(t, err, grpc_errors.ErrAccountLocked)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		hashed := *mockLibrarian
+		require.NoError(t, hashed.HashPassword())
+
+		lockoutUC.EXPECT().Reserve(gomock.Any(), lockout.RealmLibrarian, mockLibrarian.Email, ip).Return(nil)
+		librarianPGRepository.EXPECT().FindByEmail(gomock.Any(), mockLibrarian.Email).Return(&hashed, nil)
+		lockoutUC.EXPECT().RegisterSuccess(gomock.Any(), lockout.RealmLibrarian, mockLibrarian.Email).Return(nil)
+
+		loggedLibrarian, err := librarianUC.Login(ctx, mockLibrarian.Email, mockLibrarian.Password, ip)
+		require.NoError(t, err)
+		require.Equal(t, librarianID, loggedLibrarian.LibrarianID)
+	})
+}
+
+func TestLibrarianUseCase_UnlockById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	librarianPGRepository := mock.NewMockLibrarianPGRepository(ctrl)
+	librarianRedisRepository := mock.NewMockLibrarianRedisRepository(ctrl)
+	lockoutUC := lockoutMock.NewMockLockoutUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{}
+	librarianUC := NewLibrarianUseCase(cfg, apiLogger, nil, mailer.NewOutbox(""), lockoutUC, librarianPGRepository, librarianRedisRepository)
+
+	librarianID := uuid.New()
+	mockLibrarian := &models.Librarian{LibrarianID: librarianID, Email: "email@gmail.com"}
+
+	ctx := context.Background()
+
+	librarianPGRepository.EXPECT().FindById(gomock.Any(), librarianID).Return(mockLibrarian, nil)
+	lockoutUC.EXPECT().Unlock(gomock.Any(), lockout.RealmLibrarian, mockLibrarian.Email, "127.0.0.1").Return(nil)
+
+	err := librarianUC.UnlockById(ctx, librarianID, "127.0.0.1")
+	require.NoError(t, err)
 }
 
 func TestLibrarianUseCase_FindAll(t *testing.T) {
@@ -131,7 +299,10 @@ func TestLibrarianUseCase_FindAll(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{}
-	librarianUC := NewLibrarianUseCase(cfg, apiLogger, librarianPGRepository, librarianRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	librarianUC := NewLibrarianUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), librarianPGRepository, librarianRedisRepository)
 
 	librarianID := uuid.New()
 	mockLibrarian := &models.Librarian{
@@ -164,7 +335,10 @@ func TestLibrarianUseCase_FindById(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{}
-	librarianUC := NewLibrarianUseCase(cfg, apiLogger, librarianPGRepository, librarianRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	librarianUC := NewLibrarianUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), librarianPGRepository, librarianRedisRepository)
 
 	librarianID := uuid.New()
 	mockLibrarian := &models.Librarian{
@@ -200,7 +374,10 @@ func TestLibrarianUseCase_CachedFindById(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{}
-	librarianUC := NewLibrarianUseCase(cfg, apiLogger, librarianPGRepository, librarianRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	librarianUC := NewLibrarianUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), librarianPGRepository, librarianRedisRepository)
 
 	librarianID := uuid.New()
 	mockLibrarian := &models.Librarian{
@@ -235,7 +412,10 @@ func TestLibrarianUseCase_UpdateById(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{}
-	librarianUC := NewLibrarianUseCase(cfg, apiLogger, librarianPGRepository, librarianRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	librarianUC := NewLibrarianUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), librarianPGRepository, librarianRedisRepository)
 
 	librarianID := uuid.New()
 	mockLibrarian := &models.Librarian{
@@ -269,7 +449,10 @@ func TestLibrarianUseCase_DeleteById(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{}
-	librarianUC := NewLibrarianUseCase(cfg, apiLogger, librarianPGRepository, librarianRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	librarianUC := NewLibrarianUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), librarianPGRepository, librarianRedisRepository)
 
 	librarianID := uuid.New()
 	mockLibrarian := &models.Librarian{
@@ -286,7 +469,7 @@ func TestLibrarianUseCase_DeleteById(t *testing.T) {
 	librarianPGRepository.EXPECT().DeleteById(gomock.Any(), mockLibrarian.LibrarianID).Return(nil)
 	librarianRedisRepository.EXPECT().DeleteLibrarianCtx(gomock.Any(), mockLibrarian.LibrarianID.String()).AnyTimes().Return(nil)
 
-	err := librarianUC.DeleteById(ctx, mockLibrarian.LibrarianID)
+	err = librarianUC.DeleteById(ctx, mockLibrarian.LibrarianID)
 	require.NoError(t, err)
 
 	librarianPGRepository.EXPECT().FindById(gomock.Any(), mockLibrarian.LibrarianID).AnyTimes().Return(nil, nil)
@@ -304,7 +487,10 @@ func TestLibrarianUseCase_GenerateTokenPair(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{}
-	librarianUC := NewLibrarianUseCase(cfg, apiLogger, librarianPGRepository, librarianRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	librarianUC := NewLibrarianUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), librarianPGRepository, librarianRedisRepository)
 
 	librarianID := uuid.New()
 	mockLibrarian := &models.Librarian{
@@ -316,8 +502,18 @@ func TestLibrarianUseCase_GenerateTokenPair(t *testing.T) {
 		Password:      "123456",
 	}
 
-	at, rt, err := librarianUC.GenerateTokenPair(mockLibrarian, mockLibrarian.LibrarianID.String())
+	at, rt, err := librarianUC.GenerateTokenPair(mockLibrarian, mockLibrarian.LibrarianID.String(), "refresh token id")
 	require.NoError(t, err)
 	require.NotEqual(t, at, "")
 	require.NotEqual(t, rt, "")
+
+	accessToken, err := tokenService.ParseAccessToken(at)
+	require.NoError(t, err)
+	atClaims := accessToken.Claims.(jwt.MapClaims)
+	require.Equal(t, mockLibrarian.LibrarianID.String(), atClaims["sub"])
+	require.Equal(t, mockLibrarian.LibrarianID.String(), atClaims["librarian_id"])
+
+	refreshToken, err := tokenService.ParseRefreshToken(rt)
+	require.NoError(t, err)
+	require.Equal(t, "refresh token id", refreshToken.Claims.(jwt.MapClaims)["jti"])
 }
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: redis_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLockoutRepository is a mock of LockoutRepository interface.
+type MockLockoutRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockoutRepositoryMockRecorder
+}
+
+// MockLockoutRepositoryMockRecorder is the mock recorder for MockLockoutRepository.
+type MockLockoutRepositoryMockRecorder struct {
+	mock *MockLockoutRepository
+}
+
+// NewMockLockoutRepository creates a new mock instance.
+func NewMockLockoutRepository(ctrl *gomock.Controller) *MockLockoutRepository {
+	mock := &MockLockoutRepository{ctrl: ctrl}
+	mock.recorder = &MockLockoutRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLockoutRepository) EXPECT() *MockLockoutRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Block mocks base method.
+func (m *MockLockoutRepository) Block(ctx context.Context, subject string, seconds int, locked bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", ctx, subject, seconds, locked)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockLockoutRepositoryMockRecorder) Block(ctx, subject, seconds, locked interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockLockoutRepository)(nil).Block), ctx, subject, seconds, locked)
+}
+
+// GetBlock mocks base method.
+func (m *MockLockoutRepository) GetBlock(ctx context.Context, subject string) (bool, time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlock", ctx, subject)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBlock indicates an expected call of GetBlock.
+func (mr *MockLockoutRepositoryMockRecorder) GetBlock(ctx, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlock", reflect.TypeOf((*MockLockoutRepository)(nil).GetBlock), ctx, subject)
+}
+
+// IncrAttempts mocks base method.
+func (m *MockLockoutRepository) IncrAttempts(ctx context.Context, subject string, window int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrAttempts", ctx, subject, window)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrAttempts indicates an expected call of IncrAttempts.
+func (mr *MockLockoutRepositoryMockRecorder) IncrAttempts(ctx, subject, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrAttempts", reflect.TypeOf((*MockLockoutRepository)(nil).IncrAttempts), ctx, subject, window)
+}
+
+// Reset mocks base method.
+func (m *MockLockoutRepository) Reset(ctx context.Context, subject string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, subject)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockLockoutRepositoryMockRecorder) Reset(ctx, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockLockoutRepository)(nil).Reset), ctx, subject)
+}
+
+// TryBlock mocks base method.
+func (m *MockLockoutRepository) TryBlock(ctx context.Context, subject string, seconds int, locked bool) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryBlock", ctx, subject, seconds, locked)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryBlock indicates an expected call of TryBlock.
+func (mr *MockLockoutRepositoryMockRecorder) TryBlock(ctx, subject, seconds, locked interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryBlock", reflect.TypeOf((*MockLockoutRepository)(nil).TryBlock), ctx, subject, seconds, locked)
+}
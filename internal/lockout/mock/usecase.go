@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLockoutUseCase is a mock of LockoutUseCase interface.
+type MockLockoutUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockoutUseCaseMockRecorder
+}
+
+// MockLockoutUseCaseMockRecorder is the mock recorder for MockLockoutUseCase.
+type MockLockoutUseCaseMockRecorder struct {
+	mock *MockLockoutUseCase
+}
+
+// NewMockLockoutUseCase creates a new mock instance.
+func NewMockLockoutUseCase(ctrl *gomock.Controller) *MockLockoutUseCase {
+	mock := &MockLockoutUseCase{ctrl: ctrl}
+	mock.recorder = &MockLockoutUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLockoutUseCase) EXPECT() *MockLockoutUseCaseMockRecorder {
+	return m.recorder
+}
+
+// RegisterFailure mocks base method.
+func (m *MockLockoutUseCase) RegisterFailure(ctx context.Context, realm, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterFailure", ctx, realm, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterFailure indicates an expected call of RegisterFailure.
+func (mr *MockLockoutUseCaseMockRecorder) RegisterFailure(ctx, realm, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFailure", reflect.TypeOf((*MockLockoutUseCase)(nil).RegisterFailure), ctx, realm, ip)
+}
+
+// RegisterSuccess mocks base method.
+func (m *MockLockoutUseCase) RegisterSuccess(ctx context.Context, realm, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterSuccess", ctx, realm, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterSuccess indicates an expected call of RegisterSuccess.
+func (mr *MockLockoutUseCaseMockRecorder) RegisterSuccess(ctx, realm, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSuccess", reflect.TypeOf((*MockLockoutUseCase)(nil).RegisterSuccess), ctx, realm, email)
+}
+
+// Reserve mocks base method.
+func (m *MockLockoutUseCase) Reserve(ctx context.Context, realm, email, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, realm, email, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockLockoutUseCaseMockRecorder) Reserve(ctx, realm, email, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockLockoutUseCase)(nil).Reserve), ctx, realm, email, ip)
+}
+
+// Unlock mocks base method.
+func (m *MockLockoutUseCase) Unlock(ctx context.Context, realm, email, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", ctx, realm, email, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockLockoutUseCaseMockRecorder) Unlock(ctx, realm, email, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockLockoutUseCase)(nil).Unlock), ctx, realm, email, ip)
+}
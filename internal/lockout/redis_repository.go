@@ -0,0 +1,16 @@
+//go:generate mockgen -source redis_repository.go -destination mock/redis_repository.go -package mock
+package lockout
+
+import (
+	"context"
+	"time"
+)
+
+// Lockout repository, keeps login attempts and blocks per subject
+type LockoutRepository interface {
+	IncrAttempts(ctx context.Context, subject string, window int) (int, error)
+	Block(ctx context.Context, subject string, seconds int, locked bool) error
+	TryBlock(ctx context.Context, subject string, seconds int, locked bool) (bool, error)
+	GetBlock(ctx context.Context, subject string) (locked bool, retryAfter time.Duration, err error)
+	Reset(ctx context.Context, subject string) error
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/dinorain/pinjembuku/internal/lockout"
+)
+
+const (
+	attemptsPrefix = "login-attempts:"
+	blocksPrefix   = "login-blocks:"
+
+	blockLocked  = "locked"
+	blockBackoff = "backoff"
+)
+
+// Lockout repository
+type lockoutRepo struct {
+	redisClient    *redis.Client
+	attemptsPrefix string
+	blocksPrefix   string
+}
+
+var _ lockout.LockoutRepository = (*lockoutRepo)(nil)
+
+// Lockout repository constructor
+func NewLockoutRepository(redisClient *redis.Client) lockout.LockoutRepository {
+	return &lockoutRepo{redisClient: redisClient, attemptsPrefix: attemptsPrefix, blocksPrefix: blocksPrefix}
+}
+
+// Count attempt of subject, the count expires window seconds after the last attempt
+func (r *lockoutRepo) IncrAttempts(ctx context.Context, subject string, window int) (int, error) {
+	key := r.createKey(r.attemptsPrefix, subject)
+
+	var incr *redis.IntCmd
+	if _, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, time.Second*time.Duration(window))
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return int(incr.Val()), nil
+}
+
+// Block logins of subject for seconds, locked tells a lockout apart from a backoff
+func (r *lockoutRepo) Block(ctx context.Context, subject string, seconds int, locked bool) error {
+	return r.redisClient.Set(ctx, r.createKey(r.blocksPrefix, subject), r.blockValue(locked), time.Second*time.Duration(seconds)).Err()
+}
+
+// Block logins of subject for seconds unless it is blocked already, reports whether the block was set
+func (r *lockoutRepo) TryBlock(ctx context.Context, subject string, seconds int, locked bool) (bool, error) {
+	return r.redisClient.SetNX(ctx, r.createKey(r.blocksPrefix, subject), r.blockValue(locked), time.Second*time.Duration(seconds)).Result()
+}
+
+// Get block of subject and how long it lasts, returns redis.Nil when subject is not blocked
+func (r *lockoutRepo) GetBlock(ctx context.Context, subject string) (bool, time.Duration, error) {
+	key := r.createKey(r.blocksPrefix, subject)
+
+	var get *redis.StringCmd
+	var ttl *redis.DurationCmd
+	if _, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, key)
+		ttl = pipe.PTTL(ctx, key)
+		return nil
+	}); err != nil {
+		return false, 0, err
+	}
+
+	return get.Val() == blockLocked, ttl.Val(), nil
+}
+
+// Reset attempts and block of subject
+func (r *lockoutRepo) Reset(ctx context.Context, subject string) error {
+	return r.redisClient.Del(ctx, r.createKey(r.attemptsPrefix, subject), r.createKey(r.blocksPrefix, subject)).Err()
+}
+
+func (r *lockoutRepo) createKey(prefix string, subject string) string {
+	return fmt.Sprintf("%s %s", prefix, subject)
+}
+
+func (r *lockoutRepo) blockValue(locked bool) string {
+	if locked {
+		return blockLocked
+	}
+	return blockBackoff
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/internal/lockout"
+)
+
+func SetupRedis() lockout.LockoutRepository {
+	mr, err := miniredis.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	lockoutRepository := NewLockoutRepository(client)
+	return lockoutRepository
+}
+
+func TestIncrAttempts(t *testing.T) {
+	t.Parallel()
+
+	lockoutRepository := SetupRedis()
+
+	t.Run("IncrAttempts", func(t *testing.T) {
+		subject := "user:email:email@gmail.com"
+
+		attempts, err := lockoutRepository.IncrAttempts(context.Background(), subject, 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, attempts)
+
+		attempts, err = lockoutRepository.IncrAttempts(context.Background(), subject, 10)
+		require.NoError(t, err)
+		require.Equal(t, 2, attempts)
+
+		attempts, err = lockoutRepository.IncrAttempts(context.Background(), "user:ip:127.0.0.1", 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, attempts)
+	})
+}
+
+func TestBlock(t *testing.T) {
+	t.Parallel()
+
+	lockoutRepository := SetupRedis()
+
+	t.Run("Block", func(t *testing.T) {
+		subject := "user:email:email@gmail.com"
+
+		_, _, err := lockoutRepository.GetBlock(context.Background(), subject)
+		require.ErrorIs(t, err, redis.Nil)
+
+		err = lockoutRepository.Block(context.Background(), subject, 10, false)
+		require.NoError(t, err)
+
+		locked, retryAfter, err := lockoutRepository.GetBlock(context.Background(), subject)
+		require.NoError(t, err)
+		require.False(t, locked)
+		require.True(t, retryAfter > 0 && retryAfter <= 10*time.Second)
+
+		err = lockoutRepository.Block(context.Background(), subject, 10, true)
+		require.NoError(t, err)
+
+		locked, _, err = lockoutRepository.GetBlock(context.Background(), subject)
+		require.NoError(t, err)
+		require.True(t, locked)
+	})
+}
+
+func TestTryBlock(t *testing.T) {
+	t.Parallel()
+
+	lockoutRepository := SetupRedis()
+
+	t.Run("TryBlock", func(t *testing.T) {
+		subject := "user:email:email@gmail.com"
+
+		blocked, err := lockoutRepository.TryBlock(context.Background(), subject, 10, false)
+		require.NoError(t, err)
+		require.True(t, blocked)
+
+		blocked, err = lockoutRepository.TryBlock(context.Background(), subject, 10, true)
+		require.NoError(t, err)
+		require.False(t, blocked)
+
+		locked, _, err := lockoutRepository.GetBlock(context.Background(), subject)
+		require.NoError(t, err)
+		require.False(t, locked)
+	})
+}
+
+func TestReset(t *testing.T) {
+	t.Parallel()
+
+	lockoutRepository := SetupRedis()
+
+	t.Run("Reset", func(t *testing.T) {
+		subject := "user:email:email@gmail.com"
+
+		_, err := lockoutRepository.IncrAttempts(context.Background(), subject, 10)
+		require.NoError(t, err)
+		err = lockoutRepository.Block(context.Background(), subject, 10, true)
+		require.NoError(t, err)
+
+		err = lockoutRepository.Reset(context.Background(), subject)
+		require.NoError(t, err)
+
+		_, _, err = lockoutRepository.GetBlock(context.Background(), subject)
+		require.ErrorIs(t, err, redis.Nil)
+
+		attempts, err := lockoutRepository.IncrAttempts(context.Background(), subject, 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, attempts)
+	})
+}
@@ -0,0 +1,19 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package lockout
+
+import (
+	"context"
+)
+
+const (
+	RealmUser      = "user"
+	RealmLibrarian = "librarian"
+)
+
+// Lockout UseCase, guards logins of a realm against guessing passwords by email and by client ip
+type LockoutUseCase interface {
+	Reserve(ctx context.Context, realm string, email string, ip string) error
+	RegisterFailure(ctx context.Context, realm string, ip string) error
+	RegisterSuccess(ctx context.Context, realm string, email string) error
+	Unlock(ctx context.Context, realm string, email string, ip string) error
+}
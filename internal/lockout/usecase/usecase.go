@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/lockout"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+)
+
+const (
+	defaultFreeAttempts      = 3
+	defaultMaxAttempts       = 10
+	defaultIPMaxAttempts     = 50
+	defaultBackoffSeconds    = 1
+	defaultMaxBackoffSeconds = 5 * 60
+	defaultWindowSeconds     = 15 * 60
+	defaultLockSeconds       = 15 * 60
+)
+
+// Lockout use case
+type lockoutUC struct {
+	lockoutRepo lockout.LockoutRepository
+	cfg         *config.Config
+}
+
+var _ lockout.LockoutUseCase = (*lockoutUC)(nil)
+
+// New lockout use case constructor
+func NewLockoutUseCase(lockoutRepo lockout.LockoutRepository, cfg *config.Config) lockout.LockoutUseCase {
+	return &lockoutUC{lockoutRepo: lockoutRepo, cfg: cfg}
+}
+
+// Reserve a login attempt of email from ip before the password is compared, so parallel attempts cannot outrun
+// the backoff. Returns grpc_errors.RetryError while email or ip is blocked or the attempt lost the race for its slot
+func (u *lockoutUC) Reserve(ctx context.Context, realm string, email string, ip string) error {
+	if err := u.checkBlocks(ctx, u.subjects(realm, email, ip)); err != nil {
+		return err
+	}
+
+	emailSubject := u.emailSubject(realm, email)
+	attempts, err := u.lockoutRepo.IncrAttempts(ctx, emailSubject, u.windowSeconds())
+	if err != nil {
+		return errors.Wrap(err, "lockoutRepo.IncrAttempts")
+	}
+
+	switch {
+	case attempts > u.maxAttempts():
+		// raced the last attempt, which locks the account
+		if err := u.lockoutRepo.Block(ctx, emailSubject, u.lockSeconds(), true); err != nil {
+			return errors.Wrap(err, "lockoutRepo.Block")
+		}
+		return &grpc_errors.RetryError{Err: grpc_errors.ErrAccountLocked, RetryAfter: time.Duration(u.lockSeconds()) * time.Second}
+	case attempts == u.maxAttempts():
+		// last attempt locks the account unless it succeeds
+		if err := u.lockoutRepo.Block(ctx, emailSubject, u.lockSeconds(), true); err != nil {
+			return errors.Wrap(err, "lockoutRepo.Block")
+		}
+	case attempts > u.freeAttempts():
+		seconds := u.backoffSeconds(attempts)
+		reserved, err := u.lockoutRepo.TryBlock(ctx, emailSubject, seconds, false)
+		if err != nil {
+			return errors.Wrap(err, "lockoutRepo.TryBlock")
+		}
+		if !reserved {
+			return &grpc_errors.RetryError{Err: grpc_errors.ErrTooManyAttempts, RetryAfter: time.Duration(seconds) * time.Second}
+		}
+	}
+
+	return nil
+}
+
+// Count failed login from ip and block it at IPMaxAttempts, attempts of the email were counted by Reserve
+func (u *lockoutUC) RegisterFailure(ctx context.Context, realm string, ip string) error {
+	if ip == "" {
+		return nil
+	}
+
+	ipSubject := u.ipSubject(realm, ip)
+	failures, err := u.lockoutRepo.IncrAttempts(ctx, ipSubject, u.windowSeconds())
+	if err != nil {
+		return errors.Wrap(err, "lockoutRepo.IncrAttempts")
+	}
+	if failures >= u.ipMaxAttempts() {
+		if err := u.lockoutRepo.Block(ctx, ipSubject, u.lockSeconds(), false); err != nil {
+			return errors.Wrap(err, "lockoutRepo.Block")
+		}
+	}
+
+	return nil
+}
+
+// Reset login attempts of email, failures of the client ip keep counting
+func (u *lockoutUC) RegisterSuccess(ctx context.Context, realm string, email string) error {
+	if err := u.lockoutRepo.Reset(ctx, u.emailSubject(realm, email)); err != nil {
+		return errors.Wrap(err, "lockoutRepo.Reset")
+	}
+	return nil
+}
+
+// Unlock email and, when set, ip by resetting their login attempts
+func (u *lockoutUC) Unlock(ctx context.Context, realm string, email string, ip string) error {
+	for _, subject := range u.subjects(realm, email, ip) {
+		if err := u.lockoutRepo.Reset(ctx, subject); err != nil {
+			return errors.Wrap(err, "lockoutRepo.Reset")
+		}
+	}
+	return nil
+}
+
+func (u *lockoutUC) checkBlocks(ctx context.Context, subjects []string) error {
+	for _, subject := range subjects {
+		locked, retryAfter, err := u.lockoutRepo.GetBlock(ctx, subject)
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "lockoutRepo.GetBlock")
+		}
+
+		if locked {
+			return &grpc_errors.RetryError{Err: grpc_errors.ErrAccountLocked, RetryAfter: retryAfter}
+		}
+		return &grpc_errors.RetryError{Err: grpc_errors.ErrTooManyAttempts, RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// Backoff after attempts, doubles with every attempt past the free attempts
+func (u *lockoutUC) backoffSeconds(attempts int) int {
+	extra := attempts - u.freeAttempts()
+	if extra <= 0 {
+		return 0
+	}
+
+	seconds, maxSeconds := u.cfg.Lockout.BackoffSeconds, u.cfg.Lockout.MaxBackoffSeconds
+	if seconds <= 0 {
+		seconds = defaultBackoffSeconds
+	}
+	if maxSeconds <= 0 {
+		maxSeconds = defaultMaxBackoffSeconds
+	}
+
+	for i := 1; i < extra && seconds < maxSeconds; i++ {
+		seconds *= 2
+	}
+	if seconds > maxSeconds {
+		return maxSeconds
+	}
+	return seconds
+}
+
+func (u *lockoutUC) subjects(realm string, email string, ip string) []string {
+	subjects := []string{u.emailSubject(realm, email)}
+	if ip != "" {
+		subjects = append(subjects, u.ipSubject(realm, ip))
+	}
+	return subjects
+}
+
+func (u *lockoutUC) emailSubject(realm string, email string) string {
+	return fmt.Sprintf("%s:email:%s", realm, strings.ToLower(strings.TrimSpace(email)))
+}
+
+func (u *lockoutUC) ipSubject(realm string, ip string) string {
+	return fmt.Sprintf("%s:ip:%s", realm, ip)
+}
+
+func (u *lockoutUC) freeAttempts() int {
+	if u.cfg.Lockout.FreeAttempts <= 0 {
+		return defaultFreeAttempts
+	}
+	return u.cfg.Lockout.FreeAttempts
+}
+
+func (u *lockoutUC) maxAttempts() int {
+	if u.cfg.Lockout.MaxAttempts <= 0 {
+		return defaultMaxAttempts
+	}
+	return u.cfg.Lockout.MaxAttempts
+}
+
+func (u *lockoutUC) ipMaxAttempts() int {
+	if u.cfg.Lockout.IPMaxAttempts <= 0 {
+		return defaultIPMaxAttempts
+	}
+	return u.cfg.Lockout.IPMaxAttempts
+}
+
+func (u *lockoutUC) windowSeconds() int {
+	if u.cfg.Lockout.WindowSeconds <= 0 {
+		return defaultWindowSeconds
+	}
+	return u.cfg.Lockout.WindowSeconds
+}
+
+func (u *lockoutUC) lockSeconds() int {
+	if u.cfg.Lockout.LockSeconds <= 0 {
+		return defaultLockSeconds
+	}
+	return u.cfg.Lockout.LockSeconds
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/lockout"
+	"github.com/dinorain/pinjembuku/internal/lockout/mock"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+)
+
+func TestLockoutUC_Reserve(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLockoutRepo := mock.NewMockLockoutRepository(ctrl)
+	lockoutUC := NewLockoutUseCase(mockLockoutRepo, &config.Config{Lockout: config.Lockout{
+		FreeAttempts:      3,
+		MaxAttempts:       10,
+		IPMaxAttempts:     50,
+		BackoffSeconds:    1,
+		MaxBackoffSeconds: 5,
+		WindowSeconds:     900,
+		LockSeconds:       600,
+	}})
+
+	ctx := context.Background()
+	emailSubject, ipSubject := "user:email:email@gmail.com", "user:ip:127.0.0.1"
+
+	t.Run("Free attempt", func(t *testing.T) {
+		mockLockoutRepo.EXPECT().GetBlock(gomock.Any(), emailSubject).Return(false, time.Duration(0), redis.Nil)
+		mockLockoutRepo.EXPECT().GetBlock(gomock.Any(), ipSubject).Return(false, time.Duration(0), redis.Nil)
+		mockLockoutRepo.EXPECT().IncrAttempts(gomock.Any(), emailSubject, 900).Return(3, nil)
+
+		err := lockoutUC.Reserve(ctx, lockout.RealmUser, " Email@gmail.com", "127.0.0.1")
+		require.NoError(t, err)
+	})
+
+	t.Run("Backoff", func(t *testing.T) {
+		mockLockoutRepo.EXPECT().GetBlock(gomock.Any(), emailSubject).Return(false, time.Duration(0), redis.Nil)
+		mockLockoutRepo.EXPECT().GetBlock(gomock.Any(), ipSubject).Return(false, time.Duration(0), redis.Nil)
+		mockLockoutRepo.EXPECT().IncrAttempts(gomock.Any(), emailSubject, 900).Return(6, nil)
+		mockLockoutRepo.EXPECT().TryBlock(gomock.Any(), emailSubject, 4, false).Return(true, nil)
+
+		err := lockoutUC.Reserve(ctx, lockout.RealmUser, "email@gmail.com", "127.0.0.1")
+		require.NoError(t, err)
+	})
+
+	t.Run("Backoff taken by parallel attempt", func(t *testing.T) {
+		mockLockoutRepo.EXPECT().GetBlock(gomock.Any(), emailSubject).Return(false, time.Duration(0), redis.Nil)
+		mockLockoutRepo.EXPECT().GetBlock(gomock.Any(), ipSubject).Return(false, time.Duration(0), redis.Nil)
+		mockLockoutRepo.EXPECT().IncrAttempts(gomock.Any(), emailSubject, 900).Return(7, nil)
+		mockLockoutRepo.EXPECT().TryBlock(gomock.Any(), emailSubject, 5, false).Return(false, nil)
+
+		err := lockoutUC.Reserve(ctx, lockout.RealmUser, "email@gmail.com", "127.0.0.1")
+		require.ErrorIs(t, err, grpc_errors.ErrTooManyAttempts)
+
+		var retryErr *grpc_errors.RetryError
+		require.ErrorAs(t, err, &retryErr)
+		require.Equal(t, 5*time.Second, retryErr.RetryAfter)
+	})
+
+	t.Run("Last attempt", func(t *testing.T) {
+		mockLockoutRepo.EXPECT().GetBlock(gomock.Any(), emailSubject).Return(false, time.Duration(0), redis.Nil)
+		mockLockoutRepo.EXPECT().GetBlock(gomock.Any(), ipSubject).Return(false, time.Duration(0), redis.Nil)
+		mockLockoutRepo.EXPECT().IncrAttempts(gomock.Any(), emailSubject, 900).Return(10, nil)
+		mockLockoutRepo.EXPECT().Block(gomock.Any(), emailSubject, 600, true).Return(nil)
+
+		err := lockoutUC.Reserve(ctx, lockout.RealmUser, "email@gmail.com", "127.0.0.1")
+		require.NoError(t, err)
+	})
+
+	t.Run("Past last attempt", func(t *testing.T) {
+		mockLockoutRepo.EXPECT().GetBlock(gomock.Any(), emailSubject).Return(false, time.Duration(0), redis.Nil)
+		mockLockoutRepo.EXPECT().GetBlock(gomock.Any(), ipSubject).Return(false, time.Duration(0), redis.Nil)
+		mockLockoutRepo.EXPECT().IncrAttempts(gomock.Any(), emailSubject, 900).Return(11, nil)
+		mockLockoutRepo.EXPECT().Block(gomock.Any(), emailSubject, 600, true).Return(nil)
+
+		err := lockoutUC.Reserve(ctx, lockout.RealmUser, "email@gmail.com", "127.0.0.1")
+		require.ErrorIs(t, err, grpc_errors.ErrAccountLocked)
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		mockLockoutRepo.EXPECT().GetBlock(gomock.Any(), emailSubject).Return(true, time.Minute, nil)
+
+		err := lockoutUC.Reserve(ctx, lockout.RealmUser, "email@gmail.com", "127.0.0.1")
+		require.ErrorIs(t, err, grpc_errors.ErrAccountLocked)
+
+		var retryErr *grpc_errors.RetryError
+		require.ErrorAs(t, err, &retryErr)
+		require.Equal(t, time.Minute, retryErr.RetryAfter)
+	})
+
+	t.Run("IP blocked", func(t *testing.T) {
+		mockLockoutRepo.EXPECT().GetBlock(gomock.Any(), "librarian:email:email@gmail.com").Return(false, time.Duration(0), redis.Nil)
+		mockLockoutRepo.EXPECT().GetBlock(gomock.Any(), "librarian:ip:127.0.0.1").Return(false, time.Second, nil)
+
+		err := lockoutUC.Reserve(ctx, lockout.RealmLibrarian, "email@gmail.com", "127.0.0.1")
+		require.ErrorIs(t, err, grpc_errors.ErrTooManyAttempts)
+	})
+}
+
+func TestLockoutUC_RegisterFailure(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLockoutRepo := mock.NewMockLockoutRepository(ctrl)
+	lockoutUC := NewLockoutUseCase(mockLockoutRepo, &config.Config{Lockout: config.Lockout{
+		FreeAttempts:  3,
+		IPMaxAttempts: 50,
+		WindowSeconds: 900,
+		LockSeconds:   600,
+	}})
+
+	ctx := context.Background()
+	ipSubject := "user:ip:127.0.0.1"
+
+	t.Run("Below threshold", func(t *testing.T) {
+		mockLockoutRepo.EXPECT().IncrAttempts(gomock.Any(), ipSubject, 900).Return(9, nil)
+
+		err := lockoutUC.RegisterFailure(ctx, lockout.RealmUser, "127.0.0.1")
+		require.NoError(t, err)
+	})
+
+	t.Run("Threshold", func(t *testing.T) {
+		mockLockoutRepo.EXPECT().IncrAttempts(gomock.Any(), ipSubject, 900).Return(50, nil)
+		mockLockoutRepo.EXPECT().Block(gomock.Any(), ipSubject, 600, false).Return(nil)
+
+		err := lockoutUC.RegisterFailure(ctx, lockout.RealmUser, "127.0.0.1")
+		require.NoError(t, err)
+	})
+}
+
+func TestLockoutUC_RegisterSuccess(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLockoutRepo := mock.NewMockLockoutRepository(ctrl)
+	lockoutUC := NewLockoutUseCase(mockLockoutRepo, &config.Config{})
+
+	mockLockoutRepo.EXPECT().Reset(gomock.Any(), "user:email:email@gmail.com").Return(nil)
+
+	err := lockoutUC.RegisterSuccess(context.Background(), lockout.RealmUser, "email@gmail.com")
+	require.NoError(t, err)
+}
+
+func TestLockoutUC_Unlock(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLockoutRepo := mock.NewMockLockoutRepository(ctrl)
+	lockoutUC := NewLockoutUseCase(mockLockoutRepo, &config.Config{})
+
+	mockLockoutRepo.EXPECT().Reset(gomock.Any(), "librarian:email:email@gmail.com").Return(nil)
+	mockLockoutRepo.EXPECT().Reset(gomock.Any(), "librarian:ip:127.0.0.1").Return(nil)
+
+	err := lockoutUC.Unlock(context.Background(), lockout.RealmLibrarian, "email@gmail.com", "127.0.0.1")
+	require.NoError(t, err)
+}
@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"time"
@@ -11,8 +12,11 @@ import (
 
 	"github.com/dinorain/pinjembuku/config"
 	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/internal/session"
+	"github.com/dinorain/pinjembuku/pkg/constants"
 	httpErrors "github.com/dinorain/pinjembuku/pkg/http_errors"
 	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
 )
 
 type MiddlewareManager interface {
@@ -21,44 +25,80 @@ type MiddlewareManager interface {
 	IsLibrarian(next echo.HandlerFunc) echo.HandlerFunc
 	IsUser(next echo.HandlerFunc) echo.HandlerFunc
 	IsAdmin(next echo.HandlerFunc) echo.HandlerFunc
+	ForgetSession(sessionID string)
 }
 
 type middlewareManager struct {
-	logger logger.Logger
-	cfg    *config.Config
+	logger   logger.Logger
+	cfg      *config.Config
+	tokens   *tokens.Service
+	sessRepo session.SessRepository
+	sessions *sessionCache
 }
 
 var _ MiddlewareManager = (*middlewareManager)(nil)
 
-func NewMiddlewareManager(logger logger.Logger, cfg *config.Config) *middlewareManager {
-	return &middlewareManager{logger: logger, cfg: cfg}
+func NewMiddlewareManager(logger logger.Logger, cfg *config.Config, tokens *tokens.Service, sessRepo session.SessRepository) *middlewareManager {
+	return &middlewareManager{logger: logger, cfg: cfg, tokens: tokens, sessRepo: sessRepo, sessions: newSessionCache()}
 }
 
+// IsLoggedIn verify the access token and that its session still exists, and put the principal into the context
 func (mw *middlewareManager) IsLoggedIn() echo.MiddlewareFunc {
-	return middleware.JWTWithConfig(middleware.JWTConfig{
-		SigningKey: []byte(mw.cfg.Server.JwtSecretKey),
+	jwtMiddleware := middleware.JWTWithConfig(middleware.JWTConfig{
+		ParseTokenFunc: func(auth string, c echo.Context) (interface{}, error) {
+			return mw.tokens.ParseAccessToken(auth)
+		},
 	})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return jwtMiddleware(func(c echo.Context) error {
+			user, ok := c.Get("user").(*jwt.Token)
+			if !ok {
+				mw.logger.Warnf("jwt.Token: %+v", c.Get("user"))
+				return httpErrors.NewUnauthorizedError(c, nil, mw.cfg.Http.DebugErrorsResponse)
+			}
+			claims, ok := user.Claims.(jwt.MapClaims)
+			if !ok {
+				mw.logger.Warnf("jwt.MapClaims: %+v", c.Get("user"))
+				return httpErrors.NewUnauthorizedError(c, nil, mw.cfg.Http.DebugErrorsResponse)
+			}
+
+			principal, err := models.NewPrincipalFromClaims(claims)
+			if err != nil {
+				mw.logger.Warnf("models.NewPrincipalFromClaims: %v", err)
+				return httpErrors.NewUnauthorizedError(c, err, mw.cfg.Http.DebugErrorsResponse)
+			}
+
+			session, err := mw.getSession(c.Request().Context(), principal.SessionID)
+			if err != nil {
+				mw.logger.Warnf("getSession: %v", err)
+				return httpErrors.NewUnauthorizedError(c, nil, mw.cfg.Http.DebugErrorsResponse)
+			}
+			if session.UserID != principal.ID {
+				mw.logger.Warnf("session %s does not belong to %s", principal.SessionID, principal.ID)
+				return httpErrors.NewUnauthorizedError(c, nil, mw.cfg.Http.DebugErrorsResponse)
+			}
+
+			c.Set(constants.Principal, principal)
+			return next(c)
+		})
+	}
+}
+
+// ForgetSession drop session from the in-process cache, call it whenever a session is deleted
+func (mw *middlewareManager) ForgetSession(sessionID string) {
+	mw.sessions.delete(sessionID)
 }
 
 func (mw *middlewareManager) IsAdmin(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		user, ok := c.Get("user").(*jwt.Token)
-		if !ok {
-			mw.logger.Warnf("jwt.Token: %+v", c.Get("user"))
-			return errors.New("invalid token header")
-		}
-		claims := user.Claims.(jwt.MapClaims)
-		if !ok {
-			mw.logger.Warnf("jwt.MapClaims: %+v", c.Get("user"))
-			return errors.New("invalid token header")
-		}
-
-		role, ok := claims["role"].(string)
-		if !ok {
-			mw.logger.Warnf("role: %+v", claims)
+		principal, err := PrincipalFromCtx(c)
+		if err != nil {
+			mw.logger.Warnf("PrincipalFromCtx: %v", err)
+			return httpErrors.NewUnauthorizedError(c, nil, mw.cfg.Http.DebugErrorsResponse)
 		}
 
-		if role != models.UserRoleAdmin {
+		if principal.IsLibrarian() || principal.Role != models.UserRoleAdmin {
 			return httpErrors.NewForbiddenError(c, nil, mw.cfg.Http.DebugErrorsResponse)
 		}
 
@@ -68,22 +108,13 @@ func (mw *middlewareManager) IsAdmin(next echo.HandlerFunc) echo.HandlerFunc {
 
 func (mw *middlewareManager) IsLibrarian(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		user, ok := c.Get("user").(*jwt.Token)
-		if !ok {
-			mw.logger.Warnf("jwt.Token: %+v", c.Get("user"))
-			return errors.New("invalid token header")
-		}
-		claims := user.Claims.(jwt.MapClaims)
-		if !ok {
-			mw.logger.Warnf("jwt.MapClaims: %+v", c.Get("user"))
-			return errors.New("invalid token header")
-		}
-		librarianID, ok := claims["librarian_id"].(string)
-		if !ok {
-			mw.logger.Warnf("librarian_id: %+v", claims)
+		principal, err := PrincipalFromCtx(c)
+		if err != nil {
+			mw.logger.Warnf("PrincipalFromCtx: %v", err)
+			return httpErrors.NewUnauthorizedError(c, nil, mw.cfg.Http.DebugErrorsResponse)
 		}
 
-		if librarianID == "" {
+		if !principal.IsLibrarian() {
 			return httpErrors.NewForbiddenError(c, nil, mw.cfg.Http.DebugErrorsResponse)
 		}
 
@@ -93,23 +124,13 @@ func (mw *middlewareManager) IsLibrarian(next echo.HandlerFunc) echo.HandlerFunc
 
 func (mw *middlewareManager) IsUser(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		user, ok := c.Get("user").(*jwt.Token)
-		if !ok {
-			mw.logger.Warnf("jwt.Token: %+v", c.Get("user"))
-			return errors.New("invalid token header")
-		}
-		claims := user.Claims.(jwt.MapClaims)
-		if !ok {
-			mw.logger.Warnf("jwt.MapClaims: %+v", c.Get("user"))
-			return errors.New("invalid token header")
-		}
-
-		role, ok := claims["role"].(string)
-		if !ok {
-			mw.logger.Warnf("role: %+v", claims)
+		principal, err := PrincipalFromCtx(c)
+		if err != nil {
+			mw.logger.Warnf("PrincipalFromCtx: %v", err)
+			return httpErrors.NewUnauthorizedError(c, nil, mw.cfg.Http.DebugErrorsResponse)
 		}
 
-		if role != models.UserRoleUser {
+		if principal.IsLibrarian() || principal.Role != models.UserRoleUser {
 			return httpErrors.NewForbiddenError(c, nil, mw.cfg.Http.DebugErrorsResponse)
 		}
 
@@ -117,6 +138,38 @@ func (mw *middlewareManager) IsUser(next echo.HandlerFunc) echo.HandlerFunc {
 	}
 }
 
+// PrincipalFromCtx returns the principal put into the context by IsLoggedIn
+func PrincipalFromCtx(c echo.Context) (*models.Principal, error) {
+	principal, ok := c.Get(constants.Principal).(*models.Principal)
+	if !ok {
+		return nil, errors.New("invalid token header")
+	}
+	return principal, nil
+}
+
+// getSession find session in the in-process cache, or else in redis
+func (mw *middlewareManager) getSession(ctx context.Context, sessionID string) (*models.Session, error) {
+	now := time.Now()
+	if session, ok := mw.sessions.get(sessionID, now); ok {
+		return session, nil
+	}
+
+	session, err := mw.sessRepo.GetSessionById(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	mw.sessions.set(sessionID, session, now, mw.sessionCacheTTL())
+
+	return session, nil
+}
+
+func (mw *middlewareManager) sessionCacheTTL() time.Duration {
+	if mw.cfg.Session.CacheSeconds > 0 {
+		return time.Duration(mw.cfg.Session.CacheSeconds) * time.Second
+	}
+	return defaultSessionCacheSeconds * time.Second
+}
+
 func (mw *middlewareManager) RequestLoggerMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(ctx echo.Context) error {
 
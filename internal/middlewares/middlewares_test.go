@@ -0,0 +1,213 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/models"
+	mockSessRepo "github.com/dinorain/pinjembuku/internal/session/mock"
+	"github.com/dinorain/pinjembuku/pkg/jwtkeys"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
+)
+
+func newTestToken(t *testing.T, tokenService *tokens.Service, claims jwt.MapClaims) string {
+	subject, _ := claims["user_id"].(string)
+	if librarianID, ok := claims["librarian_id"].(string); ok {
+		subject = librarianID
+	}
+
+	access, _, err := tokenService.GenerateTokenPair(subject, claims["session_id"].(string), uuid.New().String(), claims)
+	require.NoError(t, err)
+	return access
+}
+
+// serveStatus returns the status of the response or of the error returned by handler
+func serveStatus(t *testing.T, res *httptest.ResponseRecorder, err error) int {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	require.NoError(t, err)
+	return res.Code
+}
+
+func TestMiddlewareManager_IsLoggedIn(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sessRepo := mockSessRepo.NewMockSessRepository(ctrl)
+
+	cfg := &config.Config{Server: config.ServerConfig{JwtSecretKey: "secret"}, Session: config.Session{CacheSeconds: 60}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := NewMiddlewareManager(appLogger, cfg, tokenService, sessRepo)
+
+	e := echo.New()
+
+	serve := func(token string, next echo.HandlerFunc) int {
+		req := httptest.NewRequest(http.MethodGet, "/user/me", nil)
+		req.Header.Set(echo.HeaderAuthorization, fmt.Sprintf("bearer %v", token))
+		res := httptest.NewRecorder()
+
+		return serveStatus(t, res, mw.IsLoggedIn()(next)(e.NewContext(req, res)))
+	}
+	ok := func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}
+
+	t.Run("Principal", func(t *testing.T) {
+		userUUID := uuid.New()
+		sessID := uuid.New().String()
+		token := newTestToken(t, tokenService, jwt.MapClaims{"session_id": sessID, "user_id": userUUID.String(), "role": models.UserRoleAdmin, "email": "email@gmail.com"})
+
+		sessRepo.EXPECT().GetSessionById(gomock.Any(), sessID).Return(&models.Session{SessionID: sessID, UserID: userUUID}, nil)
+
+		var principal *models.Principal
+		code := serve(token, func(c echo.Context) error {
+			var err error
+			principal, err = PrincipalFromCtx(c)
+			require.NoError(t, err)
+			return c.NoContent(http.StatusOK)
+		})
+
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, &models.Principal{ID: userUUID, Kind: models.PrincipalKindUser, Role: models.UserRoleAdmin, Email: "email@gmail.com", SessionID: sessID}, principal)
+	})
+
+	t.Run("Librarian principal", func(t *testing.T) {
+		librarianUUID := uuid.New()
+		sessID := uuid.New().String()
+		token := newTestToken(t, tokenService, jwt.MapClaims{"session_id": sessID, "librarian_id": librarianUUID.String()})
+
+		sessRepo.EXPECT().GetSessionById(gomock.Any(), sessID).Return(&models.Session{SessionID: sessID, UserID: librarianUUID}, nil)
+
+		require.Equal(t, http.StatusOK, serve(token, mw.IsLibrarian(ok)))
+	})
+
+	t.Run("Cached session", func(t *testing.T) {
+		userUUID := uuid.New()
+		sessID := uuid.New().String()
+		token := newTestToken(t, tokenService, jwt.MapClaims{"session_id": sessID, "user_id": userUUID.String(), "role": models.UserRoleUser})
+
+		sessRepo.EXPECT().GetSessionById(gomock.Any(), sessID).Times(1).Return(&models.Session{SessionID: sessID, UserID: userUUID}, nil)
+
+		require.Equal(t, http.StatusOK, serve(token, ok))
+		require.Equal(t, http.StatusOK, serve(token, ok))
+	})
+
+	t.Run("Forgotten session", func(t *testing.T) {
+		userUUID := uuid.New()
+		sessID := uuid.New().String()
+		token := newTestToken(t, tokenService, jwt.MapClaims{"session_id": sessID, "user_id": userUUID.String(), "role": models.UserRoleUser})
+
+		gomock.InOrder(
+			sessRepo.EXPECT().GetSessionById(gomock.Any(), sessID).Return(&models.Session{SessionID: sessID, UserID: userUUID}, nil),
+			sessRepo.EXPECT().GetSessionById(gomock.Any(), sessID).Return(nil, redis.Nil),
+		)
+
+		require.Equal(t, http.StatusOK, serve(token, ok))
+		mw.ForgetSession(sessID)
+		require.Equal(t, http.StatusUnauthorized, serve(token, ok))
+	})
+
+	t.Run("Revoked session", func(t *testing.T) {
+		sessID := uuid.New().String()
+		token := newTestToken(t, tokenService, jwt.MapClaims{"session_id": sessID, "user_id": uuid.New().String(), "role": models.UserRoleUser})
+
+		sessRepo.EXPECT().GetSessionById(gomock.Any(), sessID).Return(nil, redis.Nil)
+
+		require.Equal(t, http.StatusUnauthorized, serve(token, ok))
+	})
+
+	t.Run("Session of other user", func(t *testing.T) {
+		sessID := uuid.New().String()
+		token := newTestToken(t, tokenService, jwt.MapClaims{"session_id": sessID, "user_id": uuid.New().String(), "role": models.UserRoleUser})
+
+		sessRepo.EXPECT().GetSessionById(gomock.Any(), sessID).Return(&models.Session{SessionID: sessID, UserID: uuid.New()}, nil)
+
+		require.Equal(t, http.StatusUnauthorized, serve(token, ok))
+	})
+
+	t.Run("Refresh token", func(t *testing.T) {
+		_, token, err := tokenService.GenerateTokenPair(uuid.New().String(), uuid.New().String(), uuid.New().String(), nil)
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusUnauthorized, serve(token, ok))
+	})
+
+	t.Run("Token of other issuer", func(t *testing.T) {
+		other := tokens.NewService(&config.Config{Jwt: config.Jwt{Issuer: "other"}}, keys)
+		token := newTestToken(t, other, jwt.MapClaims{"session_id": uuid.New().String(), "user_id": uuid.New().String(), "role": models.UserRoleUser})
+
+		require.Equal(t, http.StatusUnauthorized, serve(token, ok))
+	})
+
+	t.Run("Forbidden user on librarian route", func(t *testing.T) {
+		userUUID := uuid.New()
+		sessID := uuid.New().String()
+		token := newTestToken(t, tokenService, jwt.MapClaims{"session_id": sessID, "user_id": userUUID.String(), "role": models.UserRoleAdmin})
+
+		sessRepo.EXPECT().GetSessionById(gomock.Any(), sessID).Return(&models.Session{SessionID: sessID, UserID: userUUID}, nil)
+
+		require.Equal(t, http.StatusForbidden, serve(token, mw.IsLibrarian(ok)))
+	})
+}
+
+func TestMiddlewareManager_IsLoggedInKeyring(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sessRepo := mockSessRepo.NewMockSessRepository(ctrl)
+
+	cfg := &config.Config{Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+
+	key, err := jwtkeys.GenerateKey("k1", jwtkeys.AlgorithmEdDSA)
+	require.NoError(t, err)
+	keys, err := jwtkeys.NewKeyring(key)
+	require.NoError(t, err)
+	mw := NewMiddlewareManager(appLogger, cfg, tokens.NewService(cfg, keys), sessRepo)
+
+	legacyKeys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+
+	e := echo.New()
+	serve := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/user/me", nil)
+		req.Header.Set(echo.HeaderAuthorization, fmt.Sprintf("bearer %v", token))
+		res := httptest.NewRecorder()
+
+		return serveStatus(t, res, mw.IsLoggedIn()(func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})(e.NewContext(req, res)))
+	}
+
+	userUUID := uuid.New()
+	sessID := uuid.New().String()
+	claims := jwt.MapClaims{"session_id": sessID, "user_id": userUUID.String(), "role": models.UserRoleUser}
+
+	sessRepo.EXPECT().GetSessionById(gomock.Any(), sessID).Return(&models.Session{SessionID: sessID, UserID: userUUID}, nil)
+	require.Equal(t, http.StatusOK, serve(newTestToken(t, tokens.NewService(cfg, keys), claims)))
+
+	require.Equal(t, http.StatusUnauthorized, serve(newTestToken(t, tokens.NewService(cfg, legacyKeys), claims)))
+}
@@ -0,0 +1,61 @@
+package middlewares
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+)
+
+const (
+	defaultSessionCacheSeconds = 5
+	sessionCacheSweepSize      = 10000
+)
+
+type sessionCacheEntry struct {
+	session   *models.Session
+	expiresAt time.Time
+}
+
+// sessionCache keeps sessions found in redis for a short while so every request does not hit redis,
+// revoked sessions are accepted by other instances until their entry expires
+type sessionCache struct {
+	mu      sync.RWMutex
+	entries map[string]sessionCacheEntry
+}
+
+func newSessionCache() *sessionCache {
+	return &sessionCache{entries: make(map[string]sessionCacheEntry)}
+}
+
+func (c *sessionCache) get(sessionID string, now time.Time) (*models.Session, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[sessionID]
+	if !ok || now.After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.session, true
+}
+
+func (c *sessionCache) set(sessionID string, session *models.Session, now time.Time, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= sessionCacheSweepSize {
+		for id, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, id)
+			}
+		}
+	}
+	c.entries[sessionID] = sessionCacheEntry{session: session, expiresAt: now.Add(ttl)}
+}
+
+func (c *sessionCache) delete(sessionID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, sessionID)
+}
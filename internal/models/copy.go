@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CopyStatusAvailable = "available"
+	CopyStatusOnLoan    = "on_loan"
+	CopyStatusOnHold    = "on_hold"
+	CopyStatusLost      = "lost"
+	CopyStatusWithdrawn = "withdrawn"
+
+	CopyConditionGood = "good"
+)
+
+// Copy model, a physical copy of a book owned by the library
+type Copy struct {
+	CopyID    uuid.UUID `json:"copy_id" db:"copy_id"`
+	Barcode   string    `json:"barcode" db:"barcode"`
+	BookKey   string    `json:"book_key" db:"book_key"`
+	Condition string    `json:"condition" db:"condition"`
+	Location  *string   `json:"location" db:"location"`
+	Status    string    `json:"status" db:"status"`
+	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FineKindOverdue = "overdue"
+	FineKindLost    = "lost"
+
+	FineStatusOutstanding = "outstanding"
+	FineStatusPaid        = "paid"
+	FineStatusWaived      = "waived"
+)
+
+// Fine model, a charge against a user for an order, amount is in minor currency units
+type Fine struct {
+	FineID      uuid.UUID  `json:"fine_id" db:"fine_id"`
+	OrderID     uuid.UUID  `json:"order_id" db:"order_id"`
+	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+	LibrarianID *uuid.UUID `json:"librarian_id" db:"librarian_id"`
+	Kind        string     `json:"kind" db:"kind"`
+	Amount      int64      `json:"amount" db:"amount"`
+	Status      string     `json:"status" db:"status"`
+	Note        *string    `json:"note" db:"note"`
+	SettledAt   *time.Time `json:"settled_at" db:"settled_at"`
+	CreatedAt   time.Time  `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at,omitempty" db:"updated_at"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	HoldStatusWaiting   = "waiting"
+	HoldStatusReady     = "ready"
+	HoldStatusFulfilled = "fulfilled"
+	HoldStatusCancelled = "cancelled"
+	HoldStatusExpired   = "expired"
+)
+
+// Hold model, a place in the queue for a book with no copy free. A ready hold has a copy set aside until ReadyUntil
+type Hold struct {
+	HoldID     uuid.UUID  `json:"hold_id" db:"hold_id"`
+	UserID     uuid.UUID  `json:"user_id" db:"user_id"`
+	BookKey    string     `json:"book_key" db:"book_key"`
+	CopyID     *uuid.UUID `json:"copy_id" db:"copy_id"`
+	Status     string     `json:"status" db:"status"`
+	ReadyUntil *time.Time `json:"ready_until" db:"ready_until"`
+	CreatedAt  time.Time  `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// IsActive returns whether hold is still queued or waiting for pickup
+func (h *Hold) IsActive() bool {
+	return h.Status == HoldStatusWaiting || h.Status == HoldStatusReady
+}
@@ -8,6 +8,9 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// LibrarianRole is the role of librarian principals, librarian tokens carry no role claim
+const LibrarianRole = "librarian"
+
 // Librarian model
 type Librarian struct {
 	LibrarianID uuid.UUID `json:"librarian_id" db:"librarian_id"`
@@ -10,8 +10,14 @@ import (
 )
 
 const (
-	OrderStatusPending  = "pending"
-	OrderStatusAccepted = "accepted"
+	OrderStatusPending   = "pending"
+	OrderStatusAccepted  = "accepted"
+	OrderStatusRejected  = "rejected"
+	OrderStatusCancelled = "cancelled"
+	OrderStatusPickedUp  = "picked_up"
+	OrderStatusReturned  = "returned"
+	OrderStatusOverdue   = "overdue"
+	OrderStatusLost      = "lost"
 )
 
 // Order model
@@ -19,13 +25,45 @@ type Order struct {
 	OrderID        uuid.UUID  `json:"order_id" db:"order_id"`
 	UserID         uuid.UUID  `json:"user_id" db:"user_id"`
 	LibrarianID    *uuid.UUID `json:"librarian_id" db:"librarian_id"`
+	CopyID         *uuid.UUID `json:"copy_id" db:"copy_id"`
 	Item           OrderItem  `json:"item" db:"item"`
 	Status         string     `json:"status" db:"status"`
 	PickupSchedule time.Time  `json:"pickup_schedule,omitempty" db:"pickup_schedule"`
+	PickedUpAt     *time.Time `json:"picked_up_at" db:"picked_up_at"`
+	DueAt          *time.Time `json:"due_at" db:"due_at"`
+	ReturnedAt     *time.Time `json:"returned_at" db:"returned_at"`
+	RenewalCount   int        `json:"renewal_count" db:"renewal_count"`
+	RejectReason   *string    `json:"reject_reason" db:"reject_reason"`
 	CreatedAt      time.Time  `json:"created_at,omitempty" db:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at,omitempty" db:"updated_at"`
 }
 
+const (
+	OrderEventCreated = "created"
+	OrderEventUpdated = "updated"
+	OrderEventRenewed = "renewed"
+	OrderEventDeleted = "deleted"
+)
+
+// OrderEvent is published on every order mutation, status changes use the new status as type
+type OrderEvent struct {
+	Type       string    `json:"type"`
+	Order      Order     `json:"order"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OrderFilter narrows order listings, zero fields are ignored
+type OrderFilter struct {
+	Status      string
+	BookKey     string
+	UserID      *uuid.UUID
+	LibrarianID *uuid.UUID
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	PickupFrom  *time.Time
+	PickupTo    *time.Time
+}
+
 type OrderItem Book
 
 func (o *OrderItem) Scan(value interface{}) error {
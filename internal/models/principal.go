@@ -0,0 +1,66 @@
+package models
+
+import (
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+)
+
+const (
+	PrincipalKindUser      = "user"
+	PrincipalKindLibrarian = "librarian"
+)
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	ID        uuid.UUID
+	Kind      string
+	Role      string
+	Email     string
+	SessionID string
+}
+
+// NewPrincipalFromClaims reads the principal from access token claims, librarian tokens carry librarian_id and no role claim
+func NewPrincipalFromClaims(claims jwt.MapClaims) (*Principal, error) {
+	sessionID, _ := claims["session_id"].(string)
+	if sessionID == "" {
+		return nil, errors.Wrap(grpc_errors.ErrInvalidToken, "session_id claim")
+	}
+
+	principal := &Principal{Kind: PrincipalKindUser, SessionID: sessionID}
+	principal.Email, _ = claims["email"].(string)
+	principal.Role, _ = claims["role"].(string)
+
+	idClaim := "user_id"
+	if _, ok := claims["librarian_id"]; ok || principal.Role == "" {
+		principal.Kind = PrincipalKindLibrarian
+		principal.Role = LibrarianRole
+		idClaim = "librarian_id"
+	}
+
+	id, _ := claims[idClaim].(string)
+	principalUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.Wrapf(grpc_errors.ErrInvalidToken, "%s claim", idClaim)
+	}
+	principal.ID = principalUUID
+
+	return principal, nil
+}
+
+// IsLibrarian reports whether the principal signed in as librarian
+func (p *Principal) IsLibrarian() bool {
+	return p.Kind == PrincipalKindLibrarian
+}
+
+// HasRole reports whether the principal has one of roles
+func (p *Principal) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if p.Role == role {
+			return true
+		}
+	}
+	return false
+}
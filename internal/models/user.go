@@ -16,15 +16,16 @@ const (
 
 // User model
 type User struct {
-	UserID    uuid.UUID `json:"user_id" db:"user_id" validate:"omitempty"`
-	Email     string    `json:"email" db:"email" validate:"omitempty,lte=60,email"`
-	FirstName string    `json:"first_name" db:"first_name" validate:"required,lte=30"`
-	LastName  string    `json:"last_name" db:"last_name" validate:"required,lte=30"`
-	Role      string    `json:"role" db:"role" validate:"required"`
-	Avatar    *string   `json:"avatar" db:"avatar"`
-	Password  string    `json:"-" db:"password"`
-	CreatedAt time.Time `json:"created_at,omitempty" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at,omitempty" db:"updated_at"`
+	UserID          uuid.UUID  `json:"user_id" db:"user_id" validate:"omitempty"`
+	Email           string     `json:"email" db:"email" validate:"omitempty,lte=60,email"`
+	FirstName       string     `json:"first_name" db:"first_name" validate:"required,lte=30"`
+	LastName        string     `json:"last_name" db:"last_name" validate:"required,lte=30"`
+	Role            string     `json:"role" db:"role" validate:"required"`
+	Avatar          *string    `json:"avatar" db:"avatar"`
+	Password        string     `json:"-" db:"password"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at" db:"email_verified_at"`
+	CreatedAt       time.Time  `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at,omitempty" db:"updated_at"`
 }
 
 func (u *User) SanitizePassword() {
@@ -62,6 +63,11 @@ func (u *User) PrepareCreate() error {
 	return nil
 }
 
+// IsEmailVerified whether the user confirmed the email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
 // Get avatar string
 func (u *User) GetAvatar() string {
 	if u.Avatar == nil {
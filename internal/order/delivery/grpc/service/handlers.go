@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dinorain/pinjembuku/internal/interceptors"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+	orderService "github.com/dinorain/pinjembuku/proto/order"
+)
+
+// Create new pending order of a book on behalf of a user
+func (o *ordersServiceGRPC) Create(ctx context.Context, r *orderService.CreateRequest) (*orderService.CreateResponse, error) {
+	principal, err := o.principalFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userUUID := principal.ID
+	if principal.Role != models.UserRoleUser || r.GetUserId() != "" {
+		if userUUID, err = utils.ParseUUID(r.GetUserId()); err != nil {
+			o.logger.Errorf("utils.ParseUUID: %v", err)
+			return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "utils.ParseUUID: %v", err)
+		}
+	}
+
+	if principal.Role == models.UserRoleUser && userUUID != principal.ID {
+		return nil, status.Errorf(codes.PermissionDenied, "user_id: %v", grpc_errors.ErrPermissionDenied)
+	}
+
+	if r.GetKey() == "" || r.GetPickupSchedule() == nil {
+		o.logger.Errorf("key and pickup_schedule required")
+		return nil, status.Errorf(codes.InvalidArgument, "key and pickup_schedule required")
+	}
+
+	user, err := o.userUC.CachedFindById(ctx, userUUID)
+	if err != nil {
+		o.logger.Errorf("userUC.CachedFindById: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "userUC.CachedFindById: %v", err)
+	}
+
+	if !user.IsEmailVerified() {
+		o.logger.Warnf("user %s email not verified", user.UserID)
+		return nil, status.Errorf(codes.PermissionDenied, "user: %v", grpc_errors.ErrEmailNotVerified)
+	}
+
+	book, err := o.bookUC.CachedFindByWork(ctx, r.GetKey())
+	if err != nil {
+		o.logger.Errorf("bookUC.CachedFindByWork: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "bookUC.CachedFindByWork: %v", err)
+	}
+
+	createdOrder, err := o.orderUC.Create(ctx, o.createReqToOrderModel(r, user, book))
+	if err != nil {
+		o.logger.Errorf("orderUC.Create: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "orderUC.Create: %v", err)
+	}
+
+	return &orderService.CreateResponse{Order: o.orderModelToProto(createdOrder)}, nil
+}
+
+// FindById find order by uuid
+func (o *ordersServiceGRPC) FindById(ctx context.Context, r *orderService.FindByIdRequest) (*orderService.FindByIdResponse, error) {
+	orderUUID, err := utils.ParseUUID(r.GetUuid())
+	if err != nil {
+		o.logger.Errorf("utils.ParseUUID: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "utils.ParseUUID: %v", err)
+	}
+
+	principal, err := o.principalFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := o.orderUC.CachedFindByIdForActor(ctx, orderUUID, principal.ID, principal.Role)
+	if err != nil {
+		o.logger.Errorf("orderUC.CachedFindByIdForActor: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "orderUC.CachedFindByIdForActor: %v", err)
+	}
+
+	return &orderService.FindByIdResponse{Order: o.orderModelToProto(order)}, nil
+}
+
+// FindAll find orders matching filter page by page
+func (o *ordersServiceGRPC) FindAll(ctx context.Context, r *orderService.FindAllRequest) (*orderService.FindAllResponse, error) {
+	principal, err := o.principalFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := o.findAllReqToOrderFilter(r)
+	if err != nil {
+		o.logger.Errorf("utils.ParseUUID: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "utils.ParseUUID: %v", err)
+	}
+
+	switch principal.Role {
+	case models.UserRoleUser:
+		filter.UserID = &principal.ID
+	case models.UserRoleAdmin:
+	default:
+		if filter.UserID != nil {
+			return nil, status.Errorf(codes.PermissionDenied, "user_id: %v", grpc_errors.ErrPermissionDenied)
+		}
+	}
+
+	pq := utils.NewPaginationFromRequest(r.GetSize(), r.GetPage())
+	pq.SetOrderBy(r.GetOrderBy())
+
+	orders, err := o.orderUC.FindAll(ctx, filter, pq)
+	if err != nil {
+		o.logger.Errorf("orderUC.FindAll: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "orderUC.FindAll: %v", err)
+	}
+
+	totalCount, err := o.orderUC.CountAll(ctx, filter)
+	if err != nil {
+		o.logger.Errorf("orderUC.CountAll: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "orderUC.CountAll: %v", err)
+	}
+
+	ordersProto := make([]*orderService.Order, 0, len(orders))
+	for i := range orders {
+		ordersProto = append(ordersProto, o.orderModelToProto(&orders[i]))
+	}
+
+	return &orderService.FindAllResponse{Orders: ordersProto, TotalCount: int64(totalCount)}, nil
+}
+
+// WatchOrders stream events of orders the caller may view until the client goes away
+func (o *ordersServiceGRPC) WatchOrders(r *orderService.WatchOrdersRequest, stream orderService.OrderService_WatchOrdersServer) error {
+	ctx := stream.Context()
+
+	principal, err := o.principalFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	types := make(map[string]bool, len(r.GetTypes()))
+	for _, eventType := range r.GetTypes() {
+		types[eventType] = true
+	}
+
+	for event := range o.orderUC.Subscribe(ctx, principal.ID, principal.Role) {
+		if len(types) > 0 && !types[event.Type] {
+			continue
+		}
+
+		if err := stream.Send(&orderService.OrderEvent{
+			Type:       event.Type,
+			Order:      o.orderModelToProto(&event.Order),
+			OccurredAt: timestamppb.New(event.OccurredAt),
+		}); err != nil {
+			o.logger.Errorf("stream.Send: %v", err)
+			return status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "stream.Send: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (o *ordersServiceGRPC) principalFromCtx(ctx context.Context) (*models.Principal, error) {
+	principal, ok := interceptors.PrincipalFromCtx(ctx)
+	if !ok {
+		o.logger.Errorf("PrincipalFromCtx: no principal")
+		return nil, status.Errorf(codes.Unauthenticated, "PrincipalFromCtx: %v", grpc_errors.ErrInvalidSessionId)
+	}
+	return principal, nil
+}
+
+func (o *ordersServiceGRPC) createReqToOrderModel(r *orderService.CreateRequest, user *models.User, book *models.Book) *models.Order {
+	return &models.Order{
+		UserID: user.UserID,
+		Item: models.OrderItem{
+			BookKey:         book.BookKey,
+			Title:           book.Title,
+			EditionCount:    book.EditionCount,
+			CoverID:         book.CoverID,
+			CoverEditionKey: book.CoverEditionKey,
+		},
+		Status:         models.OrderStatusPending,
+		PickupSchedule: r.GetPickupSchedule().AsTime(),
+	}
+}
+
+func (o *ordersServiceGRPC) findAllReqToOrderFilter(r *orderService.FindAllRequest) (*models.OrderFilter, error) {
+	filter := &models.OrderFilter{
+		Status:      r.GetStatus(),
+		BookKey:     r.GetKey(),
+		CreatedFrom: timestampToTime(r.GetCreatedFrom()),
+		CreatedTo:   timestampToTime(r.GetCreatedTo()),
+		PickupFrom:  timestampToTime(r.GetPickupFrom()),
+		PickupTo:    timestampToTime(r.GetPickupTo()),
+	}
+
+	if r.GetUserId() != "" {
+		userUUID, err := utils.ParseUUID(r.GetUserId())
+		if err != nil {
+			return nil, err
+		}
+		filter.UserID = &userUUID
+	}
+
+	if r.GetLibrarianId() != "" {
+		librarianUUID, err := utils.ParseUUID(r.GetLibrarianId())
+		if err != nil {
+			return nil, err
+		}
+		filter.LibrarianID = &librarianUUID
+	}
+
+	return filter, nil
+}
+
+func (o *ordersServiceGRPC) orderModelToProto(order *models.Order) *orderService.Order {
+	orderProto := &orderService.Order{
+		Uuid:   order.OrderID.String(),
+		UserId: order.UserID.String(),
+		Item: &orderService.OrderItem{
+			Key:             order.Item.BookKey,
+			Title:           order.Item.Title,
+			EditionCount:    int64(order.Item.EditionCount),
+			CoverId:         order.Item.CoverID,
+			CoverEditionKey: order.Item.CoverEditionKey,
+		},
+		Status:         order.Status,
+		PickupSchedule: timestamppb.New(order.PickupSchedule),
+		PickedUpAt:     timeToTimestamp(order.PickedUpAt),
+		DueAt:          timeToTimestamp(order.DueAt),
+		ReturnedAt:     timeToTimestamp(order.ReturnedAt),
+		RenewalCount:   int64(order.RenewalCount),
+		CreatedAt:      timestamppb.New(order.CreatedAt),
+		UpdatedAt:      timestamppb.New(order.UpdatedAt),
+	}
+	if order.LibrarianID != nil {
+		orderProto.LibrarianId = order.LibrarianID.String()
+	}
+	if order.CopyID != nil {
+		orderProto.CopyId = order.CopyID.String()
+	}
+	if order.RejectReason != nil {
+		orderProto.RejectReason = *order.RejectReason
+	}
+	return orderProto
+}
+
+func timeToTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func timestampToTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
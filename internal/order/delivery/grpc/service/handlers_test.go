@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dinorain/pinjembuku/config"
+	mockBookUC "github.com/dinorain/pinjembuku/internal/book/mock"
+	"github.com/dinorain/pinjembuku/internal/interceptors"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/internal/order/mock"
+	mockUserUC "github.com/dinorain/pinjembuku/internal/user/mock"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/grpc_test_utils"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+	orderService "github.com/dinorain/pinjembuku/proto/order"
+)
+
+type orderServiceDeps struct {
+	orderUC *mock.MockOrderUseCase
+	bookUC  *mockBookUC.MockBookUseCase
+	userUC  *mockUserUC.MockUserUseCase
+}
+
+type principalServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func newOrderServiceClient(t *testing.T, ctrl *gomock.Controller, principal *models.Principal) (orderService.OrderServiceClient, *orderServiceDeps) {
+	deps := &orderServiceDeps{
+		orderUC: mock.NewMockOrderUseCase(ctrl),
+		bookUC:  mockBookUC.NewMockBookUseCase(ctrl),
+		userUC:  mockUserUC.NewMockUserUseCase(ctrl),
+	}
+
+	cfg := &config.Config{}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+
+	conn := grpc_test_utils.NewBufconnClientConn(t, func(grpcServer *grpc.Server) {
+		orderService.RegisterOrderServiceServer(grpcServer, NewOrderServerGRPC(appLogger, cfg, deps.orderUC, deps.bookUC, deps.userUC))
+	},
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return handler(interceptors.ContextWithPrincipal(ctx, principal), req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, &principalServerStream{ServerStream: ss, ctx: interceptors.ContextWithPrincipal(ss.Context(), principal)})
+		}),
+	)
+
+	return orderService.NewOrderServiceClient(conn), deps
+}
+
+func TestOrdersService_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userUUID := uuid.New()
+	orderUUID := uuid.New()
+	client, deps := newOrderServiceClient(t, ctrl, &models.Principal{ID: userUUID, Role: models.UserRoleUser})
+
+	pickupSchedule := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	verifiedAt := time.Now()
+
+	deps.userUC.EXPECT().CachedFindById(gomock.Any(), userUUID).Return(&models.User{UserID: userUUID, EmailVerifiedAt: &verifiedAt}, nil)
+	deps.bookUC.EXPECT().CachedFindByWork(gomock.Any(), "/works/OL1W").Return(&models.Book{BookKey: "/works/OL1W", Title: "Title"}, nil)
+	deps.orderUC.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, order *models.Order) (*models.Order, error) {
+		require.Equal(t, userUUID, order.UserID)
+		require.Equal(t, "/works/OL1W", order.Item.BookKey)
+		require.Equal(t, models.OrderStatusPending, order.Status)
+		require.True(t, pickupSchedule.Equal(order.PickupSchedule))
+		order.OrderID = orderUUID
+		return order, nil
+	})
+
+	res, err := client.Create(context.Background(), &orderService.CreateRequest{
+		Key:            "/works/OL1W",
+		PickupSchedule: timestamppb.New(pickupSchedule),
+	})
+	require.NoError(t, err)
+	require.Equal(t, orderUUID.String(), res.GetOrder().GetUuid())
+	require.Equal(t, "Title", res.GetOrder().GetItem().GetTitle())
+	require.Nil(t, res.GetOrder().GetDueAt())
+
+	_, err = client.Create(context.Background(), &orderService.CreateRequest{UserId: userUUID.String(), Key: "/works/OL1W"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Create(context.Background(), &orderService.CreateRequest{
+		UserId:         uuid.New().String(),
+		Key:            "/works/OL1W",
+		PickupSchedule: timestamppb.New(pickupSchedule),
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	deps.userUC.EXPECT().CachedFindById(gomock.Any(), userUUID).Return(&models.User{UserID: userUUID, EmailVerifiedAt: &verifiedAt}, nil)
+	deps.bookUC.EXPECT().CachedFindByWork(gomock.Any(), "/works/OL1W").Return(&models.Book{BookKey: "/works/OL1W"}, nil)
+	deps.orderUC.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, grpc_errors.ErrNoCopyAvailable)
+
+	_, err = client.Create(context.Background(), &orderService.CreateRequest{
+		UserId:         userUUID.String(),
+		Key:            "/works/OL1W",
+		PickupSchedule: timestamppb.New(pickupSchedule),
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	deps.userUC.EXPECT().CachedFindById(gomock.Any(), userUUID).Return(&models.User{UserID: userUUID}, nil)
+
+	_, err = client.Create(context.Background(), &orderService.CreateRequest{
+		Key:            "/works/OL1W",
+		PickupSchedule: timestamppb.New(pickupSchedule),
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestOrdersService_FindById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderUUID := uuid.New()
+	librarianUUID := uuid.New()
+	client, deps := newOrderServiceClient(t, ctrl, &models.Principal{ID: librarianUUID, Role: models.LibrarianRole})
+
+	reason := "damaged"
+	deps.orderUC.EXPECT().CachedFindByIdForActor(gomock.Any(), orderUUID, librarianUUID, models.LibrarianRole).Return(&models.Order{
+		OrderID:      orderUUID,
+		LibrarianID:  &librarianUUID,
+		Status:       models.OrderStatusRejected,
+		RejectReason: &reason,
+	}, nil)
+
+	res, err := client.FindById(context.Background(), &orderService.FindByIdRequest{Uuid: orderUUID.String()})
+	require.NoError(t, err)
+	require.Equal(t, librarianUUID.String(), res.GetOrder().GetLibrarianId())
+	require.Equal(t, reason, res.GetOrder().GetRejectReason())
+	require.Empty(t, res.GetOrder().GetCopyId())
+
+	missingUUID := uuid.New()
+	deps.orderUC.EXPECT().CachedFindByIdForActor(gomock.Any(), missingUUID, librarianUUID, models.LibrarianRole).Return(nil, grpc_errors.ErrNotFound)
+
+	_, err = client.FindById(context.Background(), &orderService.FindByIdRequest{Uuid: missingUUID.String()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestOrdersService_FindAll(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client, deps := newOrderServiceClient(t, ctrl, &models.Principal{ID: uuid.New(), Role: models.UserRoleAdmin})
+
+	userUUID := uuid.New()
+	createdFrom := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+
+	deps.orderUC.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, filter *models.OrderFilter, pq *utils.Pagination) ([]models.Order, error) {
+		require.Equal(t, models.OrderStatusPending, filter.Status)
+		require.Equal(t, userUUID, *filter.UserID)
+		require.Nil(t, filter.LibrarianID)
+		require.True(t, createdFrom.Equal(*filter.CreatedFrom))
+		require.Nil(t, filter.CreatedTo)
+		require.Equal(t, "due_at:asc", pq.GetOrderBy())
+		require.Equal(t, 5, pq.GetSize())
+		return []models.Order{{OrderID: uuid.New(), UserID: userUUID}}, nil
+	})
+	deps.orderUC.EXPECT().CountAll(gomock.Any(), gomock.Any()).Return(1, nil)
+
+	res, err := client.FindAll(context.Background(), &orderService.FindAllRequest{
+		Size:        5,
+		OrderBy:     "due_at:asc",
+		Status:      models.OrderStatusPending,
+		UserId:      userUUID.String(),
+		CreatedFrom: timestamppb.New(createdFrom),
+	})
+	require.NoError(t, err)
+	require.Len(t, res.GetOrders(), 1)
+	require.Equal(t, int64(1), res.GetTotalCount())
+
+	_, err = client.FindAll(context.Background(), &orderService.FindAllRequest{LibrarianId: "invalid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	deps.orderUC.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, grpc_errors.ErrInvalidOrderBy)
+
+	_, err = client.FindAll(context.Background(), &orderService.FindAllRequest{OrderBy: "password"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	librarianClient, _ := newOrderServiceClient(t, ctrl, &models.Principal{ID: uuid.New(), Role: models.LibrarianRole})
+
+	_, err = librarianClient.FindAll(context.Background(), &orderService.FindAllRequest{UserId: userUUID.String()})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestOrdersService_FindAllForcesUserFilter(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userUUID := uuid.New()
+	client, deps := newOrderServiceClient(t, ctrl, &models.Principal{ID: userUUID, Role: models.UserRoleUser})
+
+	deps.orderUC.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, filter *models.OrderFilter, _ *utils.Pagination) ([]models.Order, error) {
+		require.Equal(t, userUUID, *filter.UserID)
+		return nil, nil
+	})
+	deps.orderUC.EXPECT().CountAll(gomock.Any(), gomock.Any()).Return(0, nil)
+
+	res, err := client.FindAll(context.Background(), &orderService.FindAllRequest{UserId: uuid.New().String()})
+	require.NoError(t, err)
+	require.Empty(t, res.GetOrders())
+}
+
+func TestOrdersService_WatchOrders(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	librarianUUID := uuid.New()
+	client, deps := newOrderServiceClient(t, ctrl, &models.Principal{ID: librarianUUID, Role: models.LibrarianRole})
+
+	events := make(chan models.OrderEvent, 3)
+	subscribed := make(chan context.Context, 1)
+	deps.orderUC.EXPECT().Subscribe(gomock.Any(), librarianUUID, models.LibrarianRole).DoAndReturn(func(ctx context.Context, _ uuid.UUID, _ string) <-chan models.OrderEvent {
+		subscribed <- ctx
+		return events
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.WatchOrders(ctx, &orderService.WatchOrdersRequest{Types: []string{models.OrderEventCreated, models.OrderStatusReturned}})
+	require.NoError(t, err)
+
+	subscriberCtx := <-subscribed
+
+	createdOrderUUID := uuid.New()
+	returnedOrderUUID := uuid.New()
+	events <- models.OrderEvent{Type: models.OrderEventCreated, Order: models.Order{OrderID: createdOrderUUID}, OccurredAt: time.Now()}
+	events <- models.OrderEvent{Type: models.OrderStatusAccepted, Order: models.Order{OrderID: uuid.New()}, OccurredAt: time.Now()}
+	events <- models.OrderEvent{Type: models.OrderStatusReturned, Order: models.Order{OrderID: returnedOrderUUID}, OccurredAt: time.Now()}
+
+	event, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, models.OrderEventCreated, event.GetType())
+	require.Equal(t, createdOrderUUID.String(), event.GetOrder().GetUuid())
+
+	event, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, models.OrderStatusReturned, event.GetType())
+	require.Equal(t, returnedOrderUUID.String(), event.GetOrder().GetUuid())
+
+	cancel()
+	<-subscriberCtx.Done()
+	close(events)
+
+	_, err = stream.Recv()
+	require.Equal(t, codes.Canceled, status.Code(err))
+}
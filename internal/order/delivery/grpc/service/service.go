@@ -0,0 +1,22 @@
+package service
+
+import (
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/book"
+	"github.com/dinorain/pinjembuku/internal/order"
+	"github.com/dinorain/pinjembuku/internal/user"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+)
+
+type ordersServiceGRPC struct {
+	logger  logger.Logger
+	cfg     *config.Config
+	orderUC order.OrderUseCase
+	bookUC  book.BookUseCase
+	userUC  user.UserUseCase
+}
+
+// Order service constructor
+func NewOrderServerGRPC(logger logger.Logger, cfg *config.Config, orderUC order.OrderUseCase, bookUC book.BookUseCase, userUC user.UserUseCase) *ordersServiceGRPC {
+	return &ordersServiceGRPC{logger: logger, cfg: cfg, orderUC: orderUC, bookUC: bookUC, userUC: userUC}
+}
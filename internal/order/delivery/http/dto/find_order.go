@@ -1,8 +1,39 @@
 package dto
 
-import "github.com/dinorain/pinjembuku/pkg/utils"
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+type OrderFindRequestDto struct {
+	Status      string     `query:"status" validate:"omitempty,oneof=pending accepted rejected cancelled picked_up returned overdue lost"`
+	BookKey     string     `query:"book_key"`
+	UserID      *uuid.UUID `query:"user_id"`
+	LibrarianID *uuid.UUID `query:"librarian_id"`
+	CreatedFrom *time.Time `query:"created_from"`
+	CreatedTo   *time.Time `query:"created_to"`
+	PickupFrom  *time.Time `query:"pickup_from"`
+	PickupTo    *time.Time `query:"pickup_to"`
+}
 
 type OrderFindResponseDto struct {
 	Meta utils.PaginationMetaDto `json:"meta"`
 	Data interface{}             `json:"data"`
 }
+
+func OrderFilterFromRequest(req *OrderFindRequestDto) *models.OrderFilter {
+	return &models.OrderFilter{
+		Status:      req.Status,
+		BookKey:     req.BookKey,
+		UserID:      req.UserID,
+		LibrarianID: req.LibrarianID,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+		PickupFrom:  req.PickupFrom,
+		PickupTo:    req.PickupTo,
+	}
+}
@@ -12,9 +12,15 @@ type OrderResponseDto struct {
 	OrderID        uuid.UUID        `json:"order_id"`
 	UserID         uuid.UUID        `json:"user_id"`
 	LibrarianID    *uuid.UUID       `json:"librarian_id"`
+	CopyID         *uuid.UUID       `json:"copy_id"`
 	Item           models.OrderItem `json:"item"`
 	Status         string           `json:"status" db:"status"`
 	PickupSchedule time.Time        `json:"pickup_schedule,omitempty"`
+	PickedUpAt     *time.Time       `json:"picked_up_at"`
+	DueAt          *time.Time       `json:"due_at"`
+	ReturnedAt     *time.Time       `json:"returned_at"`
+	RenewalCount   int              `json:"renewal_count"`
+	RejectReason   *string          `json:"reject_reason"`
 	CreatedAt      time.Time        `json:"created_at,omitempty"`
 	UpdatedAt      time.Time        `json:"updated_at,omitempty"`
 }
@@ -24,9 +30,15 @@ func OrderResponseFromModel(order *models.Order) *OrderResponseDto {
 		OrderID:        order.OrderID,
 		UserID:         order.UserID,
 		LibrarianID:    order.LibrarianID,
+		CopyID:         order.CopyID,
 		Item:           order.Item,
 		Status:         order.Status,
 		PickupSchedule: order.PickupSchedule,
+		PickedUpAt:     order.PickedUpAt,
+		DueAt:          order.DueAt,
+		ReturnedAt:     order.ReturnedAt,
+		RenewalCount:   order.RenewalCount,
+		RejectReason:   order.RejectReason,
 		CreatedAt:      order.CreatedAt,
 		UpdatedAt:      order.UpdatedAt,
 	}
@@ -0,0 +1,5 @@
+package dto
+
+type OrderRejectRequestDto struct {
+	Reason string `json:"reason" validate:"required,lte=250"`
+}
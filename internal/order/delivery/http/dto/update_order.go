@@ -1,5 +0,0 @@
-package dto
-
-type OrderUpdateRequestDto struct {
-	Status string `json:"status"`
-}
@@ -1,13 +1,9 @@
 package handlers
 
 import (
-	"errors"
-	"fmt"
 	"net/http"
 
 	"github.com/go-playground/validator"
-	"github.com/go-redis/redis/v8"
-	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
@@ -21,6 +17,7 @@ import (
 	"github.com/dinorain/pinjembuku/internal/session"
 	"github.com/dinorain/pinjembuku/internal/user"
 	"github.com/dinorain/pinjembuku/pkg/constants"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
 	httpErrors "github.com/dinorain/pinjembuku/pkg/http_errors"
 	"github.com/dinorain/pinjembuku/pkg/logger"
 	"github.com/dinorain/pinjembuku/pkg/utils"
@@ -81,30 +78,26 @@ func (h *orderHandlersHTTP) Create() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		sessID, _, _, err := h.getSessionIDFromCtx(c)
+		principal, err := middlewares.PrincipalFromCtx(c)
 		if err != nil {
-			h.logger.Errorf("getSessionIDFromCtx: %v", err)
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		session, err := h.sessUC.GetSessionById(ctx, sessID)
+		user, err := h.userUC.CachedFindById(ctx, principal.ID)
 		if err != nil {
-			h.logger.Errorf("sessUC.GetSessionById: %v", err)
-			if errors.Is(err, redis.Nil) {
-				return httpErrors.NewUnauthorizedError(c, nil, h.cfg.Http.DebugErrorsResponse)
-			}
+			h.logger.Errorf("userUC.CachedFindById: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		user, err := h.userUC.CachedFindById(ctx, session.UserID)
-		if err != nil {
-			h.logger.Errorf("userUC.CachedFindById: %v", err)
-			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		if !user.IsEmailVerified() {
+			h.logger.Warnf("user %s email not verified", user.UserID)
+			return httpErrors.ErrorCtxResponse(c, grpc_errors.ErrEmailNotVerified, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		book, err := h.bookUC.FindByWork(ctx, createDto.BookKey)
+		book, err := h.bookUC.CachedFindByWork(ctx, createDto.BookKey)
 		if err != nil {
-			h.logger.Errorf("bookUC.FindByWork: %v", err)
+			h.logger.Errorf("bookUC.CachedFindByWork: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
@@ -127,49 +120,122 @@ func (h *orderHandlersHTTP) Create() echo.HandlerFunc {
 // FindAll
 // @Tags Orders
 // @Summary Find all orders
-// @Description Find all orders
+// @Description Find all orders, users only ever see their own
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param size query string false "pagination size"
 // @Param page query string false "pagination page"
+// @Param orderBy query string false "created_at, updated_at, pickup_schedule, due_at or status, optionally suffixed with :asc or :desc, defaults to created_at:desc,id:desc"
+// @Param cursor query string false "next_cursor of the previous page, takes precedence over page, not combinable with orderBy"
+// @Param status query string false "order status"
+// @Param book_key query string false "book key"
+// @Param user_id query string false "user id, admin only"
+// @Param librarian_id query string false "librarian id"
+// @Param created_from query string false "created at or after, RFC3339"
+// @Param created_to query string false "created before, RFC3339"
+// @Param pickup_from query string false "pickup scheduled at or after, RFC3339"
+// @Param pickup_to query string false "pickup scheduled before, RFC3339"
 // @Success 200 {object} dto.OrderFindResponseDto
 // @Router /order [get]
 func (h *orderHandlersHTTP) FindAll() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
 		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
+		pq.SetOrderBy(c.QueryParam(constants.OrderBy))
+		if err := pq.SetCursor(c.QueryParam(constants.Cursor)); err != nil {
+			h.logger.WarnMsg("pq.SetCursor", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
 
-		var orders []models.Order
-		_, userID, role, err := h.getSessionIDFromCtx(c)
+		findDto := &dto.OrderFindRequestDto{}
+		if err := (&echo.DefaultBinder{}).BindQueryParams(c, findDto); err != nil {
+			h.logger.WarnMsg("bind", err)
+			return httpErrors.NewBadRequestError(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.v.StructCtx(ctx, findDto); err != nil {
+			h.logger.WarnMsg("validate", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		principal, err := middlewares.PrincipalFromCtx(c)
 		if err != nil {
-			h.logger.Errorf("getSessionIDFromCtx: %v", err)
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
-		if role == models.UserRoleUser {
-			userUUID, _ := uuid.Parse(userID)
-			if res, err := h.orderUC.FindAllByUserId(ctx, userUUID, pq); err != nil {
-				h.logger.Errorf("orderUC.FindAllByUserId: %v", err)
-				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
-			} else {
-				orders = res
+
+		filter := dto.OrderFilterFromRequest(findDto)
+		switch principal.Role {
+		case models.UserRoleUser:
+			filter.UserID = &principal.ID
+		case models.UserRoleAdmin:
+		default:
+			if filter.UserID != nil {
+				return httpErrors.NewForbiddenError(c, nil, h.cfg.Http.DebugErrorsResponse)
 			}
-		} else {
-			if res, err := h.orderUC.FindAll(ctx, pq); err != nil {
-				h.logger.Errorf("orderUC.FindAll: %v", err)
-				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
-			} else {
-				orders = res
+		}
+
+		orders, err := h.orderUC.FindAll(ctx, filter, pq)
+		if err != nil {
+			h.logger.Errorf("orderUC.FindAll: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		totalCount, err := h.orderUC.CountAll(ctx, filter)
+		if err != nil {
+			h.logger.Errorf("orderUC.CountAll: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		meta := utils.NewPaginationMetaDto(pq, totalCount)
+		meta.Sort = utils.OrderBySort(pq.GetOrderBy())
+		if pq.GetOrderBy() == "" {
+			var last *utils.Cursor
+			if n := len(orders); n > 0 {
+				last = utils.NewCursor(orders[n-1].CreatedAt, orders[n-1].OrderID)
 			}
+			meta = utils.NewKeysetPaginationMetaDto(pq, totalCount, len(orders), last)
 		}
 
 		return c.JSON(http.StatusOK, dto.OrderFindResponseDto{
 			Data: orders,
-			Meta: utils.PaginationMetaDto{
-				Limit:  pq.GetLimit(),
-				Offset: pq.GetOffset(),
-				Page:   pq.GetPage(),
-			},
+			Meta: meta,
+		})
+	}
+}
+
+// FindAllOverdue
+// @Tags Orders
+// @Summary Find overdue orders
+// @Description Librarian find picked up orders past their due date, oldest due date first
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param size query string false "pagination size"
+// @Param page query string false "pagination page"
+// @Success 200 {object} dto.OrderFindResponseDto
+// @Router /order/overdue [get]
+func (h *orderHandlersHTTP) FindAllOverdue() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
+
+		orders, err := h.orderUC.FindAllOverdue(ctx, pq)
+		if err != nil {
+			h.logger.Errorf("orderUC.FindAllOverdue: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		totalCount, err := h.orderUC.CountAllOverdue(ctx)
+		if err != nil {
+			h.logger.Errorf("orderUC.CountAllOverdue: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, dto.OrderFindResponseDto{
+			Data: orders,
+			Meta: utils.NewPaginationMetaDto(pq, totalCount),
 		})
 	}
 }
@@ -177,10 +243,11 @@ func (h *orderHandlersHTTP) FindAll() echo.HandlerFunc {
 // FindById
 // @Tags Orders
 // @Summary Find order
-// @Description Find existing order by id
+// @Description Find existing order by id visible to the caller
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
+// @Param id path string true "Order ID"
 // @Success 200 {object} dto.OrderResponseDto
 // @Router /order/{id} [get]
 func (h *orderHandlersHTTP) FindById() echo.HandlerFunc {
@@ -193,9 +260,15 @@ func (h *orderHandlersHTTP) FindById() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		order, err := h.orderUC.CachedFindById(ctx, orderUUID)
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		order, err := h.orderUC.CachedFindByIdForActor(ctx, orderUUID, principal.ID, principal.Role)
 		if err != nil {
-			h.logger.Errorf("orderUC.CachedFindById: %v", err)
+			h.logger.Errorf("orderUC.CachedFindByIdForActor: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
@@ -206,13 +279,13 @@ func (h *orderHandlersHTTP) FindById() echo.HandlerFunc {
 // AcceptById
 // @Tags Orders
 // @Summary Accept order
-// @Description Librarian accept order
+// @Description Librarian accept pending order, reserving an available copy of the book
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path string true "Order ID"
 // @Success 200 {object} dto.OrderResponseDto
-// @Router /order/{id} [put]
+// @Router /order/{id}/accept [post]
 func (h *orderHandlersHTTP) AcceptById() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
@@ -223,41 +296,99 @@ func (h *orderHandlersHTTP) AcceptById() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		updateDto := &dto.OrderUpdateRequestDto{
-			Status: models.OrderStatusAccepted,
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		order, err := h.orderUC.AcceptById(ctx, orderUUID, principal.ID)
+		if err != nil {
+			h.logger.Errorf("orderUC.AcceptById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		order, err := h.orderUC.FindById(ctx, orderUUID)
+		return c.JSON(http.StatusOK, dto.OrderResponseFromModel(order))
+	}
+}
+
+// RejectById
+// @Tags Orders
+// @Summary Reject order
+// @Description Librarian reject pending order with a reason
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Order ID"
+// @Param payload body dto.OrderRejectRequestDto true "Payload"
+// @Success 200 {object} dto.OrderResponseDto
+// @Router /order/{id}/reject [post]
+func (h *orderHandlersHTTP) RejectById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		orderUUID, err := uuid.Parse(c.Param("id"))
 		if err != nil {
-			h.logger.Errorf("orderUC.FindById: %v", err)
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		rejectDto := &dto.OrderRejectRequestDto{}
+		if err := c.Bind(rejectDto); err != nil {
+			h.logger.WarnMsg("bind", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		_, userID, role, err := h.getSessionIDFromCtx(c)
+		if err := h.v.StructCtx(ctx, rejectDto); err != nil {
+			h.logger.WarnMsg("validate", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		principal, err := middlewares.PrincipalFromCtx(c)
 		if err != nil {
-			h.logger.Errorf("getSessionIDFromCtx: %v", err)
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		if role != "" {
-			return httpErrors.NewForbiddenError(c, nil, h.cfg.Http.DebugErrorsResponse)
+		order, err := h.orderUC.RejectById(ctx, orderUUID, principal.ID, rejectDto.Reason)
+		if err != nil {
+			h.logger.Errorf("orderUC.RejectById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		order, err = h.updateReqToOrderModel(order, updateDto)
+		return c.JSON(http.StatusOK, dto.OrderResponseFromModel(order))
+	}
+}
+
+// CancelById
+// @Tags Orders
+// @Summary Cancel order
+// @Description User cancel own order which has not been picked up yet
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderResponseDto
+// @Router /order/{id}/cancel [post]
+func (h *orderHandlersHTTP) CancelById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		orderUUID, err := uuid.Parse(c.Param("id"))
 		if err != nil {
-			h.logger.Errorf("orderHandlersHTTP.updateReqToOrderModel: %v", err)
+			h.logger.WarnMsg("uuid.FromString", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		if librarianUUID, err := uuid.Parse(userID); err != nil {
-			h.logger.Errorf("uuid.Parse: %v", err)
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
-		} else {
-			order.LibrarianID = &librarianUUID
 		}
-		order, err = h.orderUC.UpdateById(ctx, order)
+
+		order, err := h.orderUC.CancelById(ctx, orderUUID, principal.ID, principal.Role)
 		if err != nil {
-			h.logger.Errorf("orderUC.UpdateById: %v", err)
+			h.logger.Errorf("orderUC.CancelById: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
@@ -265,32 +396,184 @@ func (h *orderHandlersHTTP) AcceptById() echo.HandlerFunc {
 	}
 }
 
-func (h *orderHandlersHTTP) getSessionIDFromCtx(c echo.Context) (sessionID string, userID string, role string, err error) {
-	user, ok := c.Get("user").(*jwt.Token)
-	if !ok {
-		h.logger.Warnf("jwt.Token: %+v", c.Get("user"))
-		return "", "", "", errors.New("invalid token header")
+// PickUpById
+// @Tags Orders
+// @Summary Pick up order
+// @Description Librarian hand accepted order over to its user
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderResponseDto
+// @Router /order/{id}/pickup [post]
+func (h *orderHandlersHTTP) PickUpById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		orderUUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		order, err := h.orderUC.PickUpById(ctx, orderUUID, principal.ID)
+		if err != nil {
+			h.logger.Errorf("orderUC.PickUpById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, dto.OrderResponseFromModel(order))
 	}
+}
+
+// ReturnById
+// @Tags Orders
+// @Summary Return order
+// @Description Librarian take order back from its user
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderResponseDto
+// @Router /order/{id}/return [post]
+func (h *orderHandlersHTTP) ReturnById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
 
-	claims, ok := user.Claims.(jwt.MapClaims)
-	if !ok {
-		h.logger.Warnf("jwt.MapClaims: %+v", c.Get("user"))
-		return "", "", "", errors.New("invalid token header")
+		orderUUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		order, err := h.orderUC.ReturnById(ctx, orderUUID, principal.ID)
+		if err != nil {
+			h.logger.Errorf("orderUC.ReturnById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, dto.OrderResponseFromModel(order))
 	}
+}
 
-	sessionID, ok = claims["session_id"].(string)
-	if !ok {
-		h.logger.Warnf("session_id: %+v", claims)
-		return "", "", "", errors.New("invalid token header")
+// RenewById
+// @Tags Orders
+// @Summary Renew order
+// @Description User extend own active loan by another loan period, denied once the renewal limit is reached, the loan is overdue beyond the grace period or the book is reserved by another user. Renewing an overdue loan fines the days it is late
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderResponseDto
+// @Router /order/{id}/renew [post]
+func (h *orderHandlersHTTP) RenewById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		orderUUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		order, err := h.orderUC.RenewById(ctx, orderUUID, principal.ID, principal.Role)
+		if err != nil {
+			h.logger.Errorf("orderUC.RenewById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, dto.OrderResponseFromModel(order))
 	}
+}
+
+// MarkOverdueById
+// @Tags Orders
+// @Summary Mark order overdue
+// @Description Librarian flag picked up order as overdue
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderResponseDto
+// @Router /order/{id}/overdue [post]
+func (h *orderHandlersHTTP) MarkOverdueById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		orderUUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		order, err := h.orderUC.MarkOverdueById(ctx, orderUUID, principal.ID)
+		if err != nil {
+			h.logger.Errorf("orderUC.MarkOverdueById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, dto.OrderResponseFromModel(order))
+	}
+}
+
+// MarkLostById
+// @Tags Orders
+// @Summary Mark order lost
+// @Description Librarian flag picked up order as lost
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderResponseDto
+// @Router /order/{id}/lost [post]
+func (h *orderHandlersHTTP) MarkLostById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
 
-	role, _ = claims["role"].(string)
-	if role != "" {
-		userID, _ = claims["user_id"].(string)
-	} else {
-		userID, _ = claims["librarian_id"].(string)
+		orderUUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		order, err := h.orderUC.MarkLostById(ctx, orderUUID, principal.ID)
+		if err != nil {
+			h.logger.Errorf("orderUC.MarkLostById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, dto.OrderResponseFromModel(order))
 	}
-	return sessionID, userID, role, nil
 }
 
 func (h *orderHandlersHTTP) registerReqToOrderModel(r *dto.OrderCreateRequestDto, user *models.User, librarian *models.Librarian, book *models.Book) (*models.Order, error) {
@@ -315,16 +598,3 @@ func (h *orderHandlersHTTP) registerReqToOrderModel(r *dto.OrderCreateRequestDto
 
 	return orderCandidate, nil
 }
-
-func (h *orderHandlersHTTP) updateReqToOrderModel(updateCandidate *models.Order, r *dto.OrderUpdateRequestDto) (*models.Order, error) {
-	if r.Status != models.OrderStatusPending && r.Status != models.OrderStatusAccepted {
-		return nil, fmt.Errorf("status invalid: %v", r.Status)
-	}
-
-	if r.Status == updateCandidate.Status {
-		return nil, fmt.Errorf("order has been %v", r.Status)
-	}
-	updateCandidate.Status = r.Status
-
-	return updateCandidate, nil
-}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-playground/validator"
+	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/config"
+	bookCatalog "github.com/dinorain/pinjembuku/internal/book/catalog"
+	bookRepository "github.com/dinorain/pinjembuku/internal/book/repository"
+	bookUseCase "github.com/dinorain/pinjembuku/internal/book/usecase"
+	mockLibrarianUC "github.com/dinorain/pinjembuku/internal/librarian/mock"
+	"github.com/dinorain/pinjembuku/internal/middlewares"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/internal/order/delivery/http/dto"
+	"github.com/dinorain/pinjembuku/internal/order/mock"
+	mockSessUC "github.com/dinorain/pinjembuku/internal/session/mock"
+	mockUserUC "github.com/dinorain/pinjembuku/internal/user/mock"
+	"github.com/dinorain/pinjembuku/pkg/http_client"
+	"github.com/dinorain/pinjembuku/pkg/jwtkeys"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
+)
+
+func TestOrdersService_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	openLibrary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/works/OL66554W.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key": "/works/OL66554W", "title": "Pride and Prejudice"}`))
+	}))
+	defer openLibrary.Close()
+
+	orderUC := mock.NewMockOrderUseCase(ctrl)
+	userUC := mockUserUC.NewMockUserUseCase(ctrl)
+	librarianUC := mockLibrarianUC.NewMockLibrarianUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	sessRepo := mockSessUC.NewMockSessRepository(ctrl)
+
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg, tokenService, sessRepo)
+
+	catalogProvider := bookCatalog.NewOpenLibraryProvider(http_client.NewHttpClient(false).SetRetryCount(0), openLibrary.URL, appLogger)
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+	bookRedisRepo := bookRepository.NewBookRedisRepo(redis.NewClient(&redis.Options{Addr: mr.Addr()}), appLogger)
+	bookUC := bookUseCase.NewBookUseCase(cfg, appLogger, catalogProvider, bookRedisRepo)
+
+	e := echo.New()
+	v := validator.New()
+	handlers := NewOrderHandlersHTTP(e.Group("order"), appLogger, cfg, mw, v, orderUC, bookUC, userUC, librarianUC, sessUC)
+
+	userUUID := uuid.New()
+	claims := jwt.MapClaims{}
+	claims["session_id"] = uuid.New().String()
+	claims["user_id"] = userUUID.String()
+	claims["role"] = models.UserRoleUser
+	validToken, _, err := tokenService.GenerateTokenPair(userUUID.String(), claims["session_id"].(string), uuid.New().String(), claims)
+	require.NoError(t, err)
+
+	newRequest := func(bookKey string) *http.Request {
+		buf := &bytes.Buffer{}
+		_ = json.NewEncoder(buf).Encode(&dto.OrderCreateRequestDto{
+			BookKey:        bookKey,
+			PickupSchedule: time.Now().Add(time.Hour * 24),
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/order", buf)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, fmt.Sprintf("bearer %v", validToken))
+		return req
+	}
+
+	h := mw.IsLoggedIn()(handlers.Create())
+
+	sessRepo.EXPECT().GetSessionById(gomock.Any(), claims["session_id"].(string)).AnyTimes().Return(&models.Session{UserID: userUUID}, nil)
+	verifiedAt := time.Now()
+	user := &models.User{UserID: userUUID, EmailVerifiedAt: &verifiedAt}
+	userUC.EXPECT().CachedFindById(gomock.Any(), userUUID).AnyTimes().Return(user, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		ctx := e.NewContext(newRequest("/works/OL66554W"), res)
+
+		orderUUID := uuid.New()
+		orderUC.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, order *models.Order) (*models.Order, error) {
+			require.Equal(t, "Pride and Prejudice", order.Item.Title)
+			require.Equal(t, userUUID, order.UserID)
+			order.OrderID = orderUUID
+			return order, nil
+		})
+
+		require.NoError(t, h(ctx))
+		require.Equal(t, http.StatusCreated, res.Code)
+	})
+
+	t.Run("Unknown book", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		ctx := e.NewContext(newRequest("/works/OL0W"), res)
+
+		require.NoError(t, h(ctx))
+		require.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("Catalog unavailable", func(t *testing.T) {
+		openLibrary.Close()
+
+		res := httptest.NewRecorder()
+		ctx := e.NewContext(newRequest("/works/OL66554W"), res)
+
+		orderUC.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&models.Order{OrderID: uuid.New()}, nil)
+
+		require.NoError(t, h(ctx))
+		require.Equal(t, http.StatusCreated, res.Code)
+	})
+
+	t.Run("Email not verified", func(t *testing.T) {
+		user.EmailVerifiedAt = nil
+		defer func() { user.EmailVerifiedAt = &verifiedAt }()
+
+		res := httptest.NewRecorder()
+		ctx := e.NewContext(newRequest("/works/OL66554W"), res)
+
+		require.NoError(t, h(ctx))
+		require.Equal(t, http.StatusForbidden, res.Code)
+	})
+}
@@ -4,7 +4,16 @@ func (h *orderHandlersHTTP) OrderMapRoutes() {
 	h.group.Use(h.mw.IsLoggedIn())
 	h.group.GET("", h.FindAll())
 	h.group.POST("", h.Create(), h.mw.IsUser)
+	h.group.GET("/overdue", h.FindAllOverdue(), h.mw.IsLibrarian)
 
 	h.group.GET("/:id", h.FindById())
 	h.group.POST("/:id", h.AcceptById(), h.mw.IsLibrarian)
+	h.group.POST("/:id/accept", h.AcceptById(), h.mw.IsLibrarian)
+	h.group.POST("/:id/reject", h.RejectById(), h.mw.IsLibrarian)
+	h.group.POST("/:id/cancel", h.CancelById(), h.mw.IsUser)
+	h.group.POST("/:id/pickup", h.PickUpById(), h.mw.IsLibrarian)
+	h.group.POST("/:id/return", h.ReturnById(), h.mw.IsLibrarian)
+	h.group.POST("/:id/renew", h.RenewById())
+	h.group.POST("/:id/overdue", h.MarkOverdueById(), h.mw.IsLibrarian)
+	h.group.POST("/:id/lost", h.MarkLostById(), h.mw.IsLibrarian)
 }
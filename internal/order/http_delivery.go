@@ -6,5 +6,14 @@ import "github.com/labstack/echo/v4"
 type OrderHandlers interface {
 	Create() echo.HandlerFunc
 	FindAll() echo.HandlerFunc
+	FindAllOverdue() echo.HandlerFunc
 	FindById() echo.HandlerFunc
+	AcceptById() echo.HandlerFunc
+	RejectById() echo.HandlerFunc
+	CancelById() echo.HandlerFunc
+	PickUpById() echo.HandlerFunc
+	ReturnById() echo.HandlerFunc
+	RenewById() echo.HandlerFunc
+	MarkOverdueById() echo.HandlerFunc
+	MarkLostById() echo.HandlerFunc
 }
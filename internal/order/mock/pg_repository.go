@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/dinorain/pinjembuku/internal/models"
 	utils "github.com/dinorain/pinjembuku/pkg/utils"
@@ -37,6 +38,51 @@ func (m *MockOrderPGRepository) EXPECT() *MockOrderPGRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountAll mocks base method.
+func (m *MockOrderPGRepository) CountAll(ctx context.Context, filter *models.OrderFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockOrderPGRepositoryMockRecorder) CountAll(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockOrderPGRepository)(nil).CountAll), ctx, filter)
+}
+
+// CountAllOverdue mocks base method.
+func (m *MockOrderPGRepository) CountAllOverdue(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllOverdue", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllOverdue indicates an expected call of CountAllOverdue.
+func (mr *MockOrderPGRepositoryMockRecorder) CountAllOverdue(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllOverdue", reflect.TypeOf((*MockOrderPGRepository)(nil).CountAllOverdue), ctx, now)
+}
+
+// CountWaitingByBookKey mocks base method.
+func (m *MockOrderPGRepository) CountWaitingByBookKey(ctx context.Context, bookKey string, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountWaitingByBookKey", ctx, bookKey, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountWaitingByBookKey indicates an expected call of CountWaitingByBookKey.
+func (mr *MockOrderPGRepositoryMockRecorder) CountWaitingByBookKey(ctx, bookKey, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountWaitingByBookKey", reflect.TypeOf((*MockOrderPGRepository)(nil).CountWaitingByBookKey), ctx, bookKey, userID)
+}
+
 // Create mocks base method.
 func (m *MockOrderPGRepository) Create(ctx context.Context, user *models.Order) (*models.Order, error) {
 	m.ctrl.T.Helper()
@@ -67,63 +113,33 @@ func (mr *MockOrderPGRepositoryMockRecorder) DeleteById(ctx, userID interface{})
 }
 
 // FindAll mocks base method.
-func (m *MockOrderPGRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Order, error) {
+func (m *MockOrderPGRepository) FindAll(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter, pagination)
 	ret0, _ := ret[0].([]models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockOrderPGRepositoryMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockOrderPGRepository)(nil).FindAll), ctx, pagination)
-}
-
-// FindAllByLibrarianId mocks base method.
-func (m *MockOrderPGRepository) FindAllByLibrarianId(ctx context.Context, librarianID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByLibrarianId", ctx, librarianID, pagination)
-	ret0, _ := ret[0].([]models.Order)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// FindAllByLibrarianId indicates an expected call of FindAllByLibrarianId.
-func (mr *MockOrderPGRepositoryMockRecorder) FindAllByLibrarianId(ctx, librarianID, pagination interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByLibrarianId", reflect.TypeOf((*MockOrderPGRepository)(nil).FindAllByLibrarianId), ctx, librarianID, pagination)
-}
-
-// FindAllByUserId mocks base method.
-func (m *MockOrderPGRepository) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByUserId", ctx, userID, pagination)
-	ret0, _ := ret[0].([]models.Order)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// FindAllByUserId indicates an expected call of FindAllByUserId.
-func (mr *MockOrderPGRepositoryMockRecorder) FindAllByUserId(ctx, userID, pagination interface{}) *gomock.Call {
+func (mr *MockOrderPGRepositoryMockRecorder) FindAll(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserId", reflect.TypeOf((*MockOrderPGRepository)(nil).FindAllByUserId), ctx, userID, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockOrderPGRepository)(nil).FindAll), ctx, filter, pagination)
 }
 
-// FindAllByUserIdLibrarianId mocks base method.
-func (m *MockOrderPGRepository) FindAllByUserIdLibrarianId(ctx context.Context, userID, librarianID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
+// FindAllOverdue mocks base method.
+func (m *MockOrderPGRepository) FindAllOverdue(ctx context.Context, now time.Time, pagination *utils.Pagination) ([]models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByUserIdLibrarianId", ctx, userID, librarianID, pagination)
+	ret := m.ctrl.Call(m, "FindAllOverdue", ctx, now, pagination)
 	ret0, _ := ret[0].([]models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAllByUserIdLibrarianId indicates an expected call of FindAllByUserIdLibrarianId.
-func (mr *MockOrderPGRepositoryMockRecorder) FindAllByUserIdLibrarianId(ctx, userID, librarianID, pagination interface{}) *gomock.Call {
+// FindAllOverdue indicates an expected call of FindAllOverdue.
+func (mr *MockOrderPGRepositoryMockRecorder) FindAllOverdue(ctx, now, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserIdLibrarianId", reflect.TypeOf((*MockOrderPGRepository)(nil).FindAllByUserIdLibrarianId), ctx, userID, librarianID, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllOverdue", reflect.TypeOf((*MockOrderPGRepository)(nil).FindAllOverdue), ctx, now, pagination)
 }
 
 // FindById mocks base method.
@@ -142,16 +158,16 @@ func (mr *MockOrderPGRepositoryMockRecorder) FindById(ctx, userID interface{}) *
 }
 
 // UpdateById mocks base method.
-func (m *MockOrderPGRepository) UpdateById(ctx context.Context, user *models.Order) (*models.Order, error) {
+func (m *MockOrderPGRepository) UpdateById(ctx context.Context, order *models.Order, status string) (*models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateById", ctx, user)
+	ret := m.ctrl.Call(m, "UpdateById", ctx, order, status)
 	ret0, _ := ret[0].(*models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateById indicates an expected call of UpdateById.
-func (mr *MockOrderPGRepositoryMockRecorder) UpdateById(ctx, user interface{}) *gomock.Call {
+func (mr *MockOrderPGRepositoryMockRecorder) UpdateById(ctx, order, status interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockOrderPGRepository)(nil).UpdateById), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockOrderPGRepository)(nil).UpdateById), ctx, order, status)
 }
@@ -37,6 +37,21 @@ func (m *MockOrderUseCase) EXPECT() *MockOrderUseCaseMockRecorder {
 	return m.recorder
 }
 
+// AcceptById mocks base method.
+func (m *MockOrderUseCase) AcceptById(ctx context.Context, orderID, librarianID uuid.UUID) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptById", ctx, orderID, librarianID)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptById indicates an expected call of AcceptById.
+func (mr *MockOrderUseCaseMockRecorder) AcceptById(ctx, orderID, librarianID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptById", reflect.TypeOf((*MockOrderUseCase)(nil).AcceptById), ctx, orderID, librarianID)
+}
+
 // CachedFindById mocks base method.
 func (m *MockOrderUseCase) CachedFindById(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
 	m.ctrl.T.Helper()
@@ -52,6 +67,66 @@ func (mr *MockOrderUseCaseMockRecorder) CachedFindById(ctx, orderID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CachedFindById", reflect.TypeOf((*MockOrderUseCase)(nil).CachedFindById), ctx, orderID)
 }
 
+// CachedFindByIdForActor mocks base method.
+func (m *MockOrderUseCase) CachedFindByIdForActor(ctx context.Context, orderID, actorID uuid.UUID, actorRole string) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CachedFindByIdForActor", ctx, orderID, actorID, actorRole)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CachedFindByIdForActor indicates an expected call of CachedFindByIdForActor.
+func (mr *MockOrderUseCaseMockRecorder) CachedFindByIdForActor(ctx, orderID, actorID, actorRole interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CachedFindByIdForActor", reflect.TypeOf((*MockOrderUseCase)(nil).CachedFindByIdForActor), ctx, orderID, actorID, actorRole)
+}
+
+// CancelById mocks base method.
+func (m *MockOrderUseCase) CancelById(ctx context.Context, orderID, actorID uuid.UUID, actorRole string) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelById", ctx, orderID, actorID, actorRole)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelById indicates an expected call of CancelById.
+func (mr *MockOrderUseCaseMockRecorder) CancelById(ctx, orderID, actorID, actorRole interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelById", reflect.TypeOf((*MockOrderUseCase)(nil).CancelById), ctx, orderID, actorID, actorRole)
+}
+
+// CountAll mocks base method.
+func (m *MockOrderUseCase) CountAll(ctx context.Context, filter *models.OrderFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockOrderUseCaseMockRecorder) CountAll(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockOrderUseCase)(nil).CountAll), ctx, filter)
+}
+
+// CountAllOverdue mocks base method.
+func (m *MockOrderUseCase) CountAllOverdue(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllOverdue", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllOverdue indicates an expected call of CountAllOverdue.
+func (mr *MockOrderUseCaseMockRecorder) CountAllOverdue(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllOverdue", reflect.TypeOf((*MockOrderUseCase)(nil).CountAllOverdue), ctx)
+}
+
 // Create mocks base method.
 func (m *MockOrderUseCase) Create(ctx context.Context, order *models.Order) (*models.Order, error) {
 	m.ctrl.T.Helper()
@@ -82,18 +157,18 @@ func (mr *MockOrderUseCaseMockRecorder) DeleteById(ctx, orderID interface{}) *go
 }
 
 // FindAll mocks base method.
-func (m *MockOrderUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Order, error) {
+func (m *MockOrderUseCase) FindAll(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter, pagination)
 	ret0, _ := ret[0].([]models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockOrderUseCaseMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+func (mr *MockOrderUseCaseMockRecorder) FindAll(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockOrderUseCase)(nil).FindAll), ctx, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockOrderUseCase)(nil).FindAll), ctx, filter, pagination)
 }
 
 // FindAllByLibrarianId mocks base method.
@@ -141,6 +216,21 @@ func (mr *MockOrderUseCaseMockRecorder) FindAllByUserIdLibrarianId(ctx, userID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserIdLibrarianId", reflect.TypeOf((*MockOrderUseCase)(nil).FindAllByUserIdLibrarianId), ctx, userID, librarianID, pagination)
 }
 
+// FindAllOverdue mocks base method.
+func (m *MockOrderUseCase) FindAllOverdue(ctx context.Context, pagination *utils.Pagination) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllOverdue", ctx, pagination)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllOverdue indicates an expected call of FindAllOverdue.
+func (mr *MockOrderUseCaseMockRecorder) FindAllOverdue(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllOverdue", reflect.TypeOf((*MockOrderUseCase)(nil).FindAllOverdue), ctx, pagination)
+}
+
 // FindById mocks base method.
 func (m *MockOrderUseCase) FindById(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +246,110 @@ func (mr *MockOrderUseCaseMockRecorder) FindById(ctx, orderID interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockOrderUseCase)(nil).FindById), ctx, orderID)
 }
 
+// MarkLostById mocks base method.
+func (m *MockOrderUseCase) MarkLostById(ctx context.Context, orderID, librarianID uuid.UUID) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkLostById", ctx, orderID, librarianID)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkLostById indicates an expected call of MarkLostById.
+func (mr *MockOrderUseCaseMockRecorder) MarkLostById(ctx, orderID, librarianID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkLostById", reflect.TypeOf((*MockOrderUseCase)(nil).MarkLostById), ctx, orderID, librarianID)
+}
+
+// MarkOverdueById mocks base method.
+func (m *MockOrderUseCase) MarkOverdueById(ctx context.Context, orderID, librarianID uuid.UUID) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOverdueById", ctx, orderID, librarianID)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOverdueById indicates an expected call of MarkOverdueById.
+func (mr *MockOrderUseCaseMockRecorder) MarkOverdueById(ctx, orderID, librarianID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOverdueById", reflect.TypeOf((*MockOrderUseCase)(nil).MarkOverdueById), ctx, orderID, librarianID)
+}
+
+// PickUpById mocks base method.
+func (m *MockOrderUseCase) PickUpById(ctx context.Context, orderID, librarianID uuid.UUID) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PickUpById", ctx, orderID, librarianID)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PickUpById indicates an expected call of PickUpById.
+func (mr *MockOrderUseCaseMockRecorder) PickUpById(ctx, orderID, librarianID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PickUpById", reflect.TypeOf((*MockOrderUseCase)(nil).PickUpById), ctx, orderID, librarianID)
+}
+
+// RejectById mocks base method.
+func (m *MockOrderUseCase) RejectById(ctx context.Context, orderID, librarianID uuid.UUID, reason string) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectById", ctx, orderID, librarianID, reason)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectById indicates an expected call of RejectById.
+func (mr *MockOrderUseCaseMockRecorder) RejectById(ctx, orderID, librarianID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectById", reflect.TypeOf((*MockOrderUseCase)(nil).RejectById), ctx, orderID, librarianID, reason)
+}
+
+// RenewById mocks base method.
+func (m *MockOrderUseCase) RenewById(ctx context.Context, orderID, actorID uuid.UUID, actorRole string) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewById", ctx, orderID, actorID, actorRole)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewById indicates an expected call of RenewById.
+func (mr *MockOrderUseCaseMockRecorder) RenewById(ctx, orderID, actorID, actorRole interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewById", reflect.TypeOf((*MockOrderUseCase)(nil).RenewById), ctx, orderID, actorID, actorRole)
+}
+
+// ReturnById mocks base method.
+func (m *MockOrderUseCase) ReturnById(ctx context.Context, orderID, librarianID uuid.UUID) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnById", ctx, orderID, librarianID)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnById indicates an expected call of ReturnById.
+func (mr *MockOrderUseCaseMockRecorder) ReturnById(ctx, orderID, librarianID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnById", reflect.TypeOf((*MockOrderUseCase)(nil).ReturnById), ctx, orderID, librarianID)
+}
+
+// Subscribe mocks base method.
+func (m *MockOrderUseCase) Subscribe(ctx context.Context, actorID uuid.UUID, actorRole string) <-chan models.OrderEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, actorID, actorRole)
+	ret0, _ := ret[0].(<-chan models.OrderEvent)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockOrderUseCaseMockRecorder) Subscribe(ctx, actorID, actorRole interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockOrderUseCase)(nil).Subscribe), ctx, actorID, actorRole)
+}
+
 // UpdateById mocks base method.
 func (m *MockOrderUseCase) UpdateById(ctx context.Context, order *models.Order) (*models.Order, error) {
 	m.ctrl.T.Helper()
@@ -3,6 +3,7 @@ package order
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -13,11 +14,12 @@ import (
 // Order pg repository
 type OrderPGRepository interface {
 	Create(ctx context.Context, user *models.Order) (*models.Order, error)
-	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Order, error)
-	FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error)
-	FindAllByLibrarianId(ctx context.Context, librarianID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error)
-	FindAllByUserIdLibrarianId(ctx context.Context, userID uuid.UUID, librarianID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error)
+	FindAll(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error)
+	CountAll(ctx context.Context, filter *models.OrderFilter) (int, error)
+	FindAllOverdue(ctx context.Context, now time.Time, pagination *utils.Pagination) ([]models.Order, error)
+	CountAllOverdue(ctx context.Context, now time.Time) (int, error)
+	CountWaitingByBookKey(ctx context.Context, bookKey string, userID uuid.UUID) (int, error)
 	FindById(ctx context.Context, userID uuid.UUID) (*models.Order, error)
-	UpdateById(ctx context.Context, user *models.Order) (*models.Order, error)
+	UpdateById(ctx context.Context, order *models.Order, status string) (*models.Order, error)
 	DeleteById(ctx context.Context, userID uuid.UUID) error
 }
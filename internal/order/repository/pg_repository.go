@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/dinorain/pinjembuku/internal/models"
 	"github.com/dinorain/pinjembuku/internal/order"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
 	"github.com/dinorain/pinjembuku/pkg/utils"
 )
 
@@ -43,67 +45,94 @@ func (r *OrderRepository) Create(ctx context.Context, order *models.Order) (*mod
 	return createdOrder, nil
 }
 
-// UpdateById update existing order
-func (r *OrderRepository) UpdateById(ctx context.Context, order *models.Order) (*models.Order, error) {
+// UpdateById update existing order which is still in status, an order moved on meanwhile is not touched
+func (r *OrderRepository) UpdateById(ctx context.Context, order *models.Order, status string) (*models.Order, error) {
 	if res, err := r.db.ExecContext(
 		ctx,
 		updateByIdQuery,
 		order.OrderID,
 		order.UserID,
 		order.LibrarianID,
+		order.CopyID,
 		order.Item,
 		order.Status,
 		order.PickupSchedule,
+		order.PickedUpAt,
+		order.DueAt,
+		order.ReturnedAt,
+		order.RenewalCount,
+		order.RejectReason,
+		status,
 	); err != nil {
 		return nil, errors.Wrap(err, "OrderPGRepository.Update.ExecContext")
 	} else {
-		_, err := res.RowsAffected()
+		rowsAffected, err := res.RowsAffected()
 		if err != nil {
 			return nil, errors.Wrap(err, "OrderPGRepository.Update.RowsAffected")
 		}
+		if rowsAffected == 0 {
+			return nil, errors.Wrapf(grpc_errors.ErrInvalidStatus, "order is no longer %s", status)
+		}
 	}
 
 	return order, nil
 }
 
-// FindAll Find orders
-func (r *OrderRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Order, error) {
+// FindAll Find orders matching filter
+func (r *OrderRepository) FindAll(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error) {
+	query, args, err := buildFindAllQuery(filter, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "OrderPGRepository.FindAll.buildFindAllQuery")
+	}
+
 	var orders []models.Order
-	if err := r.db.SelectContext(ctx, &orders, findAllQuery, pagination.GetLimit(), pagination.GetOffset()); err != nil {
-		return nil, errors.Wrap(err, "OrderPGRepository.FindById.SelectContext")
+	if err := r.db.SelectContext(ctx, &orders, query, args...); err != nil {
+		return nil, errors.Wrap(err, "OrderPGRepository.FindAll.SelectContext")
 	}
 
 	return orders, nil
 }
 
-// FindAllByUserId Find orders by user uuid
-func (r *OrderRepository) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
-	var orders []models.Order
-	if err := r.db.SelectContext(ctx, &orders, findByUserIdQuery, userID, pagination.GetLimit(), pagination.GetOffset()); err != nil {
-		return nil, errors.Wrap(err, "OrderPGRepository.FindAllByUserId.SelectContext")
+// CountAll Count orders matching filter
+func (r *OrderRepository) CountAll(ctx context.Context, filter *models.OrderFilter) (int, error) {
+	query, args := buildCountAllQuery(filter)
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, errors.Wrap(err, "OrderPGRepository.CountAll.GetContext")
 	}
 
-	return orders, nil
+	return count, nil
 }
 
-// FindAllByLibrarianId Find orders by librarian uuid
-func (r *OrderRepository) FindAllByLibrarianId(ctx context.Context, librarianID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
+// FindAllOverdue Find picked up orders which were due before now, oldest due date first
+func (r *OrderRepository) FindAllOverdue(ctx context.Context, now time.Time, pagination *utils.Pagination) ([]models.Order, error) {
 	var orders []models.Order
-	if err := r.db.SelectContext(ctx, &orders, findAllByLibrarianIdQuery, librarianID, pagination.GetLimit(), pagination.GetOffset()); err != nil {
-		return nil, errors.Wrap(err, "OrderPGRepository.FindAllByLibrarianId.SelectContext")
+	if err := r.db.SelectContext(ctx, &orders, findAllOverdueQuery, now, pagination.GetLimit(), pagination.GetOffset()); err != nil {
+		return nil, errors.Wrap(err, "OrderPGRepository.FindAllOverdue.SelectContext")
 	}
 
 	return orders, nil
 }
 
-// FindAllByUserIdLibrarianId Find orders by user uuid and librarian uuid
-func (r *OrderRepository) FindAllByUserIdLibrarianId(ctx context.Context, userID uuid.UUID, librarianID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
-	var orders []models.Order
-	if err := r.db.SelectContext(ctx, &orders, findAllByUserIdLibrarianIDQuery, userID, librarianID, pagination.GetLimit(), pagination.GetOffset()); err != nil {
-		return nil, errors.Wrap(err, "OrderPGRepository.FindAllByUserIdLibrarianId.SelectContext")
+// CountAllOverdue Count picked up orders which were due before now
+func (r *OrderRepository) CountAllOverdue(ctx context.Context, now time.Time) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, countAllOverdueQuery, now); err != nil {
+		return 0, errors.Wrap(err, "OrderPGRepository.CountAllOverdue.GetContext")
 	}
 
-	return orders, nil
+	return count, nil
+}
+
+// CountWaitingByBookKey Count pending orders of book placed by users other than userID
+func (r *OrderRepository) CountWaitingByBookKey(ctx context.Context, bookKey string, userID uuid.UUID) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, countWaitingByBookKeyQuery, bookKey, userID); err != nil {
+		return 0, errors.Wrap(err, "OrderPGRepository.CountWaitingByBookKey.GetContext")
+	}
+
+	return count, nil
 }
 
 // FindById Find order by uuid
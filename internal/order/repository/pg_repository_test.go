@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+var orderColumns = []string{"order_id", "user_id", "librarian_id", "copy_id", "item", "status", "pickup_schedule", "picked_up_at", "due_at", "returned_at", "renewal_count", "reject_reason", "created_at", "updated_at"}
+
+func newMockOrder() *models.Order {
+	librarianID := uuid.New()
+	copyID := uuid.New()
+	pickedUpAt := time.Now().AddDate(0, 0, -20)
+	dueAt := pickedUpAt.AddDate(0, 0, 14)
+	return &models.Order{
+		OrderID:        uuid.New(),
+		UserID:         uuid.New(),
+		LibrarianID:    &librarianID,
+		CopyID:         &copyID,
+		Item:           models.OrderItem{BookKey: "/works/OL66554W", Title: "Pride and Prejudice"},
+		Status:         models.OrderStatusPickedUp,
+		PickupSchedule: pickedUpAt,
+		PickedUpAt:     &pickedUpAt,
+		DueAt:          &dueAt,
+	}
+}
+
+func TestOrderRepository_FindAllOverdue(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	orderPGRepository := NewOrderPGRepository(sqlxDB)
+
+	mockOrder := newMockOrder()
+	item, err := mockOrder.Item.Value()
+	require.NoError(t, err)
+	rows := sqlmock.NewRows(orderColumns).AddRow(
+		mockOrder.OrderID,
+		mockOrder.UserID,
+		mockOrder.LibrarianID,
+		mockOrder.CopyID,
+		[]byte(item.(string)),
+		mockOrder.Status,
+		mockOrder.PickupSchedule,
+		mockOrder.PickedUpAt,
+		mockOrder.DueAt,
+		nil,
+		mockOrder.RenewalCount,
+		nil,
+		time.Now(),
+		time.Now(),
+	)
+
+	now := time.Now()
+	size := 10
+	mock.ExpectQuery(findAllOverdueQuery).WithArgs(now, size, 0).WillReturnRows(rows)
+
+	orders, err := orderPGRepository.FindAllOverdue(context.Background(), now, utils.NewPaginationQuery(size, 1))
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	require.Equal(t, mockOrder.OrderID, orders[0].OrderID)
+	require.Equal(t, mockOrder.Item.BookKey, orders[0].Item.BookKey)
+	require.Nil(t, orders[0].ReturnedAt)
+}
+
+func TestOrderRepository_FindAll(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	orderPGRepository := NewOrderPGRepository(sqlxDB)
+
+	mockOrder := newMockOrder()
+	item, err := mockOrder.Item.Value()
+	require.NoError(t, err)
+	rows := sqlmock.NewRows(orderColumns).AddRow(
+		mockOrder.OrderID,
+		mockOrder.UserID,
+		mockOrder.LibrarianID,
+		mockOrder.CopyID,
+		[]byte(item.(string)),
+		mockOrder.Status,
+		mockOrder.PickupSchedule,
+		mockOrder.PickedUpAt,
+		mockOrder.DueAt,
+		nil,
+		mockOrder.RenewalCount,
+		nil,
+		time.Now(),
+		time.Now(),
+	)
+
+	query := findAllQuery + " WHERE status = $1 AND user_id = $2 ORDER BY due_at DESC, order_id DESC LIMIT $3 OFFSET $4"
+	mock.ExpectQuery(query).WithArgs(mockOrder.Status, mockOrder.UserID, 10, 0).WillReturnRows(rows)
+
+	pagination := utils.NewPaginationQuery(10, 1)
+	pagination.SetOrderBy("due_at:desc")
+	orders, err := orderPGRepository.FindAll(context.Background(), &models.OrderFilter{Status: mockOrder.Status, UserID: &mockOrder.UserID}, pagination)
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	require.Equal(t, mockOrder.OrderID, orders[0].OrderID)
+}
+
+func TestOrderRepository_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	orderPGRepository := NewOrderPGRepository(sqlxDB)
+
+	mockOrder := newMockOrder()
+	returnedAt := time.Now()
+	mockOrder.Status = models.OrderStatusReturned
+	mockOrder.ReturnedAt = &returnedAt
+
+	expectUpdate := func() *sqlmock.ExpectedExec {
+		return mock.ExpectExec(updateByIdQuery).WithArgs(
+			mockOrder.OrderID,
+			mockOrder.UserID,
+			mockOrder.LibrarianID,
+			mockOrder.CopyID,
+			mockOrder.Item,
+			mockOrder.Status,
+			mockOrder.PickupSchedule,
+			mockOrder.PickedUpAt,
+			mockOrder.DueAt,
+			mockOrder.ReturnedAt,
+			mockOrder.RenewalCount,
+			mockOrder.RejectReason,
+			models.OrderStatusPickedUp,
+		)
+	}
+
+	t.Run("Current status", func(t *testing.T) {
+		expectUpdate().WillReturnResult(sqlmock.NewResult(0, 1))
+
+		updatedOrder, err := orderPGRepository.UpdateById(context.Background(), mockOrder, models.OrderStatusPickedUp)
+		require.NoError(t, err)
+		require.Equal(t, returnedAt, *updatedOrder.ReturnedAt)
+	})
+
+	t.Run("Stale status", func(t *testing.T) {
+		expectUpdate().WillReturnResult(sqlmock.NewResult(0, 0))
+
+		_, err := orderPGRepository.UpdateById(context.Background(), mockOrder, models.OrderStatusPickedUp)
+		require.True(t, errors.Is(err, grpc_errors.ErrInvalidStatus))
+	})
+}
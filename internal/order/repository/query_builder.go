@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+const defaultOrderBy = "created_at DESC"
+
+// orderByColumns whitelist of columns listings may be sorted by, keyed by the orderBy query value
+var orderByColumns = map[string]string{
+	"created_at":      "created_at",
+	"updated_at":      "updated_at",
+	"pickup_schedule": "pickup_schedule",
+	"due_at":          "due_at",
+	"status":          "status",
+}
+
+// queryBuilder collect where clauses and their positional args
+type queryBuilder struct {
+	conditions []string
+	args       []interface{}
+}
+
+// where add condition, %d in clause is replaced by the placeholder index of arg
+func (b *queryBuilder) where(clause string, arg interface{}) {
+	b.args = append(b.args, arg)
+	b.conditions = append(b.conditions, fmt.Sprintf(clause, len(b.args)))
+}
+
+// placeholder append arg and return its placeholder
+func (b *queryBuilder) placeholder(arg interface{}) string {
+	b.args = append(b.args, arg)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// parseOrderBy turn "column" or "column:asc|desc" into a safe ORDER BY expression
+func parseOrderBy(orderBy string) (string, error) {
+	if orderBy == "" {
+		return defaultOrderBy, nil
+	}
+
+	field, direction := orderBy, "asc"
+	if i := strings.Index(orderBy, ":"); i >= 0 {
+		field, direction = orderBy[:i], strings.ToLower(orderBy[i+1:])
+	}
+
+	column, ok := orderByColumns[field]
+	if !ok {
+		return "", errors.Wrapf(grpc_errors.ErrInvalidOrderBy, "unknown field %q", field)
+	}
+	if direction != "asc" && direction != "desc" {
+		return "", errors.Wrapf(grpc_errors.ErrInvalidOrderBy, "unknown direction %q", direction)
+	}
+
+	return fmt.Sprintf("%s %s", column, strings.ToUpper(direction)), nil
+}
+
+// applyFilter add a condition for every set filter field
+func (b *queryBuilder) applyFilter(filter *models.OrderFilter) {
+	if filter == nil {
+		return
+	}
+	if filter.Status != "" {
+		b.where("status = $%d", filter.Status)
+	}
+	if filter.BookKey != "" {
+		b.where("item->>'key' = $%d", filter.BookKey)
+	}
+	if filter.UserID != nil {
+		b.where("user_id = $%d", *filter.UserID)
+	}
+	if filter.LibrarianID != nil {
+		b.where("librarian_id = $%d", *filter.LibrarianID)
+	}
+	if filter.CreatedFrom != nil {
+		b.where("created_at >= $%d", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		b.where("created_at < $%d", *filter.CreatedTo)
+	}
+	if filter.PickupFrom != nil {
+		b.where("pickup_schedule >= $%d", *filter.PickupFrom)
+	}
+	if filter.PickupTo != nil {
+		b.where("pickup_schedule < $%d", *filter.PickupTo)
+	}
+}
+
+// writeWhere append the collected conditions to sb
+func (b *queryBuilder) writeWhere(sb *strings.Builder) {
+	if len(b.conditions) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(b.conditions, " AND "))
+	}
+}
+
+// buildFindAllQuery build listing query from filter, only values are passed as args.
+// With a cursor the page starts right after it in (created_at, order_id) order and OFFSET is dropped.
+// order_id breaks ties in both modes, as reported by utils.OrderBySort
+func buildFindAllQuery(filter *models.OrderFilter, pagination *utils.Pagination) (string, []interface{}, error) {
+	orderBy, err := parseOrderBy(pagination.GetOrderBy())
+	if err != nil {
+		return "", nil, err
+	}
+
+	cursor := pagination.GetCursor()
+	if cursor != nil && pagination.GetOrderBy() != "" {
+		return "", nil, errors.Wrap(grpc_errors.ErrInvalidOrderBy, "cursor pages are always sorted by created_at:desc")
+	}
+
+	b := &queryBuilder{}
+	b.applyFilter(filter)
+	if cursor != nil {
+		b.conditions = append(b.conditions, fmt.Sprintf("(created_at, order_id) < (%s, %s)", b.placeholder(cursor.CreatedAt), b.placeholder(cursor.ID)))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(findAllQuery)
+	b.writeWhere(&sb)
+	sb.WriteString(" ORDER BY ")
+	sb.WriteString(orderBy)
+	sb.WriteString(", order_id DESC")
+	sb.WriteString(" LIMIT ")
+	sb.WriteString(b.placeholder(pagination.GetLimit()))
+	if cursor == nil {
+		sb.WriteString(" OFFSET ")
+		sb.WriteString(b.placeholder(pagination.GetOffset()))
+	}
+
+	return sb.String(), b.args, nil
+}
+
+// buildCountAllQuery build count query matching buildFindAllQuery for the same filter
+func buildCountAllQuery(filter *models.OrderFilter) (string, []interface{}) {
+	b := &queryBuilder{}
+	b.applyFilter(filter)
+
+	var sb strings.Builder
+	sb.WriteString(countAllQuery)
+	b.writeWhere(&sb)
+
+	return sb.String(), b.args
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/utils"
+)
+
+func TestBuildFindAllQuery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("No filter", func(t *testing.T) {
+		query, args, err := buildFindAllQuery(nil, utils.NewPaginationQuery(10, 2))
+		require.NoError(t, err)
+		require.Equal(t, findAllQuery+" ORDER BY created_at DESC, order_id DESC LIMIT $1 OFFSET $2", query)
+		require.Equal(t, []interface{}{10, 10}, args)
+	})
+
+	t.Run("All filters", func(t *testing.T) {
+		userUUID, librarianUUID := uuid.New(), uuid.New()
+		from, to := time.Now().AddDate(0, -1, 0), time.Now()
+		filter := &models.OrderFilter{
+			Status:      models.OrderStatusPending,
+			BookKey:     "/works/OL66554W",
+			UserID:      &userUUID,
+			LibrarianID: &librarianUUID,
+			CreatedFrom: &from,
+			CreatedTo:   &to,
+			PickupFrom:  &from,
+			PickupTo:    &to,
+		}
+		pagination := utils.NewPaginationQuery(5, 1)
+		pagination.SetOrderBy("pickup_schedule:asc")
+
+		query, args, err := buildFindAllQuery(filter, pagination)
+		require.NoError(t, err)
+		require.Equal(t, findAllQuery+" WHERE status = $1 AND item->>'key' = $2 AND user_id = $3 AND librarian_id = $4"+
+			" AND created_at >= $5 AND created_at < $6 AND pickup_schedule >= $7 AND pickup_schedule < $8"+
+			" ORDER BY pickup_schedule ASC, order_id DESC LIMIT $9 OFFSET $10", query)
+		require.Equal(t, []interface{}{models.OrderStatusPending, "/works/OL66554W", userUUID, librarianUUID, from, to, from, to, 5, 0}, args)
+	})
+
+	t.Run("Cursor", func(t *testing.T) {
+		cursor := utils.NewCursor(time.Now(), uuid.New())
+		pagination := utils.NewPaginationQuery(10, 1)
+		require.NoError(t, pagination.SetCursor(cursor.Encode()))
+
+		query, args, err := buildFindAllQuery(&models.OrderFilter{Status: models.OrderStatusPending}, pagination)
+		require.NoError(t, err)
+		require.Equal(t, findAllQuery+" WHERE status = $1 AND (created_at, order_id) < ($2, $3) ORDER BY created_at DESC, order_id DESC LIMIT $4", query)
+		require.Len(t, args, 4)
+		require.Equal(t, cursor.ID, args[2])
+
+		pagination.SetOrderBy("due_at")
+		_, _, err = buildFindAllQuery(nil, pagination)
+		require.True(t, errors.Is(err, grpc_errors.ErrInvalidOrderBy))
+	})
+
+	t.Run("Unknown orderBy", func(t *testing.T) {
+		for _, orderBy := range []string{"user_id; DROP TABLE orders", "created_at:sideways"} {
+			pagination := utils.NewPaginationQuery(10, 1)
+			pagination.SetOrderBy(orderBy)
+
+			_, _, err := buildFindAllQuery(nil, pagination)
+			require.True(t, errors.Is(err, grpc_errors.ErrInvalidOrderBy))
+		}
+	})
+}
+
+func TestBuildCountAllQuery(t *testing.T) {
+	t.Parallel()
+
+	query, args := buildCountAllQuery(nil)
+	require.Equal(t, countAllQuery, query)
+	require.Empty(t, args)
+
+	userUUID := uuid.New()
+	query, args = buildCountAllQuery(&models.OrderFilter{Status: models.OrderStatusReturned, UserID: &userUUID})
+	require.Equal(t, countAllQuery+" WHERE status = $1 AND user_id = $2", query)
+	require.Equal(t, []interface{}{models.OrderStatusReturned, userUUID}, args)
+}
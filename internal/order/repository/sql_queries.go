@@ -3,20 +3,23 @@ package repository
 const (
 	createOrderQuery = `INSERT INTO orders (user_id, librarian_id, item, status, pickup_schedule) 
 		VALUES ($1, $2, $3, $4, $5)
-		RETURNING order_id, user_id, librarian_id, item, status, pickup_schedule, created_at, updated_at`
+		RETURNING order_id, user_id, librarian_id, copy_id, item, status, pickup_schedule, picked_up_at, due_at, returned_at, renewal_count, reject_reason, created_at, updated_at`
 
-	findByIdQuery = `SELECT order_id, user_id, librarian_id, item, status, pickup_schedule, created_at, updated_at FROM orders WHERE order_id = $1`
+	findByIdQuery = `SELECT order_id, user_id, librarian_id, copy_id, item, status, pickup_schedule, picked_up_at, due_at, returned_at, renewal_count, reject_reason, created_at, updated_at FROM orders WHERE order_id = $1`
 
-	findAllQuery = `SELECT order_id, user_id, librarian_id, item, status, pickup_schedule, created_at, updated_at FROM orders LIMIT $1 OFFSET $2`
+	findAllQuery = `SELECT order_id, user_id, librarian_id, copy_id, item, status, pickup_schedule, picked_up_at, due_at, returned_at, renewal_count, reject_reason, created_at, updated_at FROM orders`
 
-	findByUserIdQuery = `SELECT order_id, user_id, librarian_id, item, status, pickup_schedule, created_at, updated_at FROM orders WHERE user_id = $1 LIMIT $2 OFFSET $3`
+	countAllQuery = `SELECT COUNT(*) FROM orders`
 
-	findAllByLibrarianIdQuery = `SELECT order_id, user_id, librarian_id, item, status, pickup_schedule, created_at, updated_at FROM orders WHERE librarian_id = $1 LIMIT $2 OFFSET $3`
+	findAllOverdueQuery = `SELECT order_id, user_id, librarian_id, copy_id, item, status, pickup_schedule, picked_up_at, due_at, returned_at, renewal_count, reject_reason, created_at, updated_at FROM orders 
+		WHERE status IN ('picked_up', 'overdue') AND due_at < $1 ORDER BY due_at LIMIT $2 OFFSET $3`
 
-	findAllByUserIdLibrarianIDQuery = `SELECT order_id, user_id, librarian_id, item, status, pickup_schedule, created_at, updated_at FROM orders WHERE user_id = $1 AND librarian_id = $2 LIMIT $3 OFFSET $4`
+	countAllOverdueQuery = `SELECT COUNT(*) FROM orders WHERE status IN ('picked_up', 'overdue') AND due_at < $1`
 
-	updateByIdQuery = `UPDATE orders SET user_id = $2, librarian_id = $3, item = $4, status = $5, pickup_schedule = $6 WHERE order_id = $1
-		RETURNING order_id, user_id, librarian_id, item, status, pickup_schedule, created_at, updated_at`
+	countWaitingByBookKeyQuery = `SELECT COUNT(*) FROM orders WHERE item->>'key' = $1 AND user_id <> $2 AND status = 'pending'`
+
+	updateByIdQuery = `UPDATE orders SET user_id = $2, librarian_id = $3, copy_id = $4, item = $5, status = $6, pickup_schedule = $7, picked_up_at = $8, due_at = $9, returned_at = $10, renewal_count = $11, reject_reason = $12 WHERE order_id = $1 AND status = $13
+		RETURNING order_id, user_id, librarian_id, copy_id, item, status, pickup_schedule, picked_up_at, due_at, returned_at, renewal_count, reject_reason, created_at, updated_at`
 
 	deleteByIdQuery = `DELETE FROM orders WHERE order_id = $1`
 )
@@ -13,12 +13,25 @@ import (
 //  Order UseCase interface
 type OrderUseCase interface {
 	Create(ctx context.Context, order *models.Order) (*models.Order, error)
-	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Order, error)
+	FindAll(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error)
+	CountAll(ctx context.Context, filter *models.OrderFilter) (int, error)
 	FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error)
 	FindAllByLibrarianId(ctx context.Context, librarianID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error)
 	FindAllByUserIdLibrarianId(ctx context.Context, userID uuid.UUID, librarianID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error)
+	FindAllOverdue(ctx context.Context, pagination *utils.Pagination) ([]models.Order, error)
+	CountAllOverdue(ctx context.Context) (int, error)
 	FindById(ctx context.Context, orderID uuid.UUID) (*models.Order, error)
 	CachedFindById(ctx context.Context, orderID uuid.UUID) (*models.Order, error)
+	CachedFindByIdForActor(ctx context.Context, orderID uuid.UUID, actorID uuid.UUID, actorRole string) (*models.Order, error)
 	UpdateById(ctx context.Context, order *models.Order) (*models.Order, error)
+	AcceptById(ctx context.Context, orderID uuid.UUID, librarianID uuid.UUID) (*models.Order, error)
+	RejectById(ctx context.Context, orderID uuid.UUID, librarianID uuid.UUID, reason string) (*models.Order, error)
+	CancelById(ctx context.Context, orderID uuid.UUID, actorID uuid.UUID, actorRole string) (*models.Order, error)
+	PickUpById(ctx context.Context, orderID uuid.UUID, librarianID uuid.UUID) (*models.Order, error)
+	ReturnById(ctx context.Context, orderID uuid.UUID, librarianID uuid.UUID) (*models.Order, error)
+	RenewById(ctx context.Context, orderID uuid.UUID, actorID uuid.UUID, actorRole string) (*models.Order, error)
+	MarkOverdueById(ctx context.Context, orderID uuid.UUID, librarianID uuid.UUID) (*models.Order, error)
+	MarkLostById(ctx context.Context, orderID uuid.UUID, librarianID uuid.UUID) (*models.Order, error)
 	DeleteById(ctx context.Context, orderID uuid.UUID) error
+	Subscribe(ctx context.Context, actorID uuid.UUID, actorRole string) <-chan models.OrderEvent
 }
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+)
+
+const (
+	orderEventBufferSize = 64
+)
+
+// orderSubscriber receives events of orders its actor may view
+type orderSubscriber struct {
+	actorID   uuid.UUID
+	actorRole string
+	events    chan models.OrderEvent
+}
+
+// orderEventHub fans order events out to in-process subscribers, slow subscribers miss events instead of blocking publishers
+type orderEventHub struct {
+	logger      logger.Logger
+	mu          sync.RWMutex
+	subscribers map[*orderSubscriber]struct{}
+}
+
+func newOrderEventHub(logger logger.Logger) *orderEventHub {
+	return &orderEventHub{logger: logger, subscribers: make(map[*orderSubscriber]struct{})}
+}
+
+// subscribe register actor until ctx is done, the returned channel is closed then
+func (h *orderEventHub) subscribe(ctx context.Context, actorID uuid.UUID, actorRole string) <-chan models.OrderEvent {
+	sub := &orderSubscriber{actorID: actorID, actorRole: actorRole, events: make(chan models.OrderEvent, orderEventBufferSize)}
+
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		h.mu.Lock()
+		delete(h.subscribers, sub)
+		h.mu.Unlock()
+		close(sub.events)
+	}()
+
+	return sub.events
+}
+
+// publish deliver event to every subscriber allowed to view its order
+func (h *orderEventHub) publish(event models.OrderEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subscribers {
+		if !canView(&event.Order, sub.actorID, sub.actorRole) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			h.logger.Warnf("order event %s of %s dropped for %s %s", event.Type, event.Order.OrderID, sub.actorRole, sub.actorID)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+)
+
+// canView report whether actor may read order: users their own, librarians assigned or unassigned ones, admins all
+func canView(order *models.Order, actorID uuid.UUID, actorRole string) bool {
+	switch actorRole {
+	case models.UserRoleAdmin:
+		return true
+	case models.LibrarianRole:
+		return order.LibrarianID == nil || *order.LibrarianID == actorID
+	case models.UserRoleUser:
+		return order.UserID == actorID
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+)
+
+// orderTransitions maps every status to the statuses it may move to and the roles allowed to move it there.
+// Statuses missing from the table (rejected, cancelled, returned) are terminal.
+var orderTransitions = map[string]map[string][]string{
+	models.OrderStatusPending: {
+		models.OrderStatusAccepted:  {models.LibrarianRole},
+		models.OrderStatusRejected:  {models.LibrarianRole},
+		models.OrderStatusCancelled: {models.UserRoleUser},
+	},
+	models.OrderStatusAccepted: {
+		models.OrderStatusPickedUp:  {models.LibrarianRole},
+		models.OrderStatusCancelled: {models.UserRoleUser},
+	},
+	models.OrderStatusPickedUp: {
+		models.OrderStatusReturned: {models.LibrarianRole},
+		models.OrderStatusOverdue:  {models.LibrarianRole},
+		models.OrderStatusLost:     {models.LibrarianRole},
+	},
+	models.OrderStatusOverdue: {
+		models.OrderStatusReturned: {models.LibrarianRole},
+		models.OrderStatusLost:     {models.LibrarianRole},
+	},
+	models.OrderStatusLost: {
+		models.OrderStatusReturned: {models.LibrarianRole},
+	},
+}
+
+// checkTransition verify that actor may move order to status
+func checkTransition(order *models.Order, status string, actorID uuid.UUID, actorRole string) error {
+	roles, ok := orderTransitions[order.Status][status]
+	if !ok {
+		return errors.Wrapf(grpc_errors.ErrInvalidStatus, "%s -> %s", order.Status, status)
+	}
+
+	allowed := false
+	for _, role := range roles {
+		if role == actorRole {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return errors.Wrapf(grpc_errors.ErrPermissionDenied, "%s may not move order to %s", actorRole, status)
+	}
+
+	if actorRole == models.UserRoleUser && order.UserID != actorID {
+		return errors.Wrap(grpc_errors.ErrPermissionDenied, "order belongs to another user")
+	}
+
+	return nil
+}
@@ -2,20 +2,27 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
 	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/fine"
+	"github.com/dinorain/pinjembuku/internal/hold"
+	"github.com/dinorain/pinjembuku/internal/inventory"
 	"github.com/dinorain/pinjembuku/internal/models"
 	"github.com/dinorain/pinjembuku/internal/order"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
 	"github.com/dinorain/pinjembuku/pkg/logger"
 	"github.com/dinorain/pinjembuku/pkg/utils"
 )
 
 const (
 	orderByIdCacheDuration = 3600
+	defaultLoanPeriodDays  = 14
+	defaultMaxRenewals     = 2
 )
 
 // Order UseCase
@@ -24,23 +31,38 @@ type orderUseCase struct {
 	logger      logger.Logger
 	orderPgRepo order.OrderPGRepository
 	redisRepo   order.OrderRedisRepository
+	inventoryUC inventory.InventoryUseCase
+	fineUC      fine.FineUseCase
+	holdUC      hold.HoldUseCase
+	hub         *orderEventHub
 }
 
 var _ order.OrderUseCase = (*orderUseCase)(nil)
 
 // New Order UseCase
-func NewOrderUseCase(cfg *config.Config, logger logger.Logger, orderRepo order.OrderPGRepository, redisRepo order.OrderRedisRepository) *orderUseCase {
-	return &orderUseCase{cfg: cfg, logger: logger, orderPgRepo: orderRepo, redisRepo: redisRepo}
+func NewOrderUseCase(cfg *config.Config, logger logger.Logger, orderRepo order.OrderPGRepository, redisRepo order.OrderRedisRepository, inventoryUC inventory.InventoryUseCase, fineUC fine.FineUseCase, holdUC hold.HoldUseCase) *orderUseCase {
+	return &orderUseCase{cfg: cfg, logger: logger, orderPgRepo: orderRepo, redisRepo: redisRepo, inventoryUC: inventoryUC, fineUC: fineUC, holdUC: holdUC, hub: newOrderEventHub(logger)}
 }
 
 // Create new order
 func (u *orderUseCase) Create(ctx context.Context, order *models.Order) (*models.Order, error) {
-	return u.orderPgRepo.Create(ctx, order)
+	createdOrder, err := u.orderPgRepo.Create(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	u.publish(models.OrderEventCreated, createdOrder)
+
+	return createdOrder, nil
+}
+
+// Subscribe stream events of orders the actor may view until ctx is done
+func (u *orderUseCase) Subscribe(ctx context.Context, actorID uuid.UUID, actorRole string) <-chan models.OrderEvent {
+	return u.hub.subscribe(ctx, actorID, actorRole)
 }
 
-// FindAll find orders
-func (u *orderUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Order, error) {
-	orders, err := u.orderPgRepo.FindAll(ctx, pagination)
+// FindAll find orders matching filter
+func (u *orderUseCase) FindAll(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error) {
+	orders, err := u.orderPgRepo.FindAll(ctx, filter, pagination)
 	if err != nil {
 		return nil, errors.Wrap(err, "orderPgRepo.FindAll")
 	}
@@ -48,34 +70,49 @@ func (u *orderUseCase) FindAll(ctx context.Context, pagination *utils.Pagination
 	return orders, nil
 }
 
-// FindAllByUserId find orders by user id
-func (u *orderUseCase) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
-	orders, err := u.orderPgRepo.FindAllByUserId(ctx, userID, pagination)
+// CountAll count orders matching filter
+func (u *orderUseCase) CountAll(ctx context.Context, filter *models.OrderFilter) (int, error) {
+	count, err := u.orderPgRepo.CountAll(ctx, filter)
 	if err != nil {
-		return nil, errors.Wrap(err, "orderPgRepo.FindAllByUserId")
+		return 0, errors.Wrap(err, "orderPgRepo.CountAll")
 	}
 
-	return orders, nil
+	return count, nil
+}
+
+// FindAllByUserId find orders by user id
+func (u *orderUseCase) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
+	return u.FindAll(ctx, &models.OrderFilter{UserID: &userID}, pagination)
 }
 
 // FindAllByLibrarianId find orders by librarian id
 func (u *orderUseCase) FindAllByLibrarianId(ctx context.Context, librarianID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
-	orders, err := u.orderPgRepo.FindAllByLibrarianId(ctx, librarianID, pagination)
+	return u.FindAll(ctx, &models.OrderFilter{LibrarianID: &librarianID}, pagination)
+}
+
+// FindAllByUserIdLibrarianId find orders by user id and librarian id
+func (u *orderUseCase) FindAllByUserIdLibrarianId(ctx context.Context, userID uuid.UUID, librarianID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
+	return u.FindAll(ctx, &models.OrderFilter{UserID: &userID, LibrarianID: &librarianID}, pagination)
+}
+
+// FindAllOverdue find picked up orders past their due date
+func (u *orderUseCase) FindAllOverdue(ctx context.Context, pagination *utils.Pagination) ([]models.Order, error) {
+	orders, err := u.orderPgRepo.FindAllOverdue(ctx, time.Now(), pagination)
 	if err != nil {
-		return nil, errors.Wrap(err, "orderPgRepo.FindAllByUserId")
+		return nil, errors.Wrap(err, "orderPgRepo.FindAllOverdue")
 	}
 
 	return orders, nil
 }
 
-// FindAllByUserIdLibrarianId find orders by librarian id
-func (u *orderUseCase) FindAllByUserIdLibrarianId(ctx context.Context, userID uuid.UUID, librarianID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
-	orders, err := u.orderPgRepo.FindAllByUserIdLibrarianId(ctx, userID, librarianID, pagination)
+// CountAllOverdue count picked up orders past their due date
+func (u *orderUseCase) CountAllOverdue(ctx context.Context) (int, error) {
+	count, err := u.orderPgRepo.CountAllOverdue(ctx, time.Now())
 	if err != nil {
-		return nil, errors.Wrap(err, "orderPgRepo.FindAllByUserIdLibrarianId")
+		return 0, errors.Wrap(err, "orderPgRepo.CountAllOverdue")
 	}
 
-	return orders, nil
+	return count, nil
 }
 
 // FindById find order by uuid
@@ -110,9 +147,28 @@ func (u *orderUseCase) CachedFindById(ctx context.Context, orderID uuid.UUID) (*
 	return foundOrder, nil
 }
 
-// UpdateById update order by uuid
+// CachedFindByIdForActor find order by uuid from cache, hiding orders the actor may not see as not found
+func (u *orderUseCase) CachedFindByIdForActor(ctx context.Context, orderID uuid.UUID, actorID uuid.UUID, actorRole string) (*models.Order, error) {
+	foundOrder, err := u.CachedFindById(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canView(foundOrder, actorID, actorRole) {
+		return nil, errors.Wrapf(grpc_errors.ErrNotFound, "order %s", orderID)
+	}
+
+	return foundOrder, nil
+}
+
+// UpdateById update order by uuid, keeping its status
 func (u *orderUseCase) UpdateById(ctx context.Context, order *models.Order) (*models.Order, error) {
-	updatedOrder, err := u.orderPgRepo.UpdateById(ctx, order)
+	return u.update(ctx, order, order.Status, models.OrderEventUpdated)
+}
+
+// update persist order which is still in status, refresh its cache entry and publish eventType
+func (u *orderUseCase) update(ctx context.Context, order *models.Order, status string, eventType string) (*models.Order, error) {
+	updatedOrder, err := u.orderPgRepo.UpdateById(ctx, order, status)
 	if err != nil {
 		return nil, errors.Wrap(err, "orderPgRepo.UpdateById")
 	}
@@ -120,20 +176,297 @@ func (u *orderUseCase) UpdateById(ctx context.Context, order *models.Order) (*mo
 	if err := u.redisRepo.SetOrderCtx(ctx, updatedOrder.OrderID.String(), orderByIdCacheDuration, updatedOrder); err != nil {
 		u.logger.Errorf("redisRepo.SetOrderCtx", err)
 	}
+	u.publish(eventType, updatedOrder)
+
+	return updatedOrder, nil
+}
+
+// AcceptById accept order with the copy set aside for the user's ready hold, or else reserve an available copy of the ordered book
+func (u *orderUseCase) AcceptById(ctx context.Context, orderID uuid.UUID, librarianID uuid.UUID) (*models.Order, error) {
+	foundOrder, err := u.findForTransition(ctx, orderID, models.OrderStatusAccepted, librarianID, models.LibrarianRole)
+	if err != nil {
+		return nil, err
+	}
+
+	claimedHold, err := u.holdUC.Claim(ctx, foundOrder.UserID, foundOrder.Item.BookKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "holdUC.Claim")
+	}
+
+	var copyID *uuid.UUID
+	if claimedHold != nil {
+		copyID = claimedHold.CopyID
+	} else {
+		reservedCopy, err := u.inventoryUC.ReserveByBookKey(ctx, foundOrder.Item.BookKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "inventoryUC.ReserveByBookKey")
+		}
+		copyID = &reservedCopy.CopyID
+	}
+	status := foundOrder.Status
+	foundOrder.CopyID = copyID
+	foundOrder.LibrarianID = &librarianID
+	foundOrder.Status = models.OrderStatusAccepted
+
+	updatedOrder, err := u.update(ctx, foundOrder, status, models.OrderStatusAccepted)
+	if err != nil {
+		// hand the copy back to where it came from, the user's hold or the shelf, also when another librarian
+		// accepted the order meanwhile
+		if claimedHold != nil {
+			if err := u.holdUC.Unclaim(ctx, claimedHold); err != nil {
+				u.logger.Errorf("holdUC.Unclaim: %v", err)
+			}
+		} else if err := u.inventoryUC.ReleaseById(ctx, *copyID); err != nil {
+			u.logger.Errorf("inventoryUC.ReleaseById", err)
+		}
+		return nil, err
+	}
+
+	return updatedOrder, nil
+}
+
+// RejectById reject pending order, keeping the reason for the user to see
+func (u *orderUseCase) RejectById(ctx context.Context, orderID uuid.UUID, librarianID uuid.UUID, reason string) (*models.Order, error) {
+	foundOrder, err := u.findForTransition(ctx, orderID, models.OrderStatusRejected, librarianID, models.LibrarianRole)
+	if err != nil {
+		return nil, err
+	}
+	status := foundOrder.Status
+	foundOrder.LibrarianID = &librarianID
+	foundOrder.Status = models.OrderStatusRejected
+	foundOrder.RejectReason = &reason
+
+	return u.update(ctx, foundOrder, status, models.OrderStatusRejected)
+}
+
+// CancelById cancel order which has not been picked up yet, releasing its reserved copy
+func (u *orderUseCase) CancelById(ctx context.Context, orderID uuid.UUID, actorID uuid.UUID, actorRole string) (*models.Order, error) {
+	foundOrder, err := u.findForTransition(ctx, orderID, models.OrderStatusCancelled, actorID, actorRole)
+	if err != nil {
+		return nil, err
+	}
+	status := foundOrder.Status
+	foundOrder.Status = models.OrderStatusCancelled
+
+	updatedOrder, err := u.update(ctx, foundOrder, status, models.OrderStatusCancelled)
+	if err != nil {
+		return nil, err
+	}
+	u.releaseCopy(ctx, updatedOrder)
+
+	return updatedOrder, nil
+}
+
+// PickUpById hand accepted order over to its user, starting the loan period
+func (u *orderUseCase) PickUpById(ctx context.Context, orderID uuid.UUID, librarianID uuid.UUID) (*models.Order, error) {
+	return u.transition(ctx, orderID, models.OrderStatusPickedUp, librarianID, func(order *models.Order) {
+		pickedUpAt := time.Now()
+		dueAt := pickedUpAt.AddDate(0, 0, u.loanPeriodDays())
+		order.PickedUpAt = &pickedUpAt
+		order.DueAt = &dueAt
+	})
+}
+
+// ReturnById take order back from its user, releasing its copy and fining late returns. A lost order returned
+// after all has its lost fine replaced by the overdue fine. A failed charge is returned although the order stays
+// returned
+func (u *orderUseCase) ReturnById(ctx context.Context, orderID uuid.UUID, librarianID uuid.UUID) (*models.Order, error) {
+	wasLost := false
+	updatedOrder, err := u.transition(ctx, orderID, models.OrderStatusReturned, librarianID, func(order *models.Order) {
+		wasLost = order.Status == models.OrderStatusLost
+		returnedAt := time.Now()
+		order.ReturnedAt = &returnedAt
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.releaseCopy(ctx, updatedOrder)
+
+	if wasLost {
+		if _, err := u.fineUC.ChargeReturnedLost(ctx, updatedOrder, librarianID); err != nil {
+			return nil, errors.Wrap(err, "fineUC.ChargeReturnedLost")
+		}
+		return updatedOrder, nil
+	}
+
+	if _, err := u.fineUC.ChargeOverdue(ctx, updatedOrder); err != nil {
+		return nil, errors.Wrap(err, "fineUC.ChargeOverdue")
+	}
+
+	return updatedOrder, nil
+}
+
+// RenewById extend due date of active loan by another loan period, an overdue loan is fined for the days it is late.
+// A failed charge is returned although the loan stays renewed
+func (u *orderUseCase) RenewById(ctx context.Context, orderID uuid.UUID, actorID uuid.UUID, actorRole string) (*models.Order, error) {
+	foundOrder, err := u.orderPgRepo.FindById(ctx, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, "orderPgRepo.FindById")
+	}
+
+	switch {
+	case actorRole == models.UserRoleUser && foundOrder.UserID != actorID:
+		return nil, errors.Wrap(grpc_errors.ErrPermissionDenied, "order belongs to another user")
+	case actorRole != models.UserRoleUser && actorRole != models.LibrarianRole:
+		return nil, errors.Wrapf(grpc_errors.ErrPermissionDenied, "%s may not renew order", actorRole)
+	case foundOrder.Status != models.OrderStatusPickedUp && foundOrder.Status != models.OrderStatusOverdue:
+		return nil, errors.Wrapf(grpc_errors.ErrInvalidStatus, "%s order can not be renewed", foundOrder.Status)
+	case foundOrder.RenewalCount >= u.maxRenewals():
+		return nil, errors.Wrapf(grpc_errors.ErrRenewalDenied, "renewal limit of %d reached", u.maxRenewals())
+	}
+
+	now := time.Now()
+	if foundOrder.DueAt != nil && now.After(foundOrder.DueAt.AddDate(0, 0, u.cfg.Loan.RenewalGraceDays)) {
+		return nil, errors.Wrap(grpc_errors.ErrRenewalDenied, "loan is overdue beyond grace period")
+	}
+
+	waiting, err := u.orderPgRepo.CountWaitingByBookKey(ctx, foundOrder.Item.BookKey, foundOrder.UserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "orderPgRepo.CountWaitingByBookKey")
+	}
+	holders, err := u.holdUC.CountWaitingByBookKey(ctx, foundOrder.Item.BookKey, foundOrder.UserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "holdUC.CountWaitingByBookKey")
+	}
+	if waiting > 0 || holders > 0 {
+		return nil, errors.Wrap(grpc_errors.ErrRenewalDenied, "book is reserved by another user")
+	}
+
+	// the days already late are fined up to now, the return only fines lateness past the new due date
+	var lateOrder *models.Order
+	if foundOrder.DueAt != nil && now.After(*foundOrder.DueAt) {
+		late := *foundOrder
+		late.ReturnedAt = &now
+		lateOrder = &late
+	}
+
+	dueFrom := now
+	if foundOrder.DueAt != nil && foundOrder.DueAt.After(now) {
+		dueFrom = *foundOrder.DueAt
+	}
+	status := foundOrder.Status
+	dueAt := dueFrom.AddDate(0, 0, u.loanPeriodDays())
+	foundOrder.DueAt = &dueAt
+	foundOrder.RenewalCount++
+	foundOrder.Status = models.OrderStatusPickedUp
+
+	renewedOrder, err := u.update(ctx, foundOrder, status, models.OrderEventRenewed)
+	if err != nil {
+		return nil, err
+	}
+
+	if lateOrder != nil {
+		if _, err := u.fineUC.ChargeOverdue(ctx, lateOrder); err != nil {
+			return nil, errors.Wrap(err, "fineUC.ChargeOverdue")
+		}
+	}
+
+	return renewedOrder, nil
+}
+
+// MarkOverdueById flag picked up order as overdue
+func (u *orderUseCase) MarkOverdueById(ctx context.Context, orderID uuid.UUID, librarianID uuid.UUID) (*models.Order, error) {
+	return u.transition(ctx, orderID, models.OrderStatusOverdue, librarianID, nil)
+}
+
+// MarkLostById flag picked up order as lost, taking its copy out of circulation and charging its user
+func (u *orderUseCase) MarkLostById(ctx context.Context, orderID uuid.UUID, librarianID uuid.UUID) (*models.Order, error) {
+	updatedOrder, err := u.transition(ctx, orderID, models.OrderStatusLost, librarianID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if updatedOrder.CopyID != nil {
+		if err := u.inventoryUC.MarkLostById(ctx, *updatedOrder.CopyID); err != nil {
+			u.logger.Errorf("inventoryUC.MarkLostById", err)
+		}
+	}
+
+	if _, err := u.fineUC.ChargeLost(ctx, updatedOrder); err != nil {
+		return nil, errors.Wrap(err, "fineUC.ChargeLost")
+	}
 
 	return updatedOrder, nil
 }
 
+// findForTransition find order and verify that actor may move it to status
+func (u *orderUseCase) findForTransition(ctx context.Context, orderID uuid.UUID, status string, actorID uuid.UUID, actorRole string) (*models.Order, error) {
+	foundOrder, err := u.orderPgRepo.FindById(ctx, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, "orderPgRepo.FindById")
+	}
+
+	if err := checkTransition(foundOrder, status, actorID, actorRole); err != nil {
+		return nil, err
+	}
+
+	return foundOrder, nil
+}
+
+// transition move order to status on behalf of librarian, apply sets any fields tied to the new status
+func (u *orderUseCase) transition(ctx context.Context, orderID uuid.UUID, status string, librarianID uuid.UUID, apply func(order *models.Order)) (*models.Order, error) {
+	foundOrder, err := u.findForTransition(ctx, orderID, status, librarianID, models.LibrarianRole)
+	if err != nil {
+		return nil, err
+	}
+	if foundOrder.LibrarianID == nil {
+		foundOrder.LibrarianID = &librarianID
+	}
+	// apply sees the status the order is leaving
+	if apply != nil {
+		apply(foundOrder)
+	}
+	previousStatus := foundOrder.Status
+	foundOrder.Status = status
+
+	return u.update(ctx, foundOrder, previousStatus, status)
+}
+
+func (u *orderUseCase) loanPeriodDays() int {
+	if u.cfg.Loan.PeriodDays > 0 {
+		return u.cfg.Loan.PeriodDays
+	}
+	return defaultLoanPeriodDays
+}
+
+func (u *orderUseCase) maxRenewals() int {
+	if u.cfg.Loan.MaxRenewals > 0 {
+		return u.cfg.Loan.MaxRenewals
+	}
+	return defaultMaxRenewals
+}
+
+// releaseCopy hand copy of order to the next holder of the book, or put it back in stock
+func (u *orderUseCase) releaseCopy(ctx context.Context, order *models.Order) {
+	if order.CopyID == nil {
+		return
+	}
+
+	if _, err := u.holdUC.Allocate(ctx, order.Item.BookKey, *order.CopyID); err != nil {
+		u.logger.Errorf("holdUC.Allocate", err)
+	}
+}
+
 // DeleteById delete order by uuid
 func (u *orderUseCase) DeleteById(ctx context.Context, orderID uuid.UUID) error {
-	err := u.orderPgRepo.DeleteById(ctx, orderID)
+	foundOrder, err := u.orderPgRepo.FindById(ctx, orderID)
 	if err != nil {
+		return errors.Wrap(err, "orderPgRepo.FindById")
+	}
+
+	if err := u.orderPgRepo.DeleteById(ctx, orderID); err != nil {
 		return errors.Wrap(err, "orderPgRepo.DeleteById")
 	}
 
 	if err := u.redisRepo.DeleteOrderCtx(ctx, orderID.String()); err != nil {
 		u.logger.Errorf("redisRepo.DeleteOrderCtx", err)
 	}
+	u.publish(models.OrderEventDeleted, foundOrder)
 
 	return nil
 }
+
+// publish hand a snapshot of order to the event hub
+func (u *orderUseCase) publish(eventType string, order *models.Order) {
+	u.hub.publish(models.OrderEvent{Type: eventType, Order: *order, OccurredAt: time.Now()})
+}
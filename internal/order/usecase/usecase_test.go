@@ -0,0 +1,676 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/config"
+	mockFine "github.com/dinorain/pinjembuku/internal/fine/mock"
+	fineRepository "github.com/dinorain/pinjembuku/internal/fine/repository"
+	fineUseCase "github.com/dinorain/pinjembuku/internal/fine/usecase"
+	mockHold "github.com/dinorain/pinjembuku/internal/hold/mock"
+	mockInventory "github.com/dinorain/pinjembuku/internal/inventory/mock"
+	"github.com/dinorain/pinjembuku/internal/models"
+	"github.com/dinorain/pinjembuku/internal/order/mock"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+)
+
+func TestOrderUseCase_AcceptById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderPGRepository := mock.NewMockOrderPGRepository(ctrl)
+	orderRedisRepository := mock.NewMockOrderRedisRepository(ctrl)
+	inventoryUC := mockInventory.NewMockInventoryUseCase(ctrl)
+	fineUC := mockFine.NewMockFineUseCase(ctrl)
+	holdUC := mockHold.NewMockHoldUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	orderUC := NewOrderUseCase(&config.Config{}, apiLogger, orderPGRepository, orderRedisRepository, inventoryUC, fineUC, holdUC)
+
+	ctx := context.Background()
+	librarianUUID := uuid.New()
+	newPendingOrder := func() *models.Order {
+		return &models.Order{
+			OrderID: uuid.New(),
+			UserID:  uuid.New(),
+			Item:    models.OrderItem{BookKey: "/works/OL66554W"},
+			Status:  models.OrderStatusPending,
+		}
+	}
+
+	t.Run("Reserve copy", func(t *testing.T) {
+		mockOrder := newPendingOrder()
+		copyUUID := uuid.New()
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+		holdUC.EXPECT().Claim(gomock.Any(), mockOrder.UserID, mockOrder.Item.BookKey).Return(nil, nil)
+		inventoryUC.EXPECT().ReserveByBookKey(gomock.Any(), mockOrder.Item.BookKey).Return(&models.Copy{CopyID: copyUUID}, nil)
+		orderPGRepository.EXPECT().UpdateById(gomock.Any(), mockOrder, gomock.Any()).Return(mockOrder, nil)
+		orderRedisRepository.EXPECT().SetOrderCtx(gomock.Any(), mockOrder.OrderID.String(), orderByIdCacheDuration, mockOrder).Return(nil)
+
+		acceptedOrder, err := orderUC.AcceptById(ctx, mockOrder.OrderID, librarianUUID)
+		require.NoError(t, err)
+		require.Equal(t, copyUUID, *acceptedOrder.CopyID)
+		require.Equal(t, librarianUUID, *acceptedOrder.LibrarianID)
+		require.Equal(t, models.OrderStatusAccepted, acceptedOrder.Status)
+	})
+
+	t.Run("No copy available", func(t *testing.T) {
+		mockOrder := newPendingOrder()
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+		holdUC.EXPECT().Claim(gomock.Any(), mockOrder.UserID, mockOrder.Item.BookKey).Return(nil, nil)
+		inventoryUC.EXPECT().ReserveByBookKey(gomock.Any(), mockOrder.Item.BookKey).Return(nil, grpc_errors.ErrNoCopyAvailable)
+
+		_, err := orderUC.AcceptById(ctx, mockOrder.OrderID, librarianUUID)
+		require.True(t, errors.Is(err, grpc_errors.ErrNoCopyAvailable))
+	})
+
+	t.Run("Ready hold", func(t *testing.T) {
+		mockOrder := newPendingOrder()
+		copyUUID := uuid.New()
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+		holdUC.EXPECT().Claim(gomock.Any(), mockOrder.UserID, mockOrder.Item.BookKey).Return(&models.Hold{HoldID: uuid.New(), CopyID: &copyUUID, Status: models.HoldStatusFulfilled}, nil)
+		orderPGRepository.EXPECT().UpdateById(gomock.Any(), mockOrder, gomock.Any()).Return(mockOrder, nil)
+		orderRedisRepository.EXPECT().SetOrderCtx(gomock.Any(), mockOrder.OrderID.String(), orderByIdCacheDuration, mockOrder).Return(nil)
+
+		acceptedOrder, err := orderUC.AcceptById(ctx, mockOrder.OrderID, librarianUUID)
+		require.NoError(t, err)
+		require.Equal(t, copyUUID, *acceptedOrder.CopyID)
+	})
+
+	t.Run("Ready hold update fails", func(t *testing.T) {
+		mockOrder := newPendingOrder()
+		copyUUID := uuid.New()
+		claimedHold := &models.Hold{HoldID: uuid.New(), CopyID: &copyUUID, Status: models.HoldStatusFulfilled}
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+		holdUC.EXPECT().Claim(gomock.Any(), mockOrder.UserID, mockOrder.Item.BookKey).Return(claimedHold, nil)
+		orderPGRepository.EXPECT().UpdateById(gomock.Any(), mockOrder, gomock.Any()).Return(nil, errors.New("db down"))
+		holdUC.EXPECT().Unclaim(gomock.Any(), claimedHold).Return(nil)
+
+		_, err := orderUC.AcceptById(ctx, mockOrder.OrderID, librarianUUID)
+		require.Error(t, err)
+	})
+
+	t.Run("Accepted meanwhile", func(t *testing.T) {
+		mockOrder := newPendingOrder()
+		copyUUID := uuid.New()
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+		holdUC.EXPECT().Claim(gomock.Any(), mockOrder.UserID, mockOrder.Item.BookKey).Return(nil, nil)
+		inventoryUC.EXPECT().ReserveByBookKey(gomock.Any(), mockOrder.Item.BookKey).Return(&models.Copy{CopyID: copyUUID}, nil)
+		orderPGRepository.EXPECT().UpdateById(gomock.Any(), mockOrder, models.OrderStatusPending).Return(nil, grpc_errors.ErrInvalidStatus)
+		inventoryUC.EXPECT().ReleaseById(gomock.Any(), copyUUID).Return(nil)
+
+		_, err := orderUC.AcceptById(ctx, mockOrder.OrderID, librarianUUID)
+		require.True(t, errors.Is(err, grpc_errors.ErrInvalidStatus))
+	})
+
+	t.Run("Already accepted", func(t *testing.T) {
+		mockOrder := newPendingOrder()
+		mockOrder.Status = models.OrderStatusAccepted
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+
+		_, err := orderUC.AcceptById(ctx, mockOrder.OrderID, librarianUUID)
+		require.True(t, errors.Is(err, grpc_errors.ErrInvalidStatus))
+	})
+}
+
+func TestOrderUseCase_CancelById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderPGRepository := mock.NewMockOrderPGRepository(ctrl)
+	orderRedisRepository := mock.NewMockOrderRedisRepository(ctrl)
+	inventoryUC := mockInventory.NewMockInventoryUseCase(ctrl)
+	fineUC := mockFine.NewMockFineUseCase(ctrl)
+	holdUC := mockHold.NewMockHoldUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	orderUC := NewOrderUseCase(&config.Config{}, apiLogger, orderPGRepository, orderRedisRepository, inventoryUC, fineUC, holdUC)
+
+	ctx := context.Background()
+	copyUUID := uuid.New()
+	newAcceptedOrder := func() *models.Order {
+		return &models.Order{
+			OrderID: uuid.New(),
+			UserID:  uuid.New(),
+			CopyID:  &copyUUID,
+			Item:    models.OrderItem{BookKey: "/works/OL66554W"},
+			Status:  models.OrderStatusAccepted,
+		}
+	}
+
+	t.Run("Release copy", func(t *testing.T) {
+		mockOrder := newAcceptedOrder()
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+		orderPGRepository.EXPECT().UpdateById(gomock.Any(), mockOrder, gomock.Any()).Return(mockOrder, nil)
+		orderRedisRepository.EXPECT().SetOrderCtx(gomock.Any(), mockOrder.OrderID.String(), orderByIdCacheDuration, mockOrder).Return(nil)
+		holdUC.EXPECT().Allocate(gomock.Any(), mockOrder.Item.BookKey, copyUUID).Return(nil, nil)
+
+		cancelledOrder, err := orderUC.CancelById(ctx, mockOrder.OrderID, mockOrder.UserID, models.UserRoleUser)
+		require.NoError(t, err)
+		require.Equal(t, models.OrderStatusCancelled, cancelledOrder.Status)
+	})
+
+	t.Run("Other user", func(t *testing.T) {
+		mockOrder := newAcceptedOrder()
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+
+		_, err := orderUC.CancelById(ctx, mockOrder.OrderID, uuid.New(), models.UserRoleUser)
+		require.True(t, errors.Is(err, grpc_errors.ErrPermissionDenied))
+	})
+
+	t.Run("Already picked up", func(t *testing.T) {
+		mockOrder := newAcceptedOrder()
+		mockOrder.Status = models.OrderStatusPickedUp
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+
+		_, err := orderUC.CancelById(ctx, mockOrder.OrderID, mockOrder.UserID, models.UserRoleUser)
+		require.True(t, errors.Is(err, grpc_errors.ErrInvalidStatus))
+	})
+}
+
+func TestOrderUseCase_RejectById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderPGRepository := mock.NewMockOrderPGRepository(ctrl)
+	orderRedisRepository := mock.NewMockOrderRedisRepository(ctrl)
+	inventoryUC := mockInventory.NewMockInventoryUseCase(ctrl)
+	fineUC := mockFine.NewMockFineUseCase(ctrl)
+	holdUC := mockHold.NewMockHoldUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	orderUC := NewOrderUseCase(&config.Config{}, apiLogger, orderPGRepository, orderRedisRepository, inventoryUC, fineUC, holdUC)
+
+	ctx := context.Background()
+	librarianUUID := uuid.New()
+	reason := "Book is reserved for a class reading list"
+
+	t.Run("Store reason", func(t *testing.T) {
+		mockOrder := &models.Order{OrderID: uuid.New(), UserID: uuid.New(), Status: models.OrderStatusPending}
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+		orderPGRepository.EXPECT().UpdateById(gomock.Any(), mockOrder, gomock.Any()).Return(mockOrder, nil)
+		orderRedisRepository.EXPECT().SetOrderCtx(gomock.Any(), mockOrder.OrderID.String(), orderByIdCacheDuration, mockOrder).Return(nil)
+
+		rejectedOrder, err := orderUC.RejectById(ctx, mockOrder.OrderID, librarianUUID, reason)
+		require.NoError(t, err)
+		require.Equal(t, models.OrderStatusRejected, rejectedOrder.Status)
+		require.Equal(t, reason, *rejectedOrder.RejectReason)
+		require.Equal(t, librarianUUID, *rejectedOrder.LibrarianID)
+	})
+
+	t.Run("Already accepted", func(t *testing.T) {
+		mockOrder := &models.Order{OrderID: uuid.New(), UserID: uuid.New(), Status: models.OrderStatusAccepted}
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+
+		_, err := orderUC.RejectById(ctx, mockOrder.OrderID, librarianUUID, reason)
+		require.True(t, errors.Is(err, grpc_errors.ErrInvalidStatus))
+	})
+}
+
+func TestOrderUseCase_CachedFindByIdForActor(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderPGRepository := mock.NewMockOrderPGRepository(ctrl)
+	orderRedisRepository := mock.NewMockOrderRedisRepository(ctrl)
+	inventoryUC := mockInventory.NewMockInventoryUseCase(ctrl)
+	fineUC := mockFine.NewMockFineUseCase(ctrl)
+	holdUC := mockHold.NewMockHoldUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	orderUC := NewOrderUseCase(&config.Config{}, apiLogger, orderPGRepository, orderRedisRepository, inventoryUC, fineUC, holdUC)
+
+	ctx := context.Background()
+	librarianUUID := uuid.New()
+	mockOrder := &models.Order{OrderID: uuid.New(), UserID: uuid.New(), LibrarianID: &librarianUUID, Status: models.OrderStatusAccepted}
+	orderRedisRepository.EXPECT().GetByIdCtx(gomock.Any(), mockOrder.OrderID.String()).AnyTimes().Return(mockOrder, nil)
+
+	testCases := []struct {
+		name    string
+		actorID uuid.UUID
+		role    string
+		visible bool
+	}{
+		{"owner", mockOrder.UserID, models.UserRoleUser, true},
+		{"other user", uuid.New(), models.UserRoleUser, false},
+		{"assigned librarian", librarianUUID, models.LibrarianRole, true},
+		{"other librarian", uuid.New(), models.LibrarianRole, false},
+		{"admin", uuid.New(), models.UserRoleAdmin, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			foundOrder, err := orderUC.CachedFindByIdForActor(ctx, mockOrder.OrderID, tc.actorID, tc.role)
+			if tc.visible {
+				require.NoError(t, err)
+				require.Equal(t, mockOrder.OrderID, foundOrder.OrderID)
+				return
+			}
+			require.True(t, errors.Is(err, grpc_errors.ErrNotFound))
+		})
+	}
+
+	t.Run("unassigned order", func(t *testing.T) {
+		unassignedOrder := &models.Order{OrderID: uuid.New(), UserID: uuid.New(), Status: models.OrderStatusPending}
+		orderRedisRepository.EXPECT().GetByIdCtx(gomock.Any(), unassignedOrder.OrderID.String()).Return(unassignedOrder, nil)
+
+		_, err := orderUC.CachedFindByIdForActor(ctx, unassignedOrder.OrderID, uuid.New(), models.LibrarianRole)
+		require.NoError(t, err)
+	})
+}
+
+func TestCheckTransition(t *testing.T) {
+	t.Parallel()
+
+	userUUID := uuid.New()
+	testCases := []struct {
+		name   string
+		from   string
+		to     string
+		role   string
+		target error
+	}{
+		{"pending to accepted", models.OrderStatusPending, models.OrderStatusAccepted, models.LibrarianRole, nil},
+		{"accepted to picked up", models.OrderStatusAccepted, models.OrderStatusPickedUp, models.LibrarianRole, nil},
+		{"picked up to overdue", models.OrderStatusPickedUp, models.OrderStatusOverdue, models.LibrarianRole, nil},
+		{"overdue to returned", models.OrderStatusOverdue, models.OrderStatusReturned, models.LibrarianRole, nil},
+		{"overdue to lost", models.OrderStatusOverdue, models.OrderStatusLost, models.LibrarianRole, nil},
+		{"pending to picked up", models.OrderStatusPending, models.OrderStatusPickedUp, models.LibrarianRole, grpc_errors.ErrInvalidStatus},
+		{"returned to lost", models.OrderStatusReturned, models.OrderStatusLost, models.LibrarianRole, grpc_errors.ErrInvalidStatus},
+		{"cancelled to accepted", models.OrderStatusCancelled, models.OrderStatusAccepted, models.LibrarianRole, grpc_errors.ErrInvalidStatus},
+		{"user accepts", models.OrderStatusPending, models.OrderStatusAccepted, models.UserRoleUser, grpc_errors.ErrPermissionDenied},
+		{"librarian cancels", models.OrderStatusPending, models.OrderStatusCancelled, models.LibrarianRole, grpc_errors.ErrPermissionDenied},
+		{"admin cancels", models.OrderStatusAccepted, models.OrderStatusCancelled, models.UserRoleAdmin, grpc_errors.ErrPermissionDenied},
+	}
+
+	for _, tc := range testCases {
+		err := checkTransition(&models.Order{UserID: userUUID, Status: tc.from}, tc.to, userUUID, tc.role)
+		if tc.target == nil {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.True(t, errors.Is(err, tc.target), tc.name)
+		}
+	}
+}
+
+func TestOrderUseCase_PickUpById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderPGRepository := mock.NewMockOrderPGRepository(ctrl)
+	orderRedisRepository := mock.NewMockOrderRedisRepository(ctrl)
+	inventoryUC := mockInventory.NewMockInventoryUseCase(ctrl)
+	fineUC := mockFine.NewMockFineUseCase(ctrl)
+	holdUC := mockHold.NewMockHoldUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	orderUC := NewOrderUseCase(&config.Config{Loan: config.Loan{PeriodDays: 7}}, apiLogger, orderPGRepository, orderRedisRepository, inventoryUC, fineUC, holdUC)
+
+	librarianUUID := uuid.New()
+	mockOrder := &models.Order{
+		OrderID:     uuid.New(),
+		UserID:      uuid.New(),
+		LibrarianID: &librarianUUID,
+		Status:      models.OrderStatusAccepted,
+	}
+
+	orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+	orderPGRepository.EXPECT().UpdateById(gomock.Any(), mockOrder, gomock.Any()).Return(mockOrder, nil)
+	orderRedisRepository.EXPECT().SetOrderCtx(gomock.Any(), mockOrder.OrderID.String(), orderByIdCacheDuration, mockOrder).Return(nil)
+
+	pickedUpOrder, err := orderUC.PickUpById(context.Background(), mockOrder.OrderID, librarianUUID)
+	require.NoError(t, err)
+	require.Equal(t, models.OrderStatusPickedUp, pickedUpOrder.Status)
+	require.NotNil(t, pickedUpOrder.PickedUpAt)
+	require.Equal(t, pickedUpOrder.PickedUpAt.AddDate(0, 0, 7), *pickedUpOrder.DueAt)
+}
+
+func TestOrderUseCase_ReturnById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderPGRepository := mock.NewMockOrderPGRepository(ctrl)
+	orderRedisRepository := mock.NewMockOrderRedisRepository(ctrl)
+	inventoryUC := mockInventory.NewMockInventoryUseCase(ctrl)
+	fineUC := mockFine.NewMockFineUseCase(ctrl)
+	holdUC := mockHold.NewMockHoldUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	orderUC := NewOrderUseCase(&config.Config{}, apiLogger, orderPGRepository, orderRedisRepository, inventoryUC, fineUC, holdUC)
+
+	librarianUUID := uuid.New()
+	copyUUID := uuid.New()
+	dueAt := time.Now().AddDate(0, 0, -3)
+	mockOrder := &models.Order{
+		OrderID:     uuid.New(),
+		UserID:      uuid.New(),
+		LibrarianID: &librarianUUID,
+		CopyID:      &copyUUID,
+		Status:      models.OrderStatusOverdue,
+		DueAt:       &dueAt,
+	}
+
+	orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+	orderPGRepository.EXPECT().UpdateById(gomock.Any(), mockOrder, gomock.Any()).Return(mockOrder, nil)
+	orderRedisRepository.EXPECT().SetOrderCtx(gomock.Any(), mockOrder.OrderID.String(), orderByIdCacheDuration, mockOrder).Return(nil)
+	holdUC.EXPECT().Allocate(gomock.Any(), mockOrder.Item.BookKey, copyUUID).Return(&models.Hold{Status: models.HoldStatusReady}, nil)
+	fineUC.EXPECT().ChargeOverdue(gomock.Any(), mockOrder).Return(&models.Fine{Kind: models.FineKindOverdue}, nil)
+
+	returnedOrder, err := orderUC.ReturnById(context.Background(), mockOrder.OrderID, librarianUUID)
+	require.NoError(t, err)
+	require.Equal(t, models.OrderStatusReturned, returnedOrder.Status)
+	require.NotNil(t, returnedOrder.ReturnedAt)
+
+	failedOrder := &models.Order{OrderID: uuid.New(), UserID: uuid.New(), LibrarianID: &librarianUUID, Status: models.OrderStatusOverdue, DueAt: &dueAt}
+	chargeErr := errors.New("fines unavailable")
+	orderPGRepository.EXPECT().FindById(gomock.Any(), failedOrder.OrderID).Return(failedOrder, nil)
+	orderPGRepository.EXPECT().UpdateById(gomock.Any(), failedOrder, gomock.Any()).Return(failedOrder, nil)
+	orderRedisRepository.EXPECT().SetOrderCtx(gomock.Any(), failedOrder.OrderID.String(), orderByIdCacheDuration, failedOrder).Return(nil)
+	fineUC.EXPECT().ChargeOverdue(gomock.Any(), failedOrder).Return(nil, chargeErr)
+
+	_, err = orderUC.ReturnById(context.Background(), failedOrder.OrderID, librarianUUID)
+	require.True(t, errors.Is(err, chargeErr))
+}
+
+func TestOrderUseCase_ReturnById_Lost(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderPGRepository := mock.NewMockOrderPGRepository(ctrl)
+	orderRedisRepository := mock.NewMockOrderRedisRepository(ctrl)
+	inventoryUC := mockInventory.NewMockInventoryUseCase(ctrl)
+	fineUC := mockFine.NewMockFineUseCase(ctrl)
+	holdUC := mockHold.NewMockHoldUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	orderUC := NewOrderUseCase(&config.Config{}, apiLogger, orderPGRepository, orderRedisRepository, inventoryUC, fineUC, holdUC)
+
+	librarianUUID := uuid.New()
+	copyUUID := uuid.New()
+	dueAt := time.Now().AddDate(0, 0, -30)
+	mockOrder := &models.Order{
+		OrderID:     uuid.New(),
+		UserID:      uuid.New(),
+		LibrarianID: &librarianUUID,
+		CopyID:      &copyUUID,
+		Status:      models.OrderStatusLost,
+		DueAt:       &dueAt,
+	}
+
+	orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+	orderPGRepository.EXPECT().UpdateById(gomock.Any(), mockOrder, gomock.Any()).Return(mockOrder, nil)
+	orderRedisRepository.EXPECT().SetOrderCtx(gomock.Any(), mockOrder.OrderID.String(), orderByIdCacheDuration, mockOrder).Return(nil)
+	holdUC.EXPECT().Allocate(gomock.Any(), mockOrder.Item.BookKey, copyUUID).Return(nil, nil)
+	fineUC.EXPECT().ChargeReturnedLost(gomock.Any(), mockOrder, librarianUUID).Return(&models.Fine{Kind: models.FineKindOverdue}, nil)
+
+	returnedOrder, err := orderUC.ReturnById(context.Background(), mockOrder.OrderID, librarianUUID)
+	require.NoError(t, err)
+	require.Equal(t, models.OrderStatusReturned, returnedOrder.Status)
+}
+
+func TestOrderUseCase_RenewById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderPGRepository := mock.NewMockOrderPGRepository(ctrl)
+	orderRedisRepository := mock.NewMockOrderRedisRepository(ctrl)
+	inventoryUC := mockInventory.NewMockInventoryUseCase(ctrl)
+	fineUC := mockFine.NewMockFineUseCase(ctrl)
+	holdUC := mockHold.NewMockHoldUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+	cfg := &config.Config{Loan: config.Loan{PeriodDays: 14, MaxRenewals: 2, RenewalGraceDays: 2}}
+
+	orderUC := NewOrderUseCase(cfg, apiLogger, orderPGRepository, orderRedisRepository, inventoryUC, fineUC, holdUC)
+
+	ctx := context.Background()
+	newActiveOrder := func(dueAt time.Time) *models.Order {
+		return &models.Order{
+			OrderID: uuid.New(),
+			UserID:  uuid.New(),
+			Item:    models.OrderItem{BookKey: "/works/OL66554W"},
+			Status:  models.OrderStatusPickedUp,
+			DueAt:   &dueAt,
+		}
+	}
+
+	t.Run("Renew", func(t *testing.T) {
+		dueAt := time.Now().AddDate(0, 0, 3)
+		mockOrder := newActiveOrder(dueAt)
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+		orderPGRepository.EXPECT().CountWaitingByBookKey(gomock.Any(), mockOrder.Item.BookKey, mockOrder.UserID).Return(0, nil)
+		holdUC.EXPECT().CountWaitingByBookKey(gomock.Any(), mockOrder.Item.BookKey, mockOrder.UserID).Return(0, nil)
+		orderPGRepository.EXPECT().UpdateById(gomock.Any(), mockOrder, gomock.Any()).Return(mockOrder, nil)
+		orderRedisRepository.EXPECT().SetOrderCtx(gomock.Any(), mockOrder.OrderID.String(), orderByIdCacheDuration, mockOrder).Return(nil)
+
+		renewedOrder, err := orderUC.RenewById(ctx, mockOrder.OrderID, mockOrder.UserID, models.UserRoleUser)
+		require.NoError(t, err)
+		require.Equal(t, 1, renewedOrder.RenewalCount)
+		require.Equal(t, dueAt.AddDate(0, 0, 14), *renewedOrder.DueAt)
+	})
+
+	t.Run("Overdue within grace period", func(t *testing.T) {
+		dueAt := time.Now().AddDate(0, 0, -1)
+		mockOrder := newActiveOrder(dueAt)
+		mockOrder.Status = models.OrderStatusOverdue
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+		orderPGRepository.EXPECT().CountWaitingByBookKey(gomock.Any(), mockOrder.Item.BookKey, mockOrder.UserID).Return(0, nil)
+		holdUC.EXPECT().CountWaitingByBookKey(gomock.Any(), mockOrder.Item.BookKey, mockOrder.UserID).Return(0, nil)
+		orderPGRepository.EXPECT().UpdateById(gomock.Any(), mockOrder, gomock.Any()).Return(mockOrder, nil)
+		orderRedisRepository.EXPECT().SetOrderCtx(gomock.Any(), mockOrder.OrderID.String(), orderByIdCacheDuration, mockOrder).Return(nil)
+		fineUC.EXPECT().ChargeOverdue(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, lateOrder *models.Order) (*models.Fine, error) {
+			require.Equal(t, dueAt, *lateOrder.DueAt, "late days are fined against the old due date")
+			require.NotNil(t, lateOrder.ReturnedAt)
+			return &models.Fine{Kind: models.FineKindOverdue}, nil
+		})
+
+		renewedOrder, err := orderUC.RenewById(ctx, mockOrder.OrderID, mockOrder.UserID, models.UserRoleUser)
+		require.NoError(t, err)
+		require.Equal(t, models.OrderStatusPickedUp, renewedOrder.Status)
+		require.True(t, renewedOrder.DueAt.After(time.Now().AddDate(0, 0, 13)))
+	})
+
+	t.Run("Limit reached", func(t *testing.T) {
+		mockOrder := newActiveOrder(time.Now().AddDate(0, 0, 3))
+		mockOrder.RenewalCount = 2
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+
+		_, err := orderUC.RenewById(ctx, mockOrder.OrderID, mockOrder.UserID, models.UserRoleUser)
+		require.True(t, errors.Is(err, grpc_errors.ErrRenewalDenied))
+	})
+
+	t.Run("Overdue beyond grace period", func(t *testing.T) {
+		mockOrder := newActiveOrder(time.Now().AddDate(0, 0, -3))
+		mockOrder.Status = models.OrderStatusOverdue
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+
+		_, err := orderUC.RenewById(ctx, mockOrder.OrderID, mockOrder.UserID, models.UserRoleUser)
+		require.True(t, errors.Is(err, grpc_errors.ErrRenewalDenied))
+	})
+
+	t.Run("Reserved by another user", func(t *testing.T) {
+		mockOrder := newActiveOrder(time.Now().AddDate(0, 0, 3))
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+		orderPGRepository.EXPECT().CountWaitingByBookKey(gomock.Any(), mockOrder.Item.BookKey, mockOrder.UserID).Return(0, nil)
+		holdUC.EXPECT().CountWaitingByBookKey(gomock.Any(), mockOrder.Item.BookKey, mockOrder.UserID).Return(1, nil)
+
+		_, err := orderUC.RenewById(ctx, mockOrder.OrderID, mockOrder.UserID, models.UserRoleUser)
+		require.True(t, errors.Is(err, grpc_errors.ErrRenewalDenied))
+	})
+
+	t.Run("Other user", func(t *testing.T) {
+		mockOrder := newActiveOrder(time.Now().AddDate(0, 0, 3))
+		orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+
+		_, err := orderUC.RenewById(ctx, mockOrder.OrderID, uuid.New(), models.UserRoleUser)
+		require.True(t, errors.Is(err, grpc_errors.ErrPermissionDenied))
+	})
+}
+
+func TestOrderUseCase_RenewById_ReturnLate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	orderPGRepository := mock.NewMockOrderPGRepository(ctrl)
+	orderRedisRepository := mock.NewMockOrderRedisRepository(ctrl)
+	inventoryUC := mockInventory.NewMockInventoryUseCase(ctrl)
+	holdUC := mockHold.NewMockHoldUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+	cfg := &config.Config{
+		Loan: config.Loan{PeriodDays: 14, MaxRenewals: 2, RenewalGraceDays: 2},
+		Fine: config.Fine{OverduePerDay: 1000, OverdueCap: 50000},
+	}
+	fineUC := fineUseCase.NewFineUseCase(cfg, apiLogger, fineRepository.NewFinePGRepository(sqlxDB))
+
+	orderUC := NewOrderUseCase(cfg, apiLogger, orderPGRepository, orderRedisRepository, inventoryUC, fineUC, holdUC)
+
+	ctx := context.Background()
+	librarianUUID := uuid.New()
+	fineUUID := uuid.New()
+	dueAt := time.Now().Add(-time.Hour)
+	mockOrder := &models.Order{
+		OrderID:     uuid.New(),
+		UserID:      uuid.New(),
+		LibrarianID: &librarianUUID,
+		Item:        models.OrderItem{BookKey: "/works/OL66554W"},
+		Status:      models.OrderStatusOverdue,
+		DueAt:       &dueAt,
+	}
+	fineColumns := []string{"fine_id", "order_id", "user_id", "librarian_id", "kind", "amount", "status", "note", "settled_at", "created_at", "updated_at"}
+	createFine := `INSERT INTO fines .* ON CONFLICT \(order_id, kind\) WHERE status = 'outstanding' DO UPDATE SET amount = fines.amount \+ EXCLUDED.amount`
+
+	// renewing one day late fines that day
+	orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+	orderPGRepository.EXPECT().CountWaitingByBookKey(gomock.Any(), mockOrder.Item.BookKey, mockOrder.UserID).Return(0, nil)
+	holdUC.EXPECT().CountWaitingByBookKey(gomock.Any(), mockOrder.Item.BookKey, mockOrder.UserID).Return(0, nil)
+	orderPGRepository.EXPECT().UpdateById(gomock.Any(), mockOrder, gomock.Any()).Return(mockOrder, nil)
+	orderRedisRepository.EXPECT().SetOrderCtx(gomock.Any(), mockOrder.OrderID.String(), orderByIdCacheDuration, mockOrder).Return(nil)
+	sqlMock.ExpectQuery(createFine).
+		WithArgs(mockOrder.OrderID, mockOrder.UserID, models.FineKindOverdue, int64(1000), models.FineStatusOutstanding).
+		WillReturnRows(sqlmock.NewRows(fineColumns).AddRow(
+			fineUUID, mockOrder.OrderID, mockOrder.UserID, nil, models.FineKindOverdue, int64(1000), models.FineStatusOutstanding, nil, nil, time.Now(), time.Now(),
+		))
+
+	renewedOrder, err := orderUC.RenewById(ctx, mockOrder.OrderID, mockOrder.UserID, models.UserRoleUser)
+	require.NoError(t, err)
+
+	// returning two days past the new due date adds those days to the same fine
+	newDueAt := time.Now().Add(-36 * time.Hour)
+	renewedOrder.DueAt = &newDueAt
+	orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(renewedOrder, nil)
+	orderPGRepository.EXPECT().UpdateById(gomock.Any(), renewedOrder, gomock.Any()).Return(renewedOrder, nil)
+	orderRedisRepository.EXPECT().SetOrderCtx(gomock.Any(), mockOrder.OrderID.String(), orderByIdCacheDuration, renewedOrder).Return(nil)
+	sqlMock.ExpectQuery(createFine).
+		WithArgs(mockOrder.OrderID, mockOrder.UserID, models.FineKindOverdue, int64(2000), models.FineStatusOutstanding).
+		WillReturnRows(sqlmock.NewRows(fineColumns).AddRow(
+			fineUUID, mockOrder.OrderID, mockOrder.UserID, nil, models.FineKindOverdue, int64(3000), models.FineStatusOutstanding, nil, nil, time.Now(), time.Now(),
+		))
+
+	returnedOrder, err := orderUC.ReturnById(ctx, mockOrder.OrderID, librarianUUID)
+	require.NoError(t, err)
+	require.Equal(t, models.OrderStatusReturned, returnedOrder.Status)
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestOrderUseCase_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderPGRepository := mock.NewMockOrderPGRepository(ctrl)
+	orderRedisRepository := mock.NewMockOrderRedisRepository(ctrl)
+	inventoryUC := mockInventory.NewMockInventoryUseCase(ctrl)
+	fineUC := mockFine.NewMockFineUseCase(ctrl)
+	holdUC := mockHold.NewMockHoldUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	orderUC := NewOrderUseCase(&config.Config{}, apiLogger, orderPGRepository, orderRedisRepository, inventoryUC, fineUC, holdUC)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	userUUID := uuid.New()
+	librarianUUID := uuid.New()
+
+	userEvents := orderUC.Subscribe(ctx, userUUID, models.UserRoleUser)
+	librarianEvents := orderUC.Subscribe(ctx, librarianUUID, models.LibrarianRole)
+	otherLibrarianEvents := orderUC.Subscribe(ctx, uuid.New(), models.LibrarianRole)
+
+	ownOrder := &models.Order{OrderID: uuid.New(), UserID: userUUID, Item: models.OrderItem{BookKey: "/works/OL66554W"}, Status: models.OrderStatusPending}
+	otherOrder := &models.Order{OrderID: uuid.New(), UserID: uuid.New(), Item: models.OrderItem{BookKey: "/works/OL66554W"}, Status: models.OrderStatusPending}
+	orderPGRepository.EXPECT().Create(gomock.Any(), ownOrder).Return(ownOrder, nil)
+	orderPGRepository.EXPECT().Create(gomock.Any(), otherOrder).Return(otherOrder, nil)
+
+	_, err := orderUC.Create(ctx, ownOrder)
+	require.NoError(t, err)
+	_, err = orderUC.Create(ctx, otherOrder)
+	require.NoError(t, err)
+
+	event := <-userEvents
+	require.Equal(t, models.OrderEventCreated, event.Type)
+	require.Equal(t, ownOrder.OrderID, event.Order.OrderID)
+	require.Len(t, userEvents, 0)
+
+	require.Len(t, librarianEvents, 2)
+	require.Len(t, otherLibrarianEvents, 2)
+	<-librarianEvents
+	<-librarianEvents
+	<-otherLibrarianEvents
+	<-otherLibrarianEvents
+
+	copyUUID := uuid.New()
+	orderPGRepository.EXPECT().FindById(gomock.Any(), ownOrder.OrderID).Return(ownOrder, nil)
+	holdUC.EXPECT().Claim(gomock.Any(), userUUID, ownOrder.Item.BookKey).Return(&models.Hold{CopyID: &copyUUID, Status: models.HoldStatusFulfilled}, nil)
+	orderPGRepository.EXPECT().UpdateById(gomock.Any(), ownOrder, gomock.Any()).Return(ownOrder, nil)
+	orderRedisRepository.EXPECT().SetOrderCtx(gomock.Any(), ownOrder.OrderID.String(), orderByIdCacheDuration, ownOrder).Return(nil)
+
+	_, err = orderUC.AcceptById(ctx, ownOrder.OrderID, librarianUUID)
+	require.NoError(t, err)
+
+	event = <-userEvents
+	require.Equal(t, models.OrderStatusAccepted, event.Type)
+	require.Equal(t, models.OrderStatusAccepted, event.Order.Status)
+
+	event = <-librarianEvents
+	require.Equal(t, models.OrderStatusAccepted, event.Type)
+
+	// the order now belongs to another librarian's desk
+	require.Len(t, otherLibrarianEvents, 0)
+
+	cancel()
+	_, open := <-userEvents
+	require.False(t, open)
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/dinorain/pinjembuku/internal/book"
+	"github.com/dinorain/pinjembuku/internal/interceptors"
+	"github.com/dinorain/pinjembuku/internal/librarian"
+	"github.com/dinorain/pinjembuku/internal/order"
+	"github.com/dinorain/pinjembuku/internal/session"
+	"github.com/dinorain/pinjembuku/internal/user"
+
+	bookGRPC "github.com/dinorain/pinjembuku/internal/book/delivery/grpc/service"
+	librarianGRPC "github.com/dinorain/pinjembuku/internal/librarian/delivery/grpc/service"
+	orderGRPC "github.com/dinorain/pinjembuku/internal/order/delivery/grpc/service"
+	userGRPC "github.com/dinorain/pinjembuku/internal/user/delivery/grpc/service"
+
+	userService "github.com/dinorain/pinjembuku/proto"
+	bookService "github.com/dinorain/pinjembuku/proto/book"
+	librarianService "github.com/dinorain/pinjembuku/proto/librarian"
+	orderService "github.com/dinorain/pinjembuku/proto/order"
+)
+
+// newGrpcServer builds the grpc server with keepalive settings from config, the logger and auth interceptors,
+// reflection and the standard health service
+func (s *Server) newGrpcServer(
+	userUC user.UserUseCase,
+	librarianUC librarian.LibrarianUseCase,
+	bookUC book.BookUseCase,
+	orderUC order.OrderUseCase,
+	sessUC session.SessUseCase,
+) (*grpc.Server, *health.Server) {
+	im := interceptors.NewInterceptorManager(s.logger, s.cfg, s.tokens, sessUC)
+
+	grpcServer := grpc.NewServer(
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle: s.cfg.Server.MaxConnectionIdle * time.Minute,
+			Timeout:           s.cfg.Server.Timeout * time.Second,
+			MaxConnectionAge:  s.cfg.Server.MaxConnectionAge * time.Minute,
+			Time:              s.cfg.Server.Time * time.Second,
+		}),
+		grpc.ChainUnaryInterceptor(im.Logger, im.Auth),
+		grpc.ChainStreamInterceptor(im.StreamLogger, im.StreamAuth),
+	)
+
+	userService.RegisterUserServiceServer(grpcServer, userGRPC.NewAuthServerGRPC(s.logger, s.cfg, s.tokens, userUC, sessUC))
+	librarianService.RegisterLibrarianServiceServer(grpcServer, librarianGRPC.NewLibrarianServerGRPC(s.logger, s.cfg, s.tokens, librarianUC, sessUC))
+	bookService.RegisterBookServiceServer(grpcServer, bookGRPC.NewBookServerGRPC(s.logger, s.cfg, bookUC))
+	orderService.RegisterOrderServiceServer(grpcServer, orderGRPC.NewOrderServerGRPC(s.logger, s.cfg, orderUC, bookUC, userUC))
+
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+
+	reflection.Register(grpcServer)
+
+	return grpcServer, healthServer
+}
+
+// stopGrpcServer waits for pending rpcs to finish and closes remaining connections once ctx is done
+func (s *Server) stopGrpcServer(ctx context.Context, grpcServer *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.logger.Warn("grpcServer.GracefulStop timed out, forcing stop")
+		grpcServer.Stop()
+	}
+}
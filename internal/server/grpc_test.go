@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/dinorain/pinjembuku/config"
+	mockBookUC "github.com/dinorain/pinjembuku/internal/book/mock"
+	mockLibrarianUC "github.com/dinorain/pinjembuku/internal/librarian/mock"
+	mockOrderUC "github.com/dinorain/pinjembuku/internal/order/mock"
+	mockSessUC "github.com/dinorain/pinjembuku/internal/session/mock"
+	mockUserUC "github.com/dinorain/pinjembuku/internal/user/mock"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+)
+
+func TestServer_NewGrpcServer(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+
+	s := NewAppServer(appLogger, cfg, nil, nil)
+	grpcServer, healthServer := s.newGrpcServer(
+		mockUserUC.NewMockUserUseCase(ctrl),
+		mockLibrarianUC.NewMockLibrarianUseCase(ctrl),
+		mockBookUC.NewMockBookUseCase(ctrl),
+		mockOrderUC.NewMockOrderUseCase(ctrl),
+		mockSessUC.NewMockSessUseCase(ctrl),
+	)
+
+	l := bufconn.Listen(1 << 20)
+	go func() {
+		_ = grpcServer.Serve(l)
+	}()
+	defer grpcServer.Stop()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return l.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	healthClient := grpc_health_v1.NewHealthClient(conn)
+	res, err := healthClient.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, res.GetStatus())
+
+	stream, err := grpc_reflection_v1alpha.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&grpc_reflection_v1alpha.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1alpha.ServerReflectionRequest_ListServices{},
+	}))
+	reflectionRes, err := stream.Recv()
+	require.NoError(t, err)
+
+	var services []string
+	for _, service := range reflectionRes.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	require.Contains(t, services, "userService.UserService")
+	require.Contains(t, services, "librarianService.LibrarianService")
+	require.Contains(t, services, "bookService.BookService")
+	require.Contains(t, services, "orderService.OrderService")
+	require.Contains(t, services, "grpc.health.v1.Health")
+
+	healthServer.Shutdown()
+	res, err = healthClient.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+}
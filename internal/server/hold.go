@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/dinorain/pinjembuku/internal/hold"
+)
+
+const (
+	defaultHoldSweepInterval = 60
+)
+
+// runHoldSweeper periodically expire ready holds which were not picked up in time until ctx is done
+func (s *Server) runHoldSweeper(ctx context.Context, holdUC hold.HoldUseCase) {
+	interval := s.cfg.Hold.SweepIntervalSeconds
+	if interval <= 0 {
+		interval = defaultHoldSweepInterval
+	}
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := holdUC.ExpireReady(ctx)
+			if err != nil {
+				s.logger.Errorf("holdUC.ExpireReady: %v", err)
+			}
+			if expired > 0 {
+				s.logger.Infof("expired %d ready holds", expired)
+			}
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package server
 
 import (
+	"net"
 	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/pkg/errors"
 	"github.com/dinorain/pinjembuku/docs"
 
 	echoSwagger "github.com/swaggo/echo-swagger"
@@ -21,6 +23,12 @@ const (
 )
 
 func (s *Server) runHttpServer() error {
+	ipExtractor, err := newIPExtractor(s.cfg.Http.TrustedProxies)
+	if err != nil {
+		return err
+	}
+	s.echo.IPExtractor = ipExtractor
+
 	s.mapRoutes()
 
 	s.echo.Server.ReadTimeout = readTimeout
@@ -38,6 +46,7 @@ func (s *Server) mapRoutes() {
 	docs.SwaggerInfo.BasePath = "/"
 
 	s.echo.GET("/swagger/*", echoSwagger.WrapHandler)
+	s.echo.GET("/.well-known/jwks.json", s.jwks)
 
 	s.echo.Use(s.mw.RequestLoggerMiddleware)
 	s.echo.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{
@@ -54,3 +63,22 @@ func (s *Server) mapRoutes() {
 	}))
 	s.echo.Use(middleware.BodyLimit(bodyLimit))
 }
+
+// newIPExtractor takes the client ip from X-Forwarded-For only behind the trusted proxy ranges,
+// otherwise from the connection, so clients cannot choose the ip seen by c.RealIP
+func newIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, cidr := range trustedProxies {
+		_, ipRange, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "trusted proxy %s", cidr)
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
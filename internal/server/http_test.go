@@ -0,0 +1,39 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_newIPExtractor(t *testing.T) {
+	t.Parallel()
+
+	newRequest := func(remoteAddr string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/user/login", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(echo.HeaderXForwardedFor, "203.0.113.7")
+		return req
+	}
+
+	t.Run("Direct", func(t *testing.T) {
+		extractIP, err := newIPExtractor(nil)
+		require.NoError(t, err)
+		require.Equal(t, "10.0.0.2", extractIP(newRequest("10.0.0.2:1234")))
+	})
+
+	t.Run("Trusted proxy", func(t *testing.T) {
+		extractIP, err := newIPExtractor([]string{"10.0.0.0/24"})
+		require.NoError(t, err)
+		require.Equal(t, "203.0.113.7", extractIP(newRequest("10.0.0.2:1234")))
+		require.Equal(t, "192.168.0.2", extractIP(newRequest("192.168.0.2:1234")))
+	})
+
+	t.Run("Invalid range", func(t *testing.T) {
+		_, err := newIPExtractor([]string{"10.0.0.0"})
+		require.Error(t, err)
+	})
+}
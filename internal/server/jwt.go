@@ -0,0 +1,23 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/dinorain/pinjembuku/pkg/jwtkeys"
+)
+
+// newKeyring loads jwt signing keys from config
+func (s *Server) newKeyring() (*jwtkeys.Keyring, error) {
+	if len(s.cfg.Jwt.Keys) == 0 {
+		s.logger.Warn("no jwt keys configured, signing with HS256 server secret")
+	}
+
+	return jwtkeys.NewKeyringFromConfig(s.cfg)
+}
+
+// jwks publishes public keys of the keyring
+func (s *Server) jwks(c echo.Context) error {
+	return c.JSON(http.StatusOK, s.keys.JWKS())
+}
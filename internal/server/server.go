@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/go-playground/validator"
 	"github.com/go-redis/redis/v8"
@@ -14,20 +15,36 @@ import (
 
 	"github.com/dinorain/pinjembuku/config"
 	"github.com/dinorain/pinjembuku/internal/middlewares"
+	"github.com/dinorain/pinjembuku/pkg/jwtkeys"
 	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/mailer"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
 
+	bookCatalog "github.com/dinorain/pinjembuku/internal/book/catalog"
 	bookDeliveryHTTP "github.com/dinorain/pinjembuku/internal/book/delivery/http/handlers"
+	fineDeliveryHTTP "github.com/dinorain/pinjembuku/internal/fine/delivery/http/handlers"
+	holdDeliveryHTTP "github.com/dinorain/pinjembuku/internal/hold/delivery/http/handlers"
+	inventoryDeliveryHTTP "github.com/dinorain/pinjembuku/internal/inventory/delivery/http/handlers"
 	librarianDeliveryHTTP "github.com/dinorain/pinjembuku/internal/librarian/delivery/http/handlers"
 	orderDeliveryHTTP "github.com/dinorain/pinjembuku/internal/order/delivery/http/handlers"
 	userDeliveryHTTP "github.com/dinorain/pinjembuku/internal/user/delivery/http/handlers"
 
 	bookUseCase "github.com/dinorain/pinjembuku/internal/book/usecase"
+	fineUseCase "github.com/dinorain/pinjembuku/internal/fine/usecase"
+	holdUseCase "github.com/dinorain/pinjembuku/internal/hold/usecase"
+	inventoryUseCase "github.com/dinorain/pinjembuku/internal/inventory/usecase"
 	librarianUseCase "github.com/dinorain/pinjembuku/internal/librarian/usecase"
+	lockoutUseCase "github.com/dinorain/pinjembuku/internal/lockout/usecase"
 	orderUseCase "github.com/dinorain/pinjembuku/internal/order/usecase"
 	sessUseCase "github.com/dinorain/pinjembuku/internal/session/usecase"
 	userUseCase "github.com/dinorain/pinjembuku/internal/user/usecase"
 
+	bookRepository "github.com/dinorain/pinjembuku/internal/book/repository"
+	fineRepository "github.com/dinorain/pinjembuku/internal/fine/repository"
+	holdRepository "github.com/dinorain/pinjembuku/internal/hold/repository"
+	inventoryRepository "github.com/dinorain/pinjembuku/internal/inventory/repository"
 	librarianRepository "github.com/dinorain/pinjembuku/internal/librarian/repository"
+	lockoutRepository "github.com/dinorain/pinjembuku/internal/lockout/repository"
 	orderRepository "github.com/dinorain/pinjembuku/internal/order/repository"
 	sessRepository "github.com/dinorain/pinjembuku/internal/session/repository"
 	userRepository "github.com/dinorain/pinjembuku/internal/user/repository"
@@ -41,6 +58,8 @@ type Server struct {
 	mw          middlewares.MiddlewareManager
 	db          *sqlx.DB
 	redisClient *redis.Client
+	keys        *jwtkeys.Keyring
+	tokens      *tokens.Service
 }
 
 // Server constructor
@@ -60,22 +79,47 @@ func (s *Server) Run() error {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
 	defer cancel()
 
-	s.mw = middlewares.NewMiddlewareManager(s.logger, s.cfg)
-
 	userRepo := userRepository.NewUserPGRepository(s.db)
 	librarianRepo := librarianRepository.NewLibrarianPGRepository(s.db)
 	orderRepo := orderRepository.NewOrderPGRepository(s.db)
+	inventoryRepo := inventoryRepository.NewInventoryPGRepository(s.db)
+	fineRepo := fineRepository.NewFinePGRepository(s.db)
+	holdRepo := holdRepository.NewHoldPGRepository(s.db)
+
+	keys, err := s.newKeyring()
+	if err != nil {
+		return err
+	}
+	s.keys = keys
+	s.tokens = tokens.NewService(s.cfg, s.keys)
 
 	sessRepo := sessRepository.NewSessionRepository(s.redisClient, s.cfg)
+	s.mw = middlewares.NewMiddlewareManager(s.logger, s.cfg, s.tokens, sessRepo)
 	userRedisRepo := userRepository.NewUserRedisRepo(s.redisClient, s.logger)
 	librarianRedisRepo := librarianRepository.NewLibrarianRedisRepo(s.redisClient, s.logger)
 	orderRedisRepo := orderRepository.NewOrderRedisRepo(s.redisClient, s.logger)
+	bookRedisRepo := bookRepository.NewBookRedisRepo(s.redisClient, s.logger)
+	lockoutRepo := lockoutRepository.NewLockoutRepository(s.redisClient)
+
+	catalogProvider, err := bookCatalog.NewCatalogProvider(s.cfg, s.logger)
+	if err != nil {
+		return err
+	}
+
+	appMailer, err := mailer.NewMailer(s.cfg)
+	if err != nil {
+		return err
+	}
 
 	sessUC := sessUseCase.NewSessionUseCase(sessRepo, s.cfg)
-	userUC := userUseCase.NewUserUseCase(s.cfg, s.logger, userRepo, userRedisRepo)
-	librarianUC := librarianUseCase.NewLibrarianUseCase(s.cfg, s.logger, librarianRepo, librarianRedisRepo)
-	bookUC := bookUseCase.NewBookUseCase(s.cfg, s.logger)
-	orderUC := orderUseCase.NewOrderUseCase(s.cfg, s.logger, orderRepo, orderRedisRepo)
+	lockoutUC := lockoutUseCase.NewLockoutUseCase(lockoutRepo, s.cfg)
+	userUC := userUseCase.NewUserUseCase(s.cfg, s.logger, s.tokens, appMailer, lockoutUC, userRepo, userRedisRepo)
+	librarianUC := librarianUseCase.NewLibrarianUseCase(s.cfg, s.logger, s.tokens, appMailer, lockoutUC, librarianRepo, librarianRedisRepo)
+	bookUC := bookUseCase.NewBookUseCase(s.cfg, s.logger, catalogProvider, bookRedisRepo)
+	inventoryUC := inventoryUseCase.NewInventoryUseCase(s.cfg, s.logger, inventoryRepo)
+	fineUC := fineUseCase.NewFineUseCase(s.cfg, s.logger, fineRepo)
+	holdUC := holdUseCase.NewHoldUseCase(s.cfg, s.logger, holdRepo, inventoryUC)
+	orderUC := orderUseCase.NewOrderUseCase(s.cfg, s.logger, orderRepo, orderRedisRepo, inventoryUC, fineUC, holdUC)
 
 	l, err := net.Listen("tcp", s.cfg.Server.Port)
 	if err != nil {
@@ -83,18 +127,29 @@ func (s *Server) Run() error {
 	}
 	defer l.Close()
 
-	userHandlers := userDeliveryHTTP.NewUserHandlersHTTP(s.echo.Group("user"), s.logger, s.cfg, s.mw, s.v, userUC, sessUC)
+	userHandlers := userDeliveryHTTP.NewUserHandlersHTTP(s.echo.Group("user"), s.logger, s.cfg, s.mw, s.v, s.tokens, userUC, sessUC)
 	userHandlers.UserMapRoutes()
 
-	librarianHandlers := librarianDeliveryHTTP.NewLibrarianHandlersHTTP(s.echo.Group("librarian"), s.logger, s.cfg, s.mw, s.v, librarianUC, sessUC)
+	librarianHandlers := librarianDeliveryHTTP.NewLibrarianHandlersHTTP(s.echo.Group("librarian"), s.logger, s.cfg, s.mw, s.v, s.tokens, librarianUC, sessUC)
 	librarianHandlers.LibrarianMapRoutes()
 
 	bookHandlers := bookDeliveryHTTP.NewBookHandlersHTTP(s.echo.Group("book"), s.logger, s.cfg, s.mw, s.v, bookUC)
 	bookHandlers.BookMapRoutes()
 
+	inventoryHandlers := inventoryDeliveryHTTP.NewInventoryHandlersHTTP(s.echo.Group("inventory"), s.logger, s.cfg, s.mw, s.v, inventoryUC)
+	inventoryHandlers.InventoryMapRoutes()
+
 	orderHandlers := orderDeliveryHTTP.NewOrderHandlersHTTP(s.echo.Group("order"), s.logger, s.cfg, s.mw, s.v, orderUC, bookUC, userUC, librarianUC, sessUC)
 	orderHandlers.OrderMapRoutes()
 
+	fineHandlers := fineDeliveryHTTP.NewFineHandlersHTTP(s.echo.Group("fine"), s.logger, s.cfg, s.mw, s.v, fineUC)
+	fineHandlers.FineMapRoutes()
+
+	holdHandlers := holdDeliveryHTTP.NewHoldHandlersHTTP(s.echo.Group("hold"), s.logger, s.cfg, s.mw, s.v, holdUC)
+	holdHandlers.HoldMapRoutes()
+
+	grpcServer, healthServer := s.newGrpcServer(userUC, librarianUC, bookUC, orderUC, sessUC)
+
 	go func() {
 		if err := s.runHttpServer(); err != nil {
 			s.logger.Errorf("s.runHttpServer: %v", err)
@@ -102,10 +157,27 @@ func (s *Server) Run() error {
 		}
 	}()
 
+	go func() {
+		s.logger.Infof("GRPC Server is listening on port: %s", s.cfg.Server.Port)
+		if err := grpcServer.Serve(l); err != nil {
+			s.logger.Errorf("grpcServer.Serve: %v", err)
+			cancel()
+		}
+	}()
+
+	go s.runHoldSweeper(ctx, holdUC)
+
 	<-ctx.Done()
-	if err := s.echo.Server.Shutdown(ctx); err != nil {
+	healthServer.Shutdown()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), s.cfg.Server.CtxDefaultTimeout*time.Second)
+	defer shutdownCancel()
+
+	if err := s.echo.Server.Shutdown(shutdownCtx); err != nil {
 		s.logger.WarnMsg("echo.Server.Shutdown", err)
 	}
+	s.stopGrpcServer(shutdownCtx, grpcServer)
+	s.logger.Info("Server Exited Properly")
 
 	return nil
 }
@@ -10,6 +10,7 @@ import (
 
 	models "github.com/dinorain/pinjembuku/internal/models"
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockSessRepository is a mock of SessRepository interface.
@@ -50,6 +51,21 @@ func (mr *MockSessRepositoryMockRecorder) CreateSession(ctx, session, expire int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSessRepository)(nil).CreateSession), ctx, session, expire)
 }
 
+// DeleteAllByUserId mocks base method.
+func (m *MockSessRepository) DeleteAllByUserId(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllByUserId", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAllByUserId indicates an expected call of DeleteAllByUserId.
+func (mr *MockSessRepositoryMockRecorder) DeleteAllByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllByUserId", reflect.TypeOf((*MockSessRepository)(nil).DeleteAllByUserId), ctx, userID)
+}
+
 // DeleteById mocks base method.
 func (m *MockSessRepository) DeleteById(ctx context.Context, sessionID string) error {
 	m.ctrl.T.Helper()
@@ -64,6 +80,21 @@ func (mr *MockSessRepositoryMockRecorder) DeleteById(ctx, sessionID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockSessRepository)(nil).DeleteById), ctx, sessionID)
 }
 
+// FindAllByUserId mocks base method.
+func (m *MockSessRepository) FindAllByUserId(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByUserId", ctx, userID)
+	ret0, _ := ret[0].([]*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByUserId indicates an expected call of FindAllByUserId.
+func (mr *MockSessRepositoryMockRecorder) FindAllByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserId", reflect.TypeOf((*MockSessRepository)(nil).FindAllByUserId), ctx, userID)
+}
+
 // GetSessionById mocks base method.
 func (m *MockSessRepository) GetSessionById(ctx context.Context, sessionID string) (*models.Session, error) {
 	m.ctrl.T.Helper()
@@ -78,3 +109,31 @@ func (mr *MockSessRepositoryMockRecorder) GetSessionById(ctx, sessionID interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionById", reflect.TypeOf((*MockSessRepository)(nil).GetSessionById), ctx, sessionID)
 }
+
+// RotateRefreshTokenId mocks base method.
+func (m *MockSessRepository) RotateRefreshTokenId(ctx context.Context, sessionID, usedTokenID, nextTokenID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshTokenId", ctx, sessionID, usedTokenID, nextTokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateRefreshTokenId indicates an expected call of RotateRefreshTokenId.
+func (mr *MockSessRepositoryMockRecorder) RotateRefreshTokenId(ctx, sessionID, usedTokenID, nextTokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshTokenId", reflect.TypeOf((*MockSessRepository)(nil).RotateRefreshTokenId), ctx, sessionID, usedTokenID, nextTokenID)
+}
+
+// SetRefreshTokenId mocks base method.
+func (m *MockSessRepository) SetRefreshTokenId(ctx context.Context, sessionID, tokenID string, expire int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRefreshTokenId", ctx, sessionID, tokenID, expire)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRefreshTokenId indicates an expected call of SetRefreshTokenId.
+func (mr *MockSessRepositoryMockRecorder) SetRefreshTokenId(ctx, sessionID, tokenID, expire interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRefreshTokenId", reflect.TypeOf((*MockSessRepository)(nil).SetRefreshTokenId), ctx, sessionID, tokenID, expire)
+}
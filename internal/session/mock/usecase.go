@@ -10,6 +10,7 @@ import (
 
 	models "github.com/dinorain/pinjembuku/internal/models"
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockSessUseCase is a mock of SessUseCase interface.
@@ -50,6 +51,21 @@ func (mr *MockSessUseCaseMockRecorder) CreateSession(ctx, session, expire interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSessUseCase)(nil).CreateSession), ctx, session, expire)
 }
 
+// DeleteAllByUserId mocks base method.
+func (m *MockSessUseCase) DeleteAllByUserId(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllByUserId", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAllByUserId indicates an expected call of DeleteAllByUserId.
+func (mr *MockSessUseCaseMockRecorder) DeleteAllByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllByUserId", reflect.TypeOf((*MockSessUseCase)(nil).DeleteAllByUserId), ctx, userID)
+}
+
 // DeleteById mocks base method.
 func (m *MockSessUseCase) DeleteById(ctx context.Context, sessionID string) error {
 	m.ctrl.T.Helper()
@@ -64,6 +80,21 @@ func (mr *MockSessUseCaseMockRecorder) DeleteById(ctx, sessionID interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockSessUseCase)(nil).DeleteById), ctx, sessionID)
 }
 
+// FindAllByUserId mocks base method.
+func (m *MockSessUseCase) FindAllByUserId(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByUserId", ctx, userID)
+	ret0, _ := ret[0].([]*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByUserId indicates an expected call of FindAllByUserId.
+func (mr *MockSessUseCaseMockRecorder) FindAllByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserId", reflect.TypeOf((*MockSessUseCase)(nil).FindAllByUserId), ctx, userID)
+}
+
 // GetSessionById mocks base method.
 func (m *MockSessUseCase) GetSessionById(ctx context.Context, sessionID string) (*models.Session, error) {
 	m.ctrl.T.Helper()
@@ -78,3 +109,33 @@ func (mr *MockSessUseCaseMockRecorder) GetSessionById(ctx, sessionID interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionById", reflect.TypeOf((*MockSessUseCase)(nil).GetSessionById), ctx, sessionID)
 }
+
+// IssueRefreshToken mocks base method.
+func (m *MockSessUseCase) IssueRefreshToken(ctx context.Context, sessionID string, expire int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueRefreshToken", ctx, sessionID, expire)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueRefreshToken indicates an expected call of IssueRefreshToken.
+func (mr *MockSessUseCaseMockRecorder) IssueRefreshToken(ctx, sessionID, expire interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueRefreshToken", reflect.TypeOf((*MockSessUseCase)(nil).IssueRefreshToken), ctx, sessionID, expire)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockSessUseCase) RotateRefreshToken(ctx context.Context, sessionID, usedTokenID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", ctx, sessionID, usedTokenID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockSessUseCaseMockRecorder) RotateRefreshToken(ctx, sessionID, usedTokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockSessUseCase)(nil).RotateRefreshToken), ctx, sessionID, usedTokenID)
+}
@@ -4,6 +4,8 @@ package session
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"github.com/dinorain/pinjembuku/internal/models"
 )
 
@@ -12,4 +14,8 @@ type SessRepository interface {
 	CreateSession(ctx context.Context, session *models.Session, expire int) (string, error)
 	GetSessionById(ctx context.Context, sessionID string) (*models.Session, error)
 	DeleteById(ctx context.Context, sessionID string) error
+	FindAllByUserId(ctx context.Context, userID uuid.UUID) ([]*models.Session, error)
+	DeleteAllByUserId(ctx context.Context, userID uuid.UUID) ([]string, error)
+	SetRefreshTokenId(ctx context.Context, sessionID string, tokenID string, expire int) error
+	RotateRefreshTokenId(ctx context.Context, sessionID string, usedTokenID string, nextTokenID string) error
 }
@@ -13,37 +13,72 @@ import (
 	"github.com/dinorain/pinjembuku/config"
 	"github.com/dinorain/pinjembuku/internal/models"
 	"github.com/dinorain/pinjembuku/internal/session"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
 )
 
 const (
-	basePrefix = "sessions:"
+	basePrefix   = "sessions:"
+	indexPrefix  = "sessions-by-user:"
+	familyPrefix = "refresh-token-families:"
 )
 
+// rotateRefreshTokenScript swaps the current refresh token id of a family only when the used one is current,
+// returns -1 when family is gone and 0 when used token was already rotated
+var rotateRefreshTokenScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if not current then
+	return -1
+end
+if current ~= ARGV[1] then
+	return 0
+end
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl > 0 then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ttl)
+else
+	redis.call("SET", KEYS[1], ARGV[2])
+end
+return 1
+`)
+
 // Session repository
 type sessionRepo struct {
-	redisClient *redis.Client
-	basePrefix  string
-	cfg         *config.Config
+	redisClient  *redis.Client
+	basePrefix   string
+	indexPrefix  string
+	familyPrefix string
+	cfg          *config.Config
 }
 
 var _ session.SessRepository = (*sessionRepo)(nil)
 
 // Session repository constructor
 func NewSessionRepository(redisClient *redis.Client, cfg *config.Config) session.SessRepository {
-	return &sessionRepo{redisClient: redisClient, basePrefix: basePrefix, cfg: cfg}
+	return &sessionRepo{redisClient: redisClient, basePrefix: basePrefix, indexPrefix: indexPrefix, familyPrefix: familyPrefix, cfg: cfg}
 }
 
-// Create session in redis
+// Create session in redis and add it to the index of its owner
 func (s *sessionRepo) CreateSession(ctx context.Context, sess *models.Session, expire int) (string, error) {
 	sess.SessionID = uuid.New().String()
+	if sess.CreatedAt.IsZero() {
+		sess.CreatedAt = time.Now().UTC()
+	}
 	sessionKey := s.generateKey(sess.SessionID)
+	indexKey := s.generateIndexKey(sess.UserID)
 
 	sessBytes, err := json.Marshal(&sess)
 	if err != nil {
 		return "", errors.WithMessage(err, "sessionRepo.CreateSession.json.Marshal")
 	}
-	if err = s.redisClient.Set(ctx, sessionKey, sessBytes, time.Second*time.Duration(expire)).Err(); err != nil {
-		return "", errors.Wrap(err, "sessionRepo.CreateSession.redisClient.Set")
+
+	// the index lives as long as the newest session, expired members are pruned on read
+	ttl := time.Second * time.Duration(expire)
+	pipe := s.redisClient.TxPipeline()
+	pipe.Set(ctx, sessionKey, sessBytes, ttl)
+	pipe.ZAdd(ctx, indexKey, &redis.Z{Score: float64(sess.CreatedAt.Unix()), Member: sess.SessionID})
+	pipe.Expire(ctx, indexKey, ttl)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return "", errors.Wrap(err, "sessionRepo.CreateSession.pipe.Exec")
 	}
 	return sess.SessionID, nil
 }
@@ -64,12 +99,123 @@ func (s *sessionRepo) GetSessionById(ctx context.Context, sessionID string) (*mo
 
 // Delete session by id
 func (s *sessionRepo) DeleteById(ctx context.Context, sessionID string) error {
-	if err := s.redisClient.Del(ctx, s.generateKey(sessionID)).Err(); err != nil {
+	sess, err := s.GetSessionById(ctx, sessionID)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return errors.Wrap(err, "sessionRepo.DeleteById.GetSessionById")
+	}
+
+	pipe := s.redisClient.TxPipeline()
+	pipe.Del(ctx, s.generateKey(sessionID), s.generateFamilyKey(sessionID))
+	if sess != nil {
+		pipe.ZRem(ctx, s.generateIndexKey(sess.UserID), sessionID)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
 		return errors.Wrap(err, "sessionRepo.DeleteById")
 	}
 	return nil
 }
 
+// Find all active sessions of user or librarian, newest first
+func (s *sessionRepo) FindAllByUserId(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	indexKey := s.generateIndexKey(userID)
+
+	sessionIDs, err := s.redisClient.ZRevRange(ctx, indexKey, 0, -1).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "sessionRepo.FindAllByUserId.redisClient.ZRevRange")
+	}
+
+	sessions := make([]*models.Session, 0, len(sessionIDs))
+	if len(sessionIDs) == 0 {
+		return sessions, nil
+	}
+
+	keys := make([]string, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, s.generateKey(sessionID))
+	}
+
+	values, err := s.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "sessionRepo.FindAllByUserId.redisClient.MGet")
+	}
+
+	var expired []interface{}
+	for i, value := range values {
+		sessString, ok := value.(string)
+		if !ok {
+			expired = append(expired, sessionIDs[i])
+			continue
+		}
+
+		sess := &models.Session{}
+		if err = json.Unmarshal([]byte(sessString), sess); err != nil {
+			return nil, errors.Wrap(err, "sessionRepo.FindAllByUserId.json.Unmarshal")
+		}
+		sessions = append(sessions, sess)
+	}
+
+	if len(expired) > 0 {
+		if err := s.redisClient.ZRem(ctx, indexKey, expired...).Err(); err != nil {
+			return nil, errors.Wrap(err, "sessionRepo.FindAllByUserId.redisClient.ZRem")
+		}
+	}
+
+	return sessions, nil
+}
+
+// Delete all sessions of user or librarian, returns ids of deleted sessions
+func (s *sessionRepo) DeleteAllByUserId(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	indexKey := s.generateIndexKey(userID)
+
+	sessionIDs, err := s.redisClient.ZRange(ctx, indexKey, 0, -1).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "sessionRepo.DeleteAllByUserId.redisClient.ZRange")
+	}
+
+	keys := make([]string, 0, 2*len(sessionIDs)+1)
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, s.generateKey(sessionID), s.generateFamilyKey(sessionID))
+	}
+	keys = append(keys, indexKey)
+
+	if err := s.redisClient.Del(ctx, keys...).Err(); err != nil {
+		return nil, errors.Wrap(err, "sessionRepo.DeleteAllByUserId.redisClient.Del")
+	}
+	return sessionIDs, nil
+}
+
+// Set current refresh token id of the token family of session
+func (s *sessionRepo) SetRefreshTokenId(ctx context.Context, sessionID string, tokenID string, expire int) error {
+	if err := s.redisClient.Set(ctx, s.generateFamilyKey(sessionID), tokenID, time.Second*time.Duration(expire)).Err(); err != nil {
+		return errors.Wrap(err, "sessionRepo.SetRefreshTokenId.redisClient.Set")
+	}
+	return nil
+}
+
+// Rotate refresh token id of the token family of session, fails when used token id is not the current one
+func (s *sessionRepo) RotateRefreshTokenId(ctx context.Context, sessionID string, usedTokenID string, nextTokenID string) error {
+	res, err := rotateRefreshTokenScript.Run(ctx, s.redisClient, []string{s.generateFamilyKey(sessionID)}, usedTokenID, nextTokenID).Int()
+	if err != nil {
+		return errors.Wrap(err, "sessionRepo.RotateRefreshTokenId.rotateRefreshTokenScript.Run")
+	}
+
+	switch res {
+	case -1:
+		return errors.Wrap(redis.Nil, "sessionRepo.RotateRefreshTokenId")
+	case 0:
+		return errors.Wrap(grpc_errors.ErrRefreshTokenReused, "sessionRepo.RotateRefreshTokenId")
+	}
+	return nil
+}
+
 func (s *sessionRepo) generateKey(sessionID string) string {
 	return fmt.Sprintf("%s: %s", s.basePrefix, sessionID)
 }
+
+func (s *sessionRepo) generateIndexKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s: %s", s.indexPrefix, userID.String())
+}
+
+func (s *sessionRepo) generateFamilyKey(sessionID string) string {
+	return fmt.Sprintf("%s: %s", s.familyPrefix, sessionID)
+}
@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"testing"
+	"time"
 
 	"github.com/alicebob/miniredis"
 	"github.com/go-redis/redis/v8"
@@ -12,6 +14,7 @@ import (
 
 	"github.com/dinorain/pinjembuku/internal/models"
 	"github.com/dinorain/pinjembuku/internal/session"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
 )
 
 func SetupRedis() session.SessRepository {
@@ -76,3 +79,105 @@ func TestDeleteSession(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestFindAllSessionsByUserId(t *testing.T) {
+	t.Parallel()
+
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+	sessRepository := NewSessionRepository(redis.NewClient(&redis.Options{Addr: mr.Addr()}), nil)
+
+	t.Run("FindAllByUserId", func(t *testing.T) {
+		userUUID := uuid.New()
+		older, err := sessRepository.CreateSession(context.Background(), &models.Session{UserID: userUUID, IP: "10.0.0.1", CreatedAt: time.Now().Add(-time.Hour)}, 100)
+		require.NoError(t, err)
+		newer, err := sessRepository.CreateSession(context.Background(), &models.Session{UserID: userUUID, IP: "10.0.0.2"}, 100)
+		require.NoError(t, err)
+		_, err = sessRepository.CreateSession(context.Background(), &models.Session{UserID: uuid.New()}, 100)
+		require.NoError(t, err)
+
+		sessions, err := sessRepository.FindAllByUserId(context.Background(), userUUID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		require.Equal(t, newer, sessions[0].SessionID)
+		require.Equal(t, "10.0.0.2", sessions[0].IP)
+		require.Equal(t, older, sessions[1].SessionID)
+	})
+
+	t.Run("Deleted and expired sessions", func(t *testing.T) {
+		userUUID := uuid.New()
+		expiring, err := sessRepository.CreateSession(context.Background(), &models.Session{UserID: userUUID}, 10)
+		require.NoError(t, err)
+		deleted, err := sessRepository.CreateSession(context.Background(), &models.Session{UserID: userUUID}, 100)
+		require.NoError(t, err)
+		active, err := sessRepository.CreateSession(context.Background(), &models.Session{UserID: userUUID}, 100)
+		require.NoError(t, err)
+
+		require.NoError(t, sessRepository.DeleteById(context.Background(), deleted))
+		mr.Del(fmt.Sprintf("%s: %s", basePrefix, expiring))
+
+		sessions, err := sessRepository.FindAllByUserId(context.Background(), userUUID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		require.Equal(t, active, sessions[0].SessionID)
+
+		members, err := mr.ZMembers(fmt.Sprintf("%s: %s", indexPrefix, userUUID))
+		require.NoError(t, err)
+		require.Equal(t, []string{active}, members)
+	})
+}
+
+func TestDeleteAllSessionsByUserId(t *testing.T) {
+	t.Parallel()
+
+	sessRepository := SetupRedis()
+
+	t.Run("DeleteAllByUserId", func(t *testing.T) {
+		userUUID := uuid.New()
+		first, err := sessRepository.CreateSession(context.Background(), &models.Session{UserID: userUUID}, 100)
+		require.NoError(t, err)
+		second, err := sessRepository.CreateSession(context.Background(), &models.Session{UserID: userUUID}, 100)
+		require.NoError(t, err)
+		other, err := sessRepository.CreateSession(context.Background(), &models.Session{UserID: uuid.New()}, 100)
+		require.NoError(t, err)
+
+		sessionIDs, err := sessRepository.DeleteAllByUserId(context.Background(), userUUID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{first, second}, sessionIDs)
+
+		_, err = sessRepository.GetSessionById(context.Background(), first)
+		require.ErrorIs(t, err, redis.Nil)
+		_, err = sessRepository.GetSessionById(context.Background(), other)
+		require.NoError(t, err)
+
+		sessions, err := sessRepository.FindAllByUserId(context.Background(), userUUID)
+		require.NoError(t, err)
+		require.Empty(t, sessions)
+	})
+}
+
+func TestRotateRefreshTokenId(t *testing.T) {
+	t.Parallel()
+
+	sessRepository := SetupRedis()
+
+	t.Run("RotateRefreshTokenId", func(t *testing.T) {
+		sessID, err := sessRepository.CreateSession(context.Background(), &models.Session{UserID: uuid.New()}, 100)
+		require.NoError(t, err)
+		require.NoError(t, sessRepository.SetRefreshTokenId(context.Background(), sessID, "first", 100))
+
+		require.NoError(t, sessRepository.RotateRefreshTokenId(context.Background(), sessID, "first", "second"))
+		require.ErrorIs(t, sessRepository.RotateRefreshTokenId(context.Background(), sessID, "first", "third"), grpc_errors.ErrRefreshTokenReused)
+		require.NoError(t, sessRepository.RotateRefreshTokenId(context.Background(), sessID, "second", "third"))
+	})
+
+	t.Run("Revoked family", func(t *testing.T) {
+		sessID, err := sessRepository.CreateSession(context.Background(), &models.Session{UserID: uuid.New()}, 100)
+		require.NoError(t, err)
+		require.NoError(t, sessRepository.SetRefreshTokenId(context.Background(), sessID, "first", 100))
+		require.NoError(t, sessRepository.DeleteById(context.Background(), sessID))
+
+		require.ErrorIs(t, sessRepository.RotateRefreshTokenId(context.Background(), sessID, "first", "second"), redis.Nil)
+	})
+}
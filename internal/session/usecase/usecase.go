@@ -3,9 +3,13 @@ package usecase
 import (
 	"context"
 
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
 	"github.com/dinorain/pinjembuku/config"
 	"github.com/dinorain/pinjembuku/internal/models"
 	"github.com/dinorain/pinjembuku/internal/session"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
 )
 
 // Session use case
@@ -35,3 +39,39 @@ func (u *sessionUC) DeleteById(ctx context.Context, sessionID string) error {
 func (u *sessionUC) GetSessionById(ctx context.Context, sessionID string) (*models.Session, error) {
 	return u.sessionRepo.GetSessionById(ctx, sessionID)
 }
+
+// Find all active sessions of user or librarian
+func (u *sessionUC) FindAllByUserId(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	return u.sessionRepo.FindAllByUserId(ctx, userID)
+}
+
+// Delete all sessions of user or librarian
+func (u *sessionUC) DeleteAllByUserId(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	return u.sessionRepo.DeleteAllByUserId(ctx, userID)
+}
+
+// Start token family of session, returns id of its first refresh token
+func (u *sessionUC) IssueRefreshToken(ctx context.Context, sessionID string, expire int) (string, error) {
+	tokenID := uuid.New().String()
+	if err := u.sessionRepo.SetRefreshTokenId(ctx, sessionID, tokenID, expire); err != nil {
+		return "", err
+	}
+	return tokenID, nil
+}
+
+// Rotate refresh token of session, returns id of the next one.
+// Presenting an already used refresh token means it leaked, so the whole session is revoked
+func (u *sessionUC) RotateRefreshToken(ctx context.Context, sessionID string, usedTokenID string) (string, error) {
+	tokenID := uuid.New().String()
+	err := u.sessionRepo.RotateRefreshTokenId(ctx, sessionID, usedTokenID, tokenID)
+	if errors.Is(err, grpc_errors.ErrRefreshTokenReused) {
+		if err := u.sessionRepo.DeleteById(ctx, sessionID); err != nil {
+			return "", errors.Wrap(err, "sessionUC.RotateRefreshToken.DeleteById")
+		}
+		return "", err
+	}
+	if err != nil {
+		return "", err
+	}
+	return tokenID, nil
+}
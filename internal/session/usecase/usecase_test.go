@@ -5,10 +5,12 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dinorain/pinjembuku/internal/models"
 	"github.com/dinorain/pinjembuku/internal/session/mock"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
 )
 
 func TestSessionUC_CreateSession(t *testing.T) {
@@ -71,3 +73,95 @@ func TestSessionUC_DeleteById(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, err)
 }
+
+func TestSessionUC_FindAllByUserId(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessRepo := mock.NewMockSessRepository(ctrl)
+	sessUC := NewSessionUseCase(mockSessRepo, nil)
+
+	ctx := context.Background()
+	userUUID := uuid.New()
+
+	mockSessRepo.EXPECT().FindAllByUserId(gomock.Any(), gomock.Eq(userUUID)).Return([]*models.Session{{UserID: userUUID}}, nil)
+
+	sessions, err := sessUC.FindAllByUserId(ctx, userUUID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+}
+
+func TestSessionUC_DeleteAllByUserId(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessRepo := mock.NewMockSessRepository(ctrl)
+	sessUC := NewSessionUseCase(mockSessRepo, nil)
+
+	ctx := context.Background()
+	userUUID := uuid.New()
+
+	mockSessRepo.EXPECT().DeleteAllByUserId(gomock.Any(), gomock.Eq(userUUID)).Return([]string{"session id"}, nil)
+
+	sessionIDs, err := sessUC.DeleteAllByUserId(ctx, userUUID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"session id"}, sessionIDs)
+}
+
+func TestSessionUC_IssueRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessRepo := mock.NewMockSessRepository(ctrl)
+	sessUC := NewSessionUseCase(mockSessRepo, nil)
+
+	ctx := context.Background()
+	sid := "session id"
+
+	var stored string
+	mockSessRepo.EXPECT().SetRefreshTokenId(gomock.Any(), gomock.Eq(sid), gomock.Any(), 10).DoAndReturn(func(_ context.Context, _ string, tokenID string, _ int) error {
+		stored = tokenID
+		return nil
+	})
+
+	tokenID, err := sessUC.IssueRefreshToken(ctx, sid, 10)
+	require.NoError(t, err)
+	require.NotEqual(t, "", tokenID)
+	require.Equal(t, stored, tokenID)
+}
+
+func TestSessionUC_RotateRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessRepo := mock.NewMockSessRepository(ctrl)
+	sessUC := NewSessionUseCase(mockSessRepo, nil)
+
+	ctx := context.Background()
+	sid := "session id"
+
+	t.Run("Rotate", func(t *testing.T) {
+		mockSessRepo.EXPECT().RotateRefreshTokenId(gomock.Any(), gomock.Eq(sid), "used", gomock.Any()).Return(nil)
+
+		tokenID, err := sessUC.RotateRefreshToken(ctx, sid, "used")
+		require.NoError(t, err)
+		require.NotEqual(t, "", tokenID)
+		require.NotEqual(t, "used", tokenID)
+	})
+
+	t.Run("Reused", func(t *testing.T) {
+		mockSessRepo.EXPECT().RotateRefreshTokenId(gomock.Any(), gomock.Eq(sid), "stale", gomock.Any()).Return(grpc_errors.ErrRefreshTokenReused)
+		mockSessRepo.EXPECT().DeleteById(gomock.Any(), gomock.Eq(sid)).Return(nil)
+
+		_, err := sessUC.RotateRefreshToken(ctx, sid, "stale")
+		require.ErrorIs(t, err, grpc_errors.ErrRefreshTokenReused)
+	})
+}
@@ -2,15 +2,13 @@ package service
 
 import (
 	"context"
+	"strings"
 
-	"github.com/go-redis/redis/v8"
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/dinorain/pinjembuku/internal/interceptors"
 	"github.com/dinorain/pinjembuku/internal/models"
 	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
 	"github.com/dinorain/pinjembuku/pkg/utils"
@@ -19,7 +17,7 @@ import (
 
 // Register new user
 func (u *usersServiceGRPC) Register(ctx context.Context, r *userService.RegisterRequest) (*userService.RegisterResponse, error) {
-	user, err := u.registerReqToUserModel(r)
+	user, err := u.registerReqToUserModel(ctx, r)
 	if err != nil {
 		u.logger.Errorf("registerReqToUserModel: %v", err)
 		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "registerReqToUserModel: %v", err)
@@ -47,24 +45,45 @@ func (u *usersServiceGRPC) Login(ctx context.Context, r *userService.LoginReques
 		return nil, status.Errorf(codes.InvalidArgument, "ValidateEmail: %v", email)
 	}
 
-	user, err := u.userUC.Login(ctx, email, r.GetPassword())
+	ip, userAgent := utils.GetGRPCClientInfo(ctx)
+	user, err := u.userUC.Login(ctx, email, r.GetPassword(), ip)
 	if err != nil {
 		u.logger.Errorf("userUC.Login: %v", err)
+		grpc_errors.SetRetryAfterHeader(ctx, err)
 		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "Login: %v", err)
 	}
 
 	session, err := u.sessUC.CreateSession(ctx, &models.Session{
-		UserID: user.UserID,
-	}, u.cfg.Session.Expire)
+		UserID:    user.UserID,
+		IP:        ip,
+		UserAgent: userAgent,
+	}, u.tokens.RefreshTokenExpire())
 	if err != nil {
 		u.logger.Errorf("sessUC.CreateSession: %v", err)
 		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "sessUC.CreateSession: %v", err)
 	}
 
-	return &userService.LoginResponse{User: u.userModelToProto(user), SessionId: session}, err
+	refreshTokenID, err := u.sessUC.IssueRefreshToken(ctx, session, u.tokens.RefreshTokenExpire())
+	if err != nil {
+		u.logger.Errorf("sessUC.IssueRefreshToken: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "sessUC.IssueRefreshToken: %v", err)
+	}
+
+	accessToken, refreshToken, err := u.userUC.GenerateTokenPair(user, session, refreshTokenID)
+	if err != nil {
+		u.logger.Errorf("userUC.GenerateTokenPair: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "userUC.GenerateTokenPair: %v", err)
+	}
+
+	return &userService.LoginResponse{
+		User:         u.userModelToProto(user),
+		SessionId:    session,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
 }
 
-// FindByEmail find user by email address
+// FindByEmail find user by email address, admins only or the caller themself
 func (u *usersServiceGRPC) FindByEmail(ctx context.Context, r *userService.FindByEmailRequest) (*userService.FindByEmailResponse, error) {
 	email := r.GetEmail()
 	if !utils.ValidateEmail(email) {
@@ -72,21 +91,51 @@ func (u *usersServiceGRPC) FindByEmail(ctx context.Context, r *userService.FindB
 		return nil, status.Errorf(codes.InvalidArgument, "ValidateEmail: %v", email)
 	}
 
+	principal, ok := interceptors.PrincipalFromCtx(ctx)
+	if !ok {
+		u.logger.Errorf("PrincipalFromCtx: %+v", principal)
+		return nil, status.Errorf(codes.PermissionDenied, "PrincipalFromCtx: %v", grpc_errors.ErrPermissionDenied)
+	}
+
+	if !principal.HasRole(models.UserRoleAdmin) {
+		// non admins may only look up themselves, found by id so other accounts are never read
+		if principal.IsLibrarian() {
+			return nil, status.Errorf(codes.PermissionDenied, "FindByEmail: %v", grpc_errors.ErrPermissionDenied)
+		}
+
+		user, err := u.userUC.CachedFindById(ctx, principal.ID)
+		if err != nil {
+			u.logger.Errorf("userUC.CachedFindById: %v", err)
+			return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "userUC.CachedFindById: %v", err)
+		}
+		if !strings.EqualFold(user.Email, email) {
+			return nil, status.Errorf(codes.PermissionDenied, "FindByEmail: %v", grpc_errors.ErrPermissionDenied)
+		}
+
+		return &userService.FindByEmailResponse{User: u.userModelToProto(user)}, nil
+	}
+
 	user, err := u.userUC.FindByEmail(ctx, email)
 	if err != nil {
 		u.logger.Errorf("userUC.FindByEmail: %v", err)
 		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "userUC.FindByEmail: %v", err)
 	}
 
-	return &userService.FindByEmailResponse{User: u.userModelToProto(user)}, err
+	return &userService.FindByEmailResponse{User: u.userModelToProto(user)}, nil
 }
 
-// FindById find user by uuid
+// FindById find user by uuid, admins only or the caller themself
 func (u *usersServiceGRPC) FindById(ctx context.Context, r *userService.FindByIdRequest) (*userService.FindByIdResponse, error) {
-	userUUID, err := uuid.Parse(r.GetUuid())
+	userUUID, err := utils.ParseUUID(r.GetUuid())
 	if err != nil {
-		u.logger.Errorf("uuid.Parse: %v", err)
-		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "uuid.Parse: %v", err)
+		u.logger.Errorf("utils.ParseUUID: %v", err)
+		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "utils.ParseUUID: %v", err)
+	}
+
+	principal, ok := interceptors.PrincipalFromCtx(ctx)
+	if !ok || !(principal.HasRole(models.UserRoleAdmin) || (!principal.IsLibrarian() && principal.ID == userUUID)) {
+		u.logger.Errorf("PrincipalFromCtx: %+v", principal)
+		return nil, status.Errorf(codes.PermissionDenied, "FindById: %v", grpc_errors.ErrPermissionDenied)
 	}
 
 	user, err := u.userUC.CachedFindById(ctx, userUUID)
@@ -98,24 +147,15 @@ func (u *usersServiceGRPC) FindById(ctx context.Context, r *userService.FindById
 	return &userService.FindByIdResponse{User: u.userModelToProto(user)}, nil
 }
 
-// GetMe to get session id from, ctx metadata, find user by uuid and returns it
+// GetMe find the user of the principal injected by the auth interceptor
 func (u *usersServiceGRPC) GetMe(ctx context.Context, r *userService.GetMeRequest) (*userService.GetMeResponse, error) {
-	sessID, err := u.getSessionIDFromCtx(ctx)
-	if err != nil {
-		u.logger.Errorf("getSessionIDFromCtx: %v", err)
-		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "sessUC.getSessionIDFromCtx: %v", err)
-	}
-
-	session, err := u.sessUC.GetSessionById(ctx, sessID)
-	if err != nil {
-		u.logger.Errorf("sessUC.GetSessionById: %v", err)
-		if errors.Is(err, redis.Nil) {
-			return nil, status.Errorf(codes.NotFound, "sessUC.GetSessionById: %v", grpc_errors.ErrNotFound)
-		}
-		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "sessUC.GetSessionById: %v", err)
+	principal, ok := interceptors.PrincipalFromCtx(ctx)
+	if !ok || principal.Role == models.LibrarianRole {
+		u.logger.Errorf("PrincipalFromCtx: %+v", principal)
+		return nil, status.Errorf(codes.PermissionDenied, "PrincipalFromCtx: %v", grpc_errors.ErrPermissionDenied)
 	}
 
-	user, err := u.userUC.CachedFindById(ctx, session.UserID)
+	user, err := u.userUC.CachedFindById(ctx, principal.ID)
 	if err != nil {
 		u.logger.Errorf("userUC.CachedFindById: %v", err)
 		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "userUC.CachedFindById: %v", err)
@@ -126,13 +166,13 @@ func (u *usersServiceGRPC) GetMe(ctx context.Context, r *userService.GetMeReques
 
 // Logout user, delete current session
 func (u *usersServiceGRPC) Logout(ctx context.Context, request *userService.LogoutRequest) (*userService.LogoutResponse, error) {
-	sessID, err := u.getSessionIDFromCtx(ctx)
-	if err != nil {
-		u.logger.Errorf("getSessionIDFromCtx: %v", err)
-		return nil, err
+	principal, ok := interceptors.PrincipalFromCtx(ctx)
+	if !ok {
+		u.logger.Errorf("PrincipalFromCtx: %+v", principal)
+		return nil, status.Errorf(codes.Unauthenticated, "PrincipalFromCtx: %v", grpc_errors.ErrInvalidSessionId)
 	}
 
-	if err := u.sessUC.DeleteById(ctx, sessID); err != nil {
+	if err := u.sessUC.DeleteById(ctx, principal.SessionID); err != nil {
 		u.logger.Errorf("sessUC.DeleteById: %v", err)
 		return nil, status.Errorf(grpc_errors.ParseGRPCErrStatusCode(err), "sessUC.DeleteById: %v", err)
 	}
@@ -140,13 +180,19 @@ func (u *usersServiceGRPC) Logout(ctx context.Context, request *userService.Logo
 	return &userService.LogoutResponse{}, nil
 }
 
-func (u *usersServiceGRPC) registerReqToUserModel(r *userService.RegisterRequest) (*models.User, error) {
+// Only admins choose the role of the new user, anyone else registers a plain user
+func (u *usersServiceGRPC) registerReqToUserModel(ctx context.Context, r *userService.RegisterRequest) (*models.User, error) {
+	role := models.UserRoleUser
+	if principal, ok := interceptors.PrincipalFromCtx(ctx); ok && principal.HasRole(models.UserRoleAdmin) && r.GetRole() != "" {
+		role = r.GetRole()
+	}
+
 	avatar := r.GetAvatar()
 	userCandidate := &models.User{
 		Email:     r.GetEmail(),
 		FirstName: r.GetFirstName(),
 		LastName:  r.GetLastName(),
-		Role:      r.GetRole(),
+		Role:      role,
 		Avatar:    &avatar,
 		Password:  r.GetPassword(),
 	}
@@ -163,7 +209,6 @@ func (u *usersServiceGRPC) userModelToProto(user *models.User) *userService.User
 		Uuid:      user.UserID.String(),
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
-		Password:  user.Password,
 		Email:     user.Email,
 		Role:      user.Role,
 		Avatar:    user.GetAvatar(),
@@ -172,17 +217,3 @@ func (u *usersServiceGRPC) userModelToProto(user *models.User) *userService.User
 	}
 	return userProto
 }
-
-func (u *usersServiceGRPC) getSessionIDFromCtx(ctx context.Context) (string, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return "", status.Errorf(codes.Unauthenticated, "metadata.FromIncomingContext: %v", grpc_errors.ErrNoCtxMetaData)
-	}
-
-	sessionID := md.Get("session_id")
-	if sessionID[0] == "" {
-		return "", status.Errorf(codes.PermissionDenied, "md.Get sessionId: %v", grpc_errors.ErrInvalidSessionId)
-	}
-
-	return sessionID[0], nil
-}
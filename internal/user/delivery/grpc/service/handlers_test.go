@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/interceptors"
+	"github.com/dinorain/pinjembuku/internal/models"
+	mockSessUC "github.com/dinorain/pinjembuku/internal/session/mock"
+	"github.com/dinorain/pinjembuku/internal/user/mock"
+	"github.com/dinorain/pinjembuku/pkg/grpc_test_utils"
+	"github.com/dinorain/pinjembuku/pkg/logger"
+	userService "github.com/dinorain/pinjembuku/proto"
+)
+
+func newUserServiceClient(t *testing.T, ctrl *gomock.Controller, principal *models.Principal) (userService.UserServiceClient, *mock.MockUserUseCase) {
+	userUC := mock.NewMockUserUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+
+	conn := grpc_test_utils.NewBufconnClientConn(t, func(grpcServer *grpc.Server) {
+		userService.RegisterUserServiceServer(grpcServer, NewAuthServerGRPC(appLogger, cfg, nil, userUC, sessUC))
+	},
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if principal != nil {
+				ctx = interceptors.ContextWithPrincipal(ctx, principal)
+			}
+			return handler(ctx, req)
+		}),
+	)
+
+	return userService.NewUserServiceClient(conn), userUC
+}
+
+func TestUsersService_Register(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	registerReq := &userService.RegisterRequest{
+		Email:     "email@gmail.com",
+		FirstName: "FirstName",
+		LastName:  "LastName",
+		Password:  "123456",
+		Role:      models.UserRoleAdmin,
+	}
+
+	registered := func(userUC *mock.MockUserUseCase, role string) {
+		userUC.EXPECT().Register(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, user *models.User) (*models.User, error) {
+			require.Equal(t, role, user.Role)
+			user.UserID = uuid.New()
+			return user, nil
+		})
+	}
+
+	t.Run("Admin picks role", func(t *testing.T) {
+		client, userUC := newUserServiceClient(t, ctrl, &models.Principal{ID: uuid.New(), Kind: models.PrincipalKindUser, Role: models.UserRoleAdmin})
+		registered(userUC, models.UserRoleAdmin)
+
+		res, err := client.Register(context.Background(), registerReq)
+		require.NoError(t, err)
+		require.Equal(t, models.UserRoleAdmin, res.GetUser().GetRole())
+		require.Empty(t, res.GetUser().GetPassword())
+	})
+
+	t.Run("Role of non admin ignored", func(t *testing.T) {
+		client, userUC := newUserServiceClient(t, ctrl, nil)
+		registered(userUC, models.UserRoleUser)
+
+		res, err := client.Register(context.Background(), registerReq)
+		require.NoError(t, err)
+		require.Equal(t, models.UserRoleUser, res.GetUser().GetRole())
+	})
+}
+
+func TestUsersService_FindById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userUUID := uuid.New()
+	mockUser := &models.User{UserID: userUUID, Email: "email@gmail.com", Role: models.UserRoleUser, Password: "hash"}
+
+	t.Run("Self", func(t *testing.T) {
+		client, userUC := newUserServiceClient(t, ctrl, &models.Principal{ID: userUUID, Kind: models.PrincipalKindUser, Role: models.UserRoleUser})
+		userUC.EXPECT().CachedFindById(gomock.Any(), userUUID).Return(mockUser, nil)
+
+		res, err := client.FindById(context.Background(), &userService.FindByIdRequest{Uuid: userUUID.String()})
+		require.NoError(t, err)
+		require.Equal(t, userUUID.String(), res.GetUser().GetUuid())
+		require.Empty(t, res.GetUser().GetPassword())
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		client, userUC := newUserServiceClient(t, ctrl, &models.Principal{ID: uuid.New(), Kind: models.PrincipalKindUser, Role: models.UserRoleAdmin})
+		userUC.EXPECT().CachedFindById(gomock.Any(), userUUID).Return(mockUser, nil)
+
+		_, err := client.FindById(context.Background(), &userService.FindByIdRequest{Uuid: userUUID.String()})
+		require.NoError(t, err)
+	})
+
+	t.Run("Other user", func(t *testing.T) {
+		client, _ := newUserServiceClient(t, ctrl, &models.Principal{ID: uuid.New(), Kind: models.PrincipalKindUser, Role: models.UserRoleUser})
+
+		_, err := client.FindById(context.Background(), &userService.FindByIdRequest{Uuid: userUUID.String()})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Librarian", func(t *testing.T) {
+		client, _ := newUserServiceClient(t, ctrl, &models.Principal{ID: userUUID, Kind: models.PrincipalKindLibrarian, Role: models.LibrarianRole})
+
+		_, err := client.FindById(context.Background(), &userService.FindByIdRequest{Uuid: userUUID.String()})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestUsersService_FindByEmail(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userUUID := uuid.New()
+	mockUser := &models.User{UserID: userUUID, Email: "email@gmail.com", Role: models.UserRoleUser, Password: "hash"}
+
+	t.Run("Self", func(t *testing.T) {
+		client, userUC := newUserServiceClient(t, ctrl, &models.Principal{ID: userUUID, Kind: models.PrincipalKindUser, Role: models.UserRoleUser})
+		userUC.EXPECT().CachedFindById(gomock.Any(), userUUID).Return(mockUser, nil)
+
+		res, err := client.FindByEmail(context.Background(), &userService.FindByEmailRequest{Email: "Email@gmail.com"})
+		require.NoError(t, err)
+		require.Equal(t, userUUID.String(), res.GetUser().GetUuid())
+		require.Empty(t, res.GetUser().GetPassword())
+	})
+
+	t.Run("Other user", func(t *testing.T) {
+		client, userUC := newUserServiceClient(t, ctrl, &models.Principal{ID: userUUID, Kind: models.PrincipalKindUser, Role: models.UserRoleUser})
+		userUC.EXPECT().CachedFindById(gomock.Any(), userUUID).Return(mockUser, nil)
+
+		_, err := client.FindByEmail(context.Background(), &userService.FindByEmailRequest{Email: "other@gmail.com"})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		client, userUC := newUserServiceClient(t, ctrl, &models.Principal{ID: uuid.New(), Kind: models.PrincipalKindUser, Role: models.UserRoleAdmin})
+		userUC.EXPECT().FindByEmail(gomock.Any(), "other@gmail.com").Return(&models.User{UserID: uuid.New(), Email: "other@gmail.com"}, nil)
+
+		res, err := client.FindByEmail(context.Background(), &userService.FindByEmailRequest{Email: "other@gmail.com"})
+		require.NoError(t, err)
+		require.Equal(t, "other@gmail.com", res.GetUser().GetEmail())
+	})
+}
@@ -5,16 +5,18 @@ import (
 	"github.com/dinorain/pinjembuku/internal/session"
 	"github.com/dinorain/pinjembuku/internal/user"
 	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
 )
 
 type usersServiceGRPC struct {
 	logger logger.Logger
 	cfg    *config.Config
+	tokens *tokens.Service
 	userUC user.UserUseCase
 	sessUC session.SessUseCase
 }
 
 // Auth service constructor
-func NewAuthServerGRPC(logger logger.Logger, cfg *config.Config, userUC user.UserUseCase, sessUC session.SessUseCase) *usersServiceGRPC {
-	return &usersServiceGRPC{logger: logger, cfg: cfg, userUC: userUC, sessUC: sessUC}
+func NewAuthServerGRPC(logger logger.Logger, cfg *config.Config, tokens *tokens.Service, userUC user.UserUseCase, sessUC session.SessUseCase) *usersServiceGRPC {
+	return &usersServiceGRPC{logger: logger, cfg: cfg, tokens: tokens, userUC: userUC, sessUC: sessUC}
 }
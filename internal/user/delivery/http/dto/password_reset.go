@@ -0,0 +1,10 @@
+package dto
+
+type UserForgotPasswordRequestDto struct {
+	Email string `json:"email" validate:"required,lte=60,email"`
+}
+
+type UserResetPasswordRequestDto struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/dinorain/pinjembuku/internal/models"
+)
+
+type UserSessionResponseDto struct {
+	SessionID string    `json:"session_id"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	Current   bool      `json:"current"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserSessionFindResponseDto struct {
+	Data []*UserSessionResponseDto `json:"data"`
+}
+
+func UserSessionResponseFromModel(session *models.Session, currentSessionID string) *UserSessionResponseDto {
+	return &UserSessionResponseDto{
+		SessionID: session.SessionID,
+		IP:        session.IP,
+		UserAgent: session.UserAgent,
+		Current:   session.SessionID == currentSessionID,
+		CreatedAt: session.CreatedAt,
+	}
+}
+
+func UserSessionFindResponseFromModels(sessions []*models.Session, currentSessionID string) *UserSessionFindResponseDto {
+	data := make([]*UserSessionResponseDto, 0, len(sessions))
+	for _, session := range sessions {
+		data = append(data, UserSessionResponseFromModel(session, currentSessionID))
+	}
+	return &UserSessionFindResponseDto{Data: data}
+}
@@ -9,14 +9,15 @@ import (
 )
 
 type UserResponseDto struct {
-	UserID          uuid.UUID `json:"user_id"`
-	Email           string    `json:"email"`
-	FirstName       string    `json:"first_name"`
-	LastName        string    `json:"last_name"`
-	Role            string    `json:"role"`
-	Avatar          *string   `json:"avatar"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	UserID          uuid.UUID  `json:"user_id"`
+	Email           string     `json:"email"`
+	FirstName       string     `json:"first_name"`
+	LastName        string     `json:"last_name"`
+	Role            string     `json:"role"`
+	Avatar          *string    `json:"avatar"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 func UserResponseFromModel(user *models.User) *UserResponseDto {
@@ -27,6 +28,7 @@ func UserResponseFromModel(user *models.User) *UserResponseDto {
 		LastName:        user.LastName,
 		Role:            user.Role,
 		Avatar:          user.Avatar,
+		EmailVerifiedAt: user.EmailVerifiedAt,
 		CreatedAt:       user.CreatedAt,
 		UpdatedAt:       user.UpdatedAt,
 	}
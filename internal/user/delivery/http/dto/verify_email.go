@@ -0,0 +1,9 @@
+package dto
+
+type UserVerifyEmailRequestDto struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type UserResendVerificationRequestDto struct {
+	Email string `json:"email" validate:"required,lte=60,email"`
+}
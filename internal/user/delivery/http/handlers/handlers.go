@@ -1,8 +1,8 @@
 package handlers
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"net/http"
 	"strings"
 
@@ -19,8 +19,10 @@ import (
 	"github.com/dinorain/pinjembuku/internal/user"
 	"github.com/dinorain/pinjembuku/internal/user/delivery/http/dto"
 	"github.com/dinorain/pinjembuku/pkg/constants"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
 	httpErrors "github.com/dinorain/pinjembuku/pkg/http_errors"
 	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
 	"github.com/dinorain/pinjembuku/pkg/utils"
 )
 
@@ -30,6 +32,7 @@ type userHandlersHTTP struct {
 	cfg    *config.Config
 	mw     middlewares.MiddlewareManager
 	v      *validator.Validate
+	tokens *tokens.Service
 	userUC user.UserUseCase
 	sessUC session.SessUseCase
 }
@@ -42,10 +45,11 @@ func NewUserHandlersHTTP(
 	cfg *config.Config,
 	mw middlewares.MiddlewareManager,
 	v *validator.Validate,
+	tokens *tokens.Service,
 	userUC user.UserUseCase,
 	sessUC session.SessUseCase,
 ) *userHandlersHTTP {
-	return &userHandlersHTTP{group: group, logger: logger, cfg: cfg, mw: mw, v: v, userUC: userUC, sessUC: sessUC}
+	return &userHandlersHTTP{group: group, logger: logger, cfg: cfg, mw: mw, v: v, tokens: tokens, userUC: userUC, sessUC: sessUC}
 }
 
 // Register
@@ -90,6 +94,191 @@ func (h *userHandlersHTTP) Register() echo.HandlerFunc {
 	}
 }
 
+// Signup
+// @Tags Users
+// @Summary To sign up user
+// @Description Create unverified user and email the verification link
+// @Accept json
+// @Produce json
+// @Param payload body dto.UserSignupRequestDto true "Payload"
+// @Success 201 {object} dto.UserRegisterResponseDto
+// @Router /user/signup [post]
+func (h *userHandlersHTTP) Signup() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		signupDto := &dto.UserSignupRequestDto{}
+		if err := c.Bind(signupDto); err != nil {
+			h.logger.WarnMsg("bind", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.v.StructCtx(ctx, signupDto); err != nil {
+			h.logger.WarnMsg("validate", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		user, err := h.registerReqToUserModel(&dto.UserRegisterRequestDto{
+			Email:     signupDto.Email,
+			FirstName: signupDto.FirstName,
+			LastName:  signupDto.LastName,
+			Password:  signupDto.Password,
+			Role:      models.UserRoleUser,
+		})
+		if err != nil {
+			h.logger.Errorf("registerReqToUserModel: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		createdUser, err := h.userUC.Signup(ctx, user)
+		if err != nil {
+			h.logger.Errorf("userUC.Signup: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusCreated, dto.UserRegisterResponseDto{UserID: createdUser.UserID})
+	}
+}
+
+// VerifyEmail
+// @Tags Users
+// @Summary Verify email
+// @Description Verify email address with the token of the verification link
+// @Accept json
+// @Produce json
+// @Param payload body dto.UserVerifyEmailRequestDto true "Payload"
+// @Success 200 {object} dto.UserResponseDto
+// @Router /user/verify [post]
+func (h *userHandlersHTTP) VerifyEmail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		verifyDto := &dto.UserVerifyEmailRequestDto{}
+		if err := c.Bind(verifyDto); err != nil {
+			h.logger.WarnMsg("bind", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.v.StructCtx(ctx, verifyDto); err != nil {
+			h.logger.WarnMsg("validate", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		user, err := h.userUC.VerifyEmail(ctx, verifyDto.Token)
+		if err != nil {
+			h.logger.Warnf("userUC.VerifyEmail: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, dto.UserResponseFromModel(user))
+	}
+}
+
+// ResendVerification
+// @Tags Users
+// @Summary Resend verification email
+// @Description Email a new verification link to an unverified account, answers the same for unknown or verified emails
+// @Accept json
+// @Produce json
+// @Param payload body dto.UserResendVerificationRequestDto true "Payload"
+// @Success 202 {object} nil
+// @Router /user/verify/resend [post]
+func (h *userHandlersHTTP) ResendVerification() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		resendDto := &dto.UserResendVerificationRequestDto{}
+		if err := c.Bind(resendDto); err != nil {
+			h.logger.WarnMsg("bind", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.v.StructCtx(ctx, resendDto); err != nil {
+			h.logger.WarnMsg("validate", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.userUC.ResendVerification(ctx, strings.ToLower(resendDto.Email)); err != nil {
+			h.logger.Errorf("userUC.ResendVerification: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusAccepted, nil)
+	}
+}
+
+// ForgotPassword
+// @Tags Users
+// @Summary Forgot password
+// @Description Email a password reset link, answers the same whether the email is registered or not
+// @Accept json
+// @Produce json
+// @Param payload body dto.UserForgotPasswordRequestDto true "Payload"
+// @Success 202 {object} nil
+// @Router /user/password/forgot [post]
+func (h *userHandlersHTTP) ForgotPassword() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		forgotDto := &dto.UserForgotPasswordRequestDto{}
+		if err := c.Bind(forgotDto); err != nil {
+			h.logger.WarnMsg("bind", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.v.StructCtx(ctx, forgotDto); err != nil {
+			h.logger.WarnMsg("validate", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.userUC.ForgotPassword(ctx, strings.ToLower(forgotDto.Email)); err != nil {
+			h.logger.Errorf("userUC.ForgotPassword: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusAccepted, nil)
+	}
+}
+
+// ResetPassword
+// @Tags Users
+// @Summary Reset password
+// @Description Set a new password with the token of the reset link, signs out all sessions
+// @Accept json
+// @Produce json
+// @Param payload body dto.UserResetPasswordRequestDto true "Payload"
+// @Success 200 {object} nil
+// @Router /user/password/reset [post]
+func (h *userHandlersHTTP) ResetPassword() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		resetDto := &dto.UserResetPasswordRequestDto{}
+		if err := c.Bind(resetDto); err != nil {
+			h.logger.WarnMsg("bind", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.v.StructCtx(ctx, resetDto); err != nil {
+			h.logger.WarnMsg("validate", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		user, err := h.userUC.ResetPassword(ctx, resetDto.Token, resetDto.Password)
+		if err != nil {
+			h.logger.Warnf("userUC.ResetPassword: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.revokeAllSessions(ctx, user.UserID); err != nil {
+			h.logger.Errorf("revokeAllSessions: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, nil)
+	}
+}
+
 // Login
 // @Tags Users
 // @Summary User login
@@ -120,21 +309,29 @@ func (h *userHandlersHTTP) Login() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, errors.New("invalid email"), h.cfg.Http.DebugErrorsResponse)
 		}
 
-		user, err := h.userUC.Login(ctx, email, loginDto.Password)
+		user, err := h.userUC.Login(ctx, email, loginDto.Password, c.RealIP())
 		if err != nil {
 			h.logger.Errorf("userUC.Login: %v", email)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
 		session, err := h.sessUC.CreateSession(ctx, &models.Session{
-			UserID: user.UserID,
-		}, h.cfg.Session.Expire)
+			UserID:    user.UserID,
+			IP:        c.RealIP(),
+			UserAgent: c.Request().UserAgent(),
+		}, h.tokens.RefreshTokenExpire())
 		if err != nil {
 			h.logger.Errorf("sessUC.CreateSession: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		accessToken, refreshToken, err := h.userUC.GenerateTokenPair(user, session)
+		refreshTokenID, err := h.sessUC.IssueRefreshToken(ctx, session, h.tokens.RefreshTokenExpire())
+		if err != nil {
+			h.logger.Errorf("sessUC.IssueRefreshToken: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		accessToken, refreshToken, err := h.userUC.GenerateTokenPair(user, session, refreshTokenID)
 		if err != nil {
 			return err
 		}
@@ -154,26 +351,39 @@ func (h *userHandlersHTTP) Login() echo.HandlerFunc {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param size query string false "pagination size"
-// @Param page query string false "pagination page"
+// @Param page query string false "pagination page", offset mode sorted by created_at:desc,id:desc
+// @Param cursor query string false "next_cursor of the previous page, takes precedence over page"
 // @Success 200 {object} dto.UserFindResponseDto
 // @Router /user [get]
 func (h *userHandlersHTTP) FindAll() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
 		pq := utils.NewPaginationFromQueryParams(c.QueryParam(constants.Size), c.QueryParam(constants.Page))
+		if err := pq.SetCursor(c.QueryParam(constants.Cursor)); err != nil {
+			h.logger.WarnMsg("pq.SetCursor", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
 		users, err := h.userUC.FindAll(ctx, pq)
 		if err != nil {
 			h.logger.Errorf("userUC.FindAll: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
+		totalCount, err := h.userUC.CountAll(ctx)
+		if err != nil {
+			h.logger.Errorf("userUC.CountAll: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		var last *utils.Cursor
+		if n := len(users); n > 0 {
+			last = utils.NewCursor(users[n-1].CreatedAt, users[n-1].UserID)
+		}
+
 		return c.JSON(http.StatusOK, dto.UserFindResponseDto{
 			Data: users,
-			Meta: utils.PaginationMetaDto{
-				Limit:  pq.GetLimit(),
-				Offset: pq.GetOffset(),
-				Page:   pq.GetPage(),
-			},
+			Meta: utils.NewKeysetPaginationMetaDto(pq, totalCount, len(users), last),
 		})
 	}
 }
@@ -228,14 +438,14 @@ func (h *userHandlersHTTP) UpdateById() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		_, userID, role, err := h.getSessionIDFromCtx(c)
+		principal, err := middlewares.PrincipalFromCtx(c)
 		if err != nil {
-			h.logger.Errorf("getSessionIDFromCtx: %v", err)
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		if role != models.UserRoleAdmin && userID != userUUID.String() {
-			h.logger.Warnf("models.UserRoleAdmin: %v", role)
+		if principal.Role != models.UserRoleAdmin && principal.ID != userUUID {
+			h.logger.Warnf("models.UserRoleAdmin: %v", principal.Role)
 			return httpErrors.NewForbiddenError(c, nil, h.cfg.Http.DebugErrorsResponse)
 		}
 
@@ -268,6 +478,13 @@ func (h *userHandlersHTTP) UpdateById() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
+		if updateDto.Password != nil {
+			if err := h.revokeAllSessions(ctx, userUUID); err != nil {
+				h.logger.Errorf("revokeAllSessions: %v", err)
+				return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+			}
+		}
+
 		return c.JSON(http.StatusOK, dto.UserResponseFromModel(user))
 	}
 }
@@ -297,6 +514,11 @@ func (h *userHandlersHTTP) DeleteById() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
+		if err := h.revokeAllSessions(ctx, userUUID); err != nil {
+			h.logger.Errorf("revokeAllSessions: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
 		return c.JSON(http.StatusOK, nil)
 	}
 }
@@ -304,7 +526,7 @@ func (h *userHandlersHTTP) DeleteById() echo.HandlerFunc {
 // GetMe
 // @Tags Users
 // @Summary Find me
-// @Description Get session id from token, find user by uuid and returns it
+// @Description Get user id from token, find user by uuid and returns it
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
@@ -313,22 +535,13 @@ func (h *userHandlersHTTP) DeleteById() echo.HandlerFunc {
 func (h *userHandlersHTTP) GetMe() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
-		sessID, _, _, err := h.getSessionIDFromCtx(c)
+		principal, err := middlewares.PrincipalFromCtx(c)
 		if err != nil {
-			h.logger.Errorf("getSessionIDFromCtx: %v", err)
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		session, err := h.sessUC.GetSessionById(ctx, sessID)
-		if err != nil {
-			h.logger.Errorf("sessUC.GetSessionById: %v", err)
-			if errors.Is(err, redis.Nil) {
-				return httpErrors.NewUnauthorizedError(c, nil, h.cfg.Http.DebugErrorsResponse)
-			}
-			return httpErrors.NewUnauthorizedError(c, err, h.cfg.Http.DebugErrorsResponse)
-		}
-
-		user, err := h.userUC.CachedFindById(ctx, session.UserID)
+		user, err := h.userUC.CachedFindById(ctx, principal.ID)
 		if err != nil {
 			h.logger.Errorf("userUC.CachedFindById: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
@@ -350,16 +563,147 @@ func (h *userHandlersHTTP) GetMe() echo.HandlerFunc {
 func (h *userHandlersHTTP) Logout() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
-		sessID, _, _, err := h.getSessionIDFromCtx(c)
+		principal, err := middlewares.PrincipalFromCtx(c)
 		if err != nil {
-			h.logger.Errorf("getSessionIDFromCtx: %v", err)
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.sessUC.DeleteById(ctx, principal.SessionID); err != nil {
+			h.logger.Errorf("sessUC.DeleteById: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
+		h.mw.ForgetSession(principal.SessionID)
+
+		return c.JSON(http.StatusOK, nil)
+	}
+}
+
+// GetMySessions
+// @Tags Users
+// @Summary Find my sessions
+// @Description Find all active sessions of current user, newest first
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.UserSessionFindResponseDto
+// @Router /user/me/sessions [get]
+func (h *userHandlersHTTP) GetMySessions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		sessions, err := h.sessUC.FindAllByUserId(ctx, principal.ID)
+		if err != nil {
+			h.logger.Errorf("sessUC.FindAllByUserId: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, dto.UserSessionFindResponseFromModels(sessions, principal.SessionID))
+	}
+}
+
+// DeleteMySessionById
+// @Tags Users
+// @Summary Revoke my session
+// @Description Delete one of sessions of current user
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} nil
+// @Param id path string true "Session ID"
+// @Router /user/me/sessions/{id} [delete]
+func (h *userHandlersHTTP) DeleteMySessionById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		principal, err := middlewares.PrincipalFromCtx(c)
+		if err != nil {
+			h.logger.Errorf("middlewares.PrincipalFromCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		sessID := c.Param("id")
+		session, err := h.sessUC.GetSessionById(ctx, sessID)
+		if err != nil {
+			h.logger.Warnf("sessUC.GetSessionById: %v", err)
+			if errors.Is(err, redis.Nil) {
+				return httpErrors.NewNotFoundError(c, nil, h.cfg.Http.DebugErrorsResponse)
+			}
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if session.UserID != principal.ID {
+			return httpErrors.NewNotFoundError(c, nil, h.cfg.Http.DebugErrorsResponse)
+		}
 
 		if err := h.sessUC.DeleteById(ctx, sessID); err != nil {
 			h.logger.Errorf("sessUC.DeleteById: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
+		h.mw.ForgetSession(sessID)
+
+		return c.JSON(http.StatusOK, nil)
+	}
+}
+
+// DeleteAllSessionsById
+// @Tags Users
+// @Summary Revoke all sessions
+// @Description Admin delete all sessions of user
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} nil
+// @Param id path string true "User ID"
+// @Router /user/{id}/sessions [delete]
+func (h *userHandlersHTTP) DeleteAllSessionsById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		userUUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.revokeAllSessions(ctx, userUUID); err != nil {
+			h.logger.Errorf("revokeAllSessions: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		return c.JSON(http.StatusOK, nil)
+	}
+}
+
+// UnlockById
+// @Tags Users
+// @Summary Unlock login
+// @Description Admin reset failed logins of user, optionally of a client ip too
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} nil
+// @Param id path string true "User ID"
+// @Param ip query string false "Client IP"
+// @Router /user/{id}/lockout [delete]
+func (h *userHandlersHTTP) UnlockById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		userUUID, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			h.logger.WarnMsg("uuid.FromString", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
+
+		if err := h.userUC.UnlockById(ctx, userUUID, c.QueryParam("ip")); err != nil {
+			h.logger.Errorf("userUC.UnlockById: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
+		}
 
 		return c.JSON(http.StatusOK, nil)
 	}
@@ -368,7 +712,7 @@ func (h *userHandlersHTTP) Logout() echo.HandlerFunc {
 // RefreshToken
 // @Tags Users
 // @Summary Refresh access token
-// @Description Refresh access token
+// @Description Refresh access token, refresh tokens are single use and reusing one revokes its session
 // @Accept json
 // @Produce json
 // @Param payload body dto.UserRefreshTokenDto true "Payload"
@@ -384,40 +728,36 @@ func (h *userHandlersHTTP) RefreshToken() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		token, err := jwt.Parse(refreshTokenDto.RefreshToken, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				h.logger.Errorf("jwt.SigningMethodHMAC: %v", token.Header["alg"])
-				return nil, fmt.Errorf("jwt.SigningMethodHMAC: %v", token.Header["alg"])
-			}
-
-			return []byte(h.cfg.Server.JwtSecretKey), nil
-		})
-
+		token, err := h.tokens.ParseRefreshToken(refreshTokenDto.RefreshToken)
 		if err != nil {
-			h.logger.Warnf("jwt.Parse")
+			h.logger.Warnf("tokens.ParseRefreshToken: %v", err)
 			return httpErrors.ErrorCtxResponse(c, errors.New("invalid refresh token"), h.cfg.Http.DebugErrorsResponse)
 		}
+		claims := token.Claims.(jwt.MapClaims)
+		sessID := claims["session_id"].(string)
+		refreshTokenID := claims["jti"].(string)
 
-		if !token.Valid {
-			h.logger.Warnf("token.Valid")
-			return httpErrors.ErrorCtxResponse(c, errors.New("invalid refresh token"), h.cfg.Http.DebugErrorsResponse)
-		}
-
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			h.logger.Warnf("jwt.MapClaims: %+v", token.Claims)
-			return httpErrors.ErrorCtxResponse(c, errors.New("invalid refresh token"), h.cfg.Http.DebugErrorsResponse)
+		session, err := h.sessUC.GetSessionById(ctx, sessID)
+		if err != nil {
+			h.logger.Errorf("sessUC.GetSessionById: %v", err)
+			if errors.Is(err, redis.Nil) {
+				return httpErrors.NewUnauthorizedError(c, nil, h.cfg.Http.DebugErrorsResponse)
+			}
+			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
-
-		sessID, ok := claims["session_id"].(string)
-		if !ok {
-			h.logger.Warnf("session_id: %+v", claims)
-			return httpErrors.ErrorCtxResponse(c, errors.New("invalid refresh token"), h.cfg.Http.DebugErrorsResponse)
+		if session.UserID.String() != claims["sub"] {
+			h.logger.Warnf("session %s does not belong to %v", sessID, claims["sub"])
+			return httpErrors.NewUnauthorizedError(c, nil, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		session, err := h.sessUC.GetSessionById(ctx, sessID)
+		nextRefreshTokenID, err := h.sessUC.RotateRefreshToken(ctx, sessID, refreshTokenID)
 		if err != nil {
-			h.logger.Errorf("sessUC.GetSessionById: %v", err)
+			if errors.Is(err, grpc_errors.ErrRefreshTokenReused) {
+				h.logger.Warnf("security event: refresh token reuse, revoked session %s of user %s, ip: %s", sessID, session.UserID, c.RealIP())
+				h.mw.ForgetSession(sessID)
+				return httpErrors.NewUnauthorizedError(c, nil, h.cfg.Http.DebugErrorsResponse)
+			}
+			h.logger.Errorf("sessUC.RotateRefreshToken: %v", err)
 			if errors.Is(err, redis.Nil) {
 				return httpErrors.NewUnauthorizedError(c, nil, h.cfg.Http.DebugErrorsResponse)
 			}
@@ -430,7 +770,7 @@ func (h *userHandlersHTTP) RefreshToken() echo.HandlerFunc {
 			return httpErrors.ErrorCtxResponse(c, err, h.cfg.Http.DebugErrorsResponse)
 		}
 
-		accessToken, refreshToken, err := h.userUC.GenerateTokenPair(user, sessID)
+		accessToken, refreshToken, err := h.userUC.GenerateTokenPair(user, sessID, nextRefreshTokenID)
 		if err != nil {
 			return err
 		}
@@ -442,38 +782,17 @@ func (h *userHandlersHTTP) RefreshToken() echo.HandlerFunc {
 	}
 }
 
-func (h *userHandlersHTTP) getSessionIDFromCtx(c echo.Context) (sessionID string, userID string, role string, err error) {
-	user, ok := c.Get("user").(*jwt.Token)
-	if !ok {
-		h.logger.Warnf("jwt.Token: %+v", c.Get("user"))
-		return "", "", "", errors.New("invalid token header")
-	}
-
-	claims, ok := user.Claims.(jwt.MapClaims)
-	if !ok {
-		h.logger.Warnf("jwt.MapClaims: %+v", c.Get("user"))
-		return "", "", "", errors.New("invalid token header")
-	}
-
-	sessionID, ok = claims["session_id"].(string)
-	if !ok {
-		h.logger.Warnf("session_id: %+v", claims)
-		return "", "", "", errors.New("invalid token header")
-	}
-
-	userID, ok = claims["user_id"].(string)
-	if !ok {
-		h.logger.Warnf("user_id: %+v", claims)
-		return "", "", "", errors.New("invalid token header")
+// revokeAllSessions deletes all sessions of user and drops them from the session cache of this instance
+func (h *userHandlersHTTP) revokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	sessionIDs, err := h.sessUC.DeleteAllByUserId(ctx, userID)
+	if err != nil {
+		return err
 	}
 
-	role, ok = claims["role"].(string)
-	if !ok {
-		h.logger.Warnf("role: %+v", claims)
-		return "", "", "", errors.New("invalid token header")
+	for _, sessionID := range sessionIDs {
+		h.mw.ForgetSession(sessionID)
 	}
-
-	return sessionID, userID, role, nil
+	return nil
 }
 
 func (h *userHandlersHTTP) registerReqToUserModel(r *dto.UserRegisterRequestDto) (*models.User, error) {
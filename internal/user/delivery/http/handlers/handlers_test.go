@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -24,7 +25,10 @@ import (
 	"github.com/dinorain/pinjembuku/internal/user/delivery/http/dto"
 	"github.com/dinorain/pinjembuku/internal/user/mock"
 	"github.com/dinorain/pinjembuku/pkg/converter"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/jwtkeys"
 	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
 )
 
 func TestUsersService_Register(t *testing.T) {
@@ -37,12 +41,15 @@ func TestUsersService_Register(t *testing.T) {
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
 	appLogger := logger.NewAppLogger(nil)
-	mw := middlewares.NewMiddlewareManager(appLogger, nil)
+	mw := middlewares.NewMiddlewareManager(appLogger, nil, nil, nil)
 
 	e := echo.New()
 	v := validator.New()
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
-	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, userUC, sessUC)
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, tokenService, userUC, sessUC)
 
 	reqDto := &dto.UserRegisterRequestDto{
 		Email:     "email@gmail.com",
@@ -72,6 +79,176 @@ func TestUsersService_Register(t *testing.T) {
 	require.Equal(t, buf.String(), res.Body.String())
 }
 
+func TestUsersService_Signup(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userUC := mock.NewMockUserUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	appLogger := logger.NewAppLogger(nil)
+	mw := middlewares.NewMiddlewareManager(appLogger, nil, nil, nil)
+
+	e := echo.New()
+	v := validator.New()
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, tokenService, userUC, sessUC)
+
+	reqDto := &dto.UserSignupRequestDto{
+		Email:     "Email@gmail.com",
+		FirstName: "FirstName",
+		LastName:  "LastName",
+		Password:  "123456",
+	}
+
+	buf := &bytes.Buffer{}
+	_ = json.NewEncoder(buf).Encode(reqDto)
+
+	req := httptest.NewRequest(http.MethodPost, "/user/signup", buf)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+	ctx := e.NewContext(req, res)
+
+	userID := uuid.New()
+	buf, _ = converter.AnyToBytesBuffer(&dto.UserRegisterResponseDto{UserID: userID})
+
+	userUC.EXPECT().Signup(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, user *models.User) (*models.User, error) {
+		require.Equal(t, "email@gmail.com", user.Email)
+		require.Equal(t, models.UserRoleUser, user.Role)
+		require.NoError(t, user.ComparePasswords("123456"))
+		return &models.User{UserID: userID}, nil
+	})
+	require.NoError(t, handlers.Signup()(ctx))
+	require.Equal(t, http.StatusCreated, res.Code)
+	require.Equal(t, buf.String(), res.Body.String())
+}
+
+func TestUsersService_VerifyEmail(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userUC := mock.NewMockUserUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	e := echo.New()
+	v := validator.New()
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, nil, nil, nil)
+	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, tokenService, userUC, sessUC)
+
+	verify := func(token string) *httptest.ResponseRecorder {
+		buf := &bytes.Buffer{}
+		_ = json.NewEncoder(buf).Encode(&dto.UserVerifyEmailRequestDto{Token: token})
+
+		req := httptest.NewRequest(http.MethodPost, "/user/verify", buf)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		res := httptest.NewRecorder()
+		require.NoError(t, handlers.VerifyEmail()(e.NewContext(req, res)))
+		return res
+	}
+
+	t.Run("Verified", func(t *testing.T) {
+		verifiedAt := time.Now()
+		userUC.EXPECT().VerifyEmail(gomock.Any(), "token").Return(&models.User{UserID: uuid.New(), EmailVerifiedAt: &verifiedAt}, nil)
+
+		res := verify("token")
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Contains(t, res.Body.String(), "email_verified_at")
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		userUC.EXPECT().VerifyEmail(gomock.Any(), "used").Return(nil, grpc_errors.ErrInvalidToken)
+
+		res := verify("used")
+		require.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("Missing token", func(t *testing.T) {
+		res := verify("")
+		require.Equal(t, http.StatusBadRequest, res.Code)
+	})
+
+	t.Run("Resend", func(t *testing.T) {
+		userUC.EXPECT().ResendVerification(gomock.Any(), "email@gmail.com").Return(nil)
+
+		buf := &bytes.Buffer{}
+		_ = json.NewEncoder(buf).Encode(&dto.UserResendVerificationRequestDto{Email: "Email@gmail.com"})
+
+		req := httptest.NewRequest(http.MethodPost, "/user/verify/resend", buf)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		res := httptest.NewRecorder()
+		require.NoError(t, handlers.ResendVerification()(e.NewContext(req, res)))
+		require.Equal(t, http.StatusAccepted, res.Code)
+	})
+}
+
+func TestUsersService_PasswordReset(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userUC := mock.NewMockUserUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	e := echo.New()
+	v := validator.New()
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, nil, nil, nil)
+	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, tokenService, userUC, sessUC)
+
+	post := func(h echo.HandlerFunc, path string, reqDto interface{}) *httptest.ResponseRecorder {
+		buf := &bytes.Buffer{}
+		_ = json.NewEncoder(buf).Encode(reqDto)
+
+		req := httptest.NewRequest(http.MethodPost, path, buf)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		res := httptest.NewRecorder()
+		require.NoError(t, h(e.NewContext(req, res)))
+		return res
+	}
+
+	t.Run("Forgot", func(t *testing.T) {
+		userUC.EXPECT().ForgotPassword(gomock.Any(), "email@gmail.com").Return(nil)
+
+		res := post(handlers.ForgotPassword(), "/user/password/forgot", &dto.UserForgotPasswordRequestDto{Email: "Email@gmail.com"})
+		require.Equal(t, http.StatusAccepted, res.Code)
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		userUUID := uuid.New()
+		userUC.EXPECT().ResetPassword(gomock.Any(), "token", "new-password").Return(&models.User{UserID: userUUID}, nil)
+		sessUC.EXPECT().DeleteAllByUserId(gomock.Any(), userUUID).Return([]string{"s1"}, nil)
+
+		res := post(handlers.ResetPassword(), "/user/password/reset", &dto.UserResetPasswordRequestDto{Token: "token", Password: "new-password"})
+		require.Equal(t, http.StatusOK, res.Code)
+	})
+
+	t.Run("Reset with used token", func(t *testing.T) {
+		userUC.EXPECT().ResetPassword(gomock.Any(), "token", "new-password").Return(nil, grpc_errors.ErrInvalidToken)
+
+		res := post(handlers.ResetPassword(), "/user/password/reset", &dto.UserResetPasswordRequestDto{Token: "token", Password: "new-password"})
+		require.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+}
+
 func TestUsersService_Login(t *testing.T) {
 	t.Parallel()
 
@@ -82,12 +259,15 @@ func TestUsersService_Login(t *testing.T) {
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
 	appLogger := logger.NewAppLogger(nil)
-	mw := middlewares.NewMiddlewareManager(appLogger, nil)
+	mw := middlewares.NewMiddlewareManager(appLogger, nil, nil, nil)
 
 	e := echo.New()
 	v := validator.New()
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
-	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, userUC, sessUC)
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, tokenService, userUC, sessUC)
 
 	reqDto := &dto.UserLoginRequestDto{
 		Email:    "email@gmail.com",
@@ -98,6 +278,7 @@ func TestUsersService_Login(t *testing.T) {
 	_ = json.NewEncoder(&buf).Encode(reqDto)
 
 	req := httptest.NewRequest(http.MethodPost, "/user/login", &buf)
+	req.Header.Set("User-Agent", "pinjembuku-test")
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	res := httptest.NewRecorder()
 	ctx := e.NewContext(req, res)
@@ -111,13 +292,63 @@ func TestUsersService_Login(t *testing.T) {
 		Role:      "user",
 	}
 
-	userUC.EXPECT().Login(gomock.Any(), reqDto.Email, reqDto.Password).AnyTimes().Return(mockUser, nil)
-	sessUC.EXPECT().CreateSession(gomock.Any(), &models.Session{UserID: mockUser.UserID}, cfg.Session.Expire).AnyTimes().Return("s", nil)
-	userUC.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).AnyTimes().Return("rt", "at", nil)
+	userUC.EXPECT().Login(gomock.Any(), reqDto.Email, reqDto.Password, "192.0.2.1").AnyTimes().Return(mockUser, nil)
+	sessUC.EXPECT().CreateSession(gomock.Any(), &models.Session{UserID: mockUser.UserID, IP: "192.0.2.1", UserAgent: "pinjembuku-test"}, cfg.Jwt.RefreshTokenExpire).AnyTimes().Return("s", nil)
+	sessUC.EXPECT().IssueRefreshToken(gomock.Any(), "s", cfg.Jwt.RefreshTokenExpire).Return("rt-id", nil)
+	userUC.EXPECT().GenerateTokenPair(gomock.Any(), "s", "rt-id").Return("rt", "at", nil)
 	require.NoError(t, handlers.Login()(ctx))
 	require.Equal(t, http.StatusCreated, res.Code)
 }
 
+func TestUsersService_LoginLocked(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userUC := mock.NewMockUserUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, nil, nil, nil)
+
+	e := echo.New()
+	v := validator.New()
+	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, nil, userUC, sessUC)
+
+	newContext := func() (echo.Context, *httptest.ResponseRecorder) {
+		buf := &bytes.Buffer{}
+		_ = json.NewEncoder(buf).Encode(&dto.UserLoginRequestDto{Email: "email@gmail.com", Password: "123456"})
+
+		req := httptest.NewRequest(http.MethodPost, "/user/login", buf)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		res := httptest.NewRecorder()
+		return e.NewContext(req, res), res
+	}
+
+	t.Run("Account locked", func(t *testing.T) {
+		ctx, res := newContext()
+
+		userUC.EXPECT().Login(gomock.Any(), "email@gmail.com", "123456", "192.0.2.1").Return(nil, &grpc_errors.RetryError{Err: grpc_errors.ErrAccountLocked, RetryAfter: 1500 * time.Millisecond})
+
+		require.NoError(t, handlers.Login()(ctx))
+		require.Equal(t, http.StatusLocked, res.Code)
+		require.Equal(t, "2", res.Header().Get(echo.HeaderRetryAfter))
+	})
+
+	t.Run("Too many attempts", func(t *testing.T) {
+		ctx, res := newContext()
+
+		userUC.EXPECT().Login(gomock.Any(), "email@gmail.com", "123456", "192.0.2.1").Return(nil, &grpc_errors.RetryError{Err: grpc_errors.ErrTooManyAttempts, RetryAfter: 4 * time.Second})
+
+		require.NoError(t, handlers.Login()(ctx))
+		require.Equal(t, http.StatusTooManyRequests, res.Code)
+		require.Equal(t, "4", res.Header().Get(echo.HeaderRetryAfter))
+	})
+}
+
 func TestUsersService_FindAll(t *testing.T) {
 	t.Parallel()
 
@@ -128,12 +359,15 @@ func TestUsersService_FindAll(t *testing.T) {
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
 	appLogger := logger.NewAppLogger(nil)
-	mw := middlewares.NewMiddlewareManager(appLogger, nil)
+	mw := middlewares.NewMiddlewareManager(appLogger, nil, nil, nil)
 
 	e := echo.New()
 	v := validator.New()
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
-	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, userUC, sessUC)
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, tokenService, userUC, sessUC)
 
 	req := httptest.NewRequest(http.MethodGet, "/user", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -151,8 +385,15 @@ func TestUsersService_FindAll(t *testing.T) {
 	})
 
 	userUC.EXPECT().FindAll(gomock.Any(), gomock.Any()).AnyTimes().Return(users, nil)
+	userUC.EXPECT().CountAll(gomock.Any()).AnyTimes().Return(11, nil)
 	require.NoError(t, handlers.FindAll()(ctx))
 	require.Equal(t, http.StatusOK, res.Code)
+
+	findResponse := &dto.UserFindResponseDto{}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), findResponse))
+	require.Equal(t, 11, findResponse.Meta.TotalCount)
+	require.Equal(t, 2, findResponse.Meta.TotalPages)
+	require.True(t, findResponse.Meta.HasMore)
 }
 
 func TestUsersService_FindById(t *testing.T) {
@@ -164,13 +405,16 @@ func TestUsersService_FindById(t *testing.T) {
 	userUC := mock.NewMockUserUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
 	appLogger := logger.NewAppLogger(cfg)
-	mw := middlewares.NewMiddlewareManager(appLogger, nil)
+	mw := middlewares.NewMiddlewareManager(appLogger, nil, nil, nil)
 
 	e := echo.New()
 	v := validator.New()
-	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, userUC, sessUC)
+	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, tokenService, userUC, sessUC)
 
 	req := httptest.NewRequest(http.MethodGet, "/user/:id", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -194,14 +438,19 @@ func TestUsersService_UpdateById(t *testing.T) {
 	userUC := mock.NewMockUserUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
 	appLogger := logger.NewAppLogger(cfg)
-	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+	appLogger.InitLogger()
+	sessRepo := mockSessUC.NewMockSessRepository(ctrl)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg, tokenService, sessRepo)
 
 	e := echo.New()
 	e.Use(middleware.JWT([]byte("secret")))
 	v := validator.New()
-	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, userUC, sessUC)
+	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, tokenService, userUC, sessUC)
 
 	change := "changed"
 	reqDto := &dto.UserUpdateRequestDto{
@@ -215,13 +464,14 @@ func TestUsersService_UpdateById(t *testing.T) {
 	_ = json.NewEncoder(buf).Encode(reqDto)
 
 	userUUID := uuid.New()
-	token := jwt.New(jwt.SigningMethodHS256)
-	claims := token.Claims.(jwt.MapClaims)
+	claims := jwt.MapClaims{}
 	claims["session_id"] = uuid.New().String()
 	claims["user_id"] = userUUID.String()
 	claims["role"] = "user"
-	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
-	validToken, _ := token.SignedString([]byte("secret"))
+	validToken, _, err := tokenService.GenerateTokenPair(userUUID.String(), claims["session_id"].(string), uuid.New().String(), claims)
+	require.NoError(t, err)
+
+	sessRepo.EXPECT().GetSessionById(gomock.Any(), claims["session_id"].(string)).AnyTimes().Return(&models.Session{UserID: userUUID}, nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/user/:id", buf)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -234,10 +484,7 @@ func TestUsersService_UpdateById(t *testing.T) {
 		ctx := e.NewContext(req, res)
 
 		handler := handlers.UpdateById()
-		h := middleware.JWTWithConfig(middleware.JWTConfig{
-			Claims:     claims,
-			SigningKey: []byte("secret"),
-		})(handler)
+		h := mw.IsLoggedIn()(handler)
 
 		ctx.SetParamNames("id")
 		ctx.SetParamValues("2ceba62a-35f4-444b-a358-4b14834837e1")
@@ -255,16 +502,14 @@ func TestUsersService_UpdateById(t *testing.T) {
 		ctx := e.NewContext(req, res)
 
 		handler := handlers.UpdateById()
-		h := middleware.JWTWithConfig(middleware.JWTConfig{
-			Claims:     claims,
-			SigningKey: []byte("secret"),
-		})(handler)
+		h := mw.IsLoggedIn()(handler)
 
 		ctx.SetParamNames("id")
 		ctx.SetParamValues(userUUID.String())
 
 		userUC.EXPECT().UpdateById(gomock.Any(), gomock.Any()).AnyTimes().Return(&models.User{UserID: userUUID}, nil)
 		userUC.EXPECT().FindById(gomock.Any(), userUUID).AnyTimes().Return(&models.User{UserID: userUUID}, nil)
+		sessUC.EXPECT().DeleteAllByUserId(gomock.Any(), userUUID).Return([]string{claims["session_id"].(string)}, nil)
 
 		require.NoError(t, h(ctx))
 		require.Equal(t, http.StatusOK, res.Code)
@@ -280,13 +525,16 @@ func TestUsersService_DeleteById(t *testing.T) {
 	userUC := mock.NewMockUserUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
 	appLogger := logger.NewAppLogger(cfg)
-	mw := middlewares.NewMiddlewareManager(appLogger, nil)
+	mw := middlewares.NewMiddlewareManager(appLogger, nil, nil, nil)
 
 	e := echo.New()
 	v := validator.New()
-	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, userUC, sessUC)
+	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, tokenService, userUC, sessUC)
 
 	req := httptest.NewRequest(http.MethodDelete, "/user/:id", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -298,6 +546,7 @@ func TestUsersService_DeleteById(t *testing.T) {
 	ctx.SetParamValues(userUUID.String())
 
 	userUC.EXPECT().DeleteById(gomock.Any(), userUUID).AnyTimes().Return(nil)
+	sessUC.EXPECT().DeleteAllByUserId(gomock.Any(), userUUID).Return([]string{uuid.New().String()}, nil)
 	require.NoError(t, handlers.DeleteById()(ctx))
 	require.Equal(t, http.StatusOK, res.Code)
 }
@@ -311,23 +560,29 @@ func TestUsersService_GetMe(t *testing.T) {
 	userUC := mock.NewMockUserUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
 	appLogger := logger.NewAppLogger(cfg)
-	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+	appLogger.InitLogger()
+	sessRepo := mockSessUC.NewMockSessRepository(ctrl)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg, tokenService, sessRepo)
 
 	e := echo.New()
 	e.Use(middleware.JWT([]byte("secret")))
 	v := validator.New()
-	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, userUC, sessUC)
+	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, tokenService, userUC, sessUC)
 
 	userUUID := uuid.New()
-	token := jwt.New(jwt.SigningMethodHS256)
-	claims := token.Claims.(jwt.MapClaims)
+	claims := jwt.MapClaims{}
 	claims["session_id"] = uuid.New().String()
 	claims["user_id"] = userUUID.String()
 	claims["role"] = "user"
-	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
-	validToken, _ := token.SignedString([]byte("secret"))
+	validToken, _, err := tokenService.GenerateTokenPair(userUUID.String(), claims["session_id"].(string), uuid.New().String(), claims)
+	require.NoError(t, err)
+
+	sessRepo.EXPECT().GetSessionById(gomock.Any(), claims["session_id"].(string)).AnyTimes().Return(&models.Session{UserID: userUUID}, nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/user/logout", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -337,12 +592,8 @@ func TestUsersService_GetMe(t *testing.T) {
 	ctx := e.NewContext(req, res)
 
 	handler := handlers.GetMe()
-	h := middleware.JWTWithConfig(middleware.JWTConfig{
-		Claims:     claims,
-		SigningKey: []byte("secret"),
-	})(handler)
+	h := mw.IsLoggedIn()(handler)
 
-	sessUC.EXPECT().GetSessionById(gomock.Any(), claims["session_id"].(string)).AnyTimes().Return(&models.Session{}, nil)
 	userUC.EXPECT().CachedFindById(gomock.Any(), gomock.Any()).AnyTimes().Return(&models.User{}, nil)
 
 	require.NoError(t, h(ctx))
@@ -358,23 +609,29 @@ func TestUsersService_Logout(t *testing.T) {
 	userUC := mock.NewMockUserUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
-	cfg := &config.Config{Session: config.Session{Expire: 1234}}
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
 	appLogger := logger.NewAppLogger(cfg)
-	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+	appLogger.InitLogger()
+	sessRepo := mockSessUC.NewMockSessRepository(ctrl)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg, tokenService, sessRepo)
 
 	e := echo.New()
 	e.Use(middleware.JWT([]byte("secret")))
 	v := validator.New()
-	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, userUC, sessUC)
+	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, tokenService, userUC, sessUC)
 
 	userUUID := uuid.New()
-	token := jwt.New(jwt.SigningMethodHS256)
-	claims := token.Claims.(jwt.MapClaims)
+	claims := jwt.MapClaims{}
 	claims["session_id"] = uuid.New().String()
 	claims["user_id"] = userUUID.String()
 	claims["role"] = "user"
-	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
-	validToken, _ := token.SignedString([]byte("secret"))
+	validToken, _, err := tokenService.GenerateTokenPair(userUUID.String(), claims["session_id"].(string), uuid.New().String(), claims)
+	require.NoError(t, err)
+
+	sessRepo.EXPECT().GetSessionById(gomock.Any(), claims["session_id"].(string)).AnyTimes().Return(&models.Session{UserID: userUUID}, nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/user/logout", nil)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -384,10 +641,7 @@ func TestUsersService_Logout(t *testing.T) {
 	ctx := e.NewContext(req, res)
 
 	handler := handlers.Logout()
-	h := middleware.JWTWithConfig(middleware.JWTConfig{
-		Claims:     claims,
-		SigningKey: []byte("secret"),
-	})(handler)
+	h := mw.IsLoggedIn()(handler)
 
 	sessUC.EXPECT().DeleteById(gomock.Any(), claims["session_id"].(string)).AnyTimes().Return(nil)
 
@@ -395,7 +649,7 @@ func TestUsersService_Logout(t *testing.T) {
 	require.Equal(t, http.StatusOK, res.Code)
 }
 
-func TestUsersService_RefreshToken(t *testing.T) {
+func TestUsersService_Sessions(t *testing.T) {
 	t.Parallel()
 
 	ctrl := gomock.NewController(t)
@@ -404,37 +658,180 @@ func TestUsersService_RefreshToken(t *testing.T) {
 	userUC := mock.NewMockUserUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
-	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
 	appLogger := logger.NewAppLogger(cfg)
-	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+	appLogger.InitLogger()
+	sessRepo := mockSessUC.NewMockSessRepository(ctrl)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg, tokenService, sessRepo)
 
 	e := echo.New()
 	v := validator.New()
-	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, userUC, sessUC)
+	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, tokenService, userUC, sessUC)
 
-	token := jwt.New(jwt.SigningMethodHS256)
-	claims := token.Claims.(jwt.MapClaims)
+	userUUID := uuid.New()
+	claims := jwt.MapClaims{}
 	claims["session_id"] = uuid.New().String()
-	claims["exp"] = time.Now().Add(time.Hour * 24).Unix()
-	validToken, _ := token.SignedString([]byte("secret"))
+	claims["user_id"] = userUUID.String()
+	claims["role"] = "user"
+	validToken, _, err := tokenService.GenerateTokenPair(userUUID.String(), claims["session_id"].(string), uuid.New().String(), claims)
+	require.NoError(t, err)
+
+	sessRepo.EXPECT().GetSessionById(gomock.Any(), claims["session_id"].(string)).AnyTimes().Return(&models.Session{UserID: userUUID}, nil)
+
+	newContext := func(method string, target string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, fmt.Sprintf("bearer %v", validToken))
+		res := httptest.NewRecorder()
+		return e.NewContext(req, res), res
+	}
+
+	t.Run("GetMySessions", func(t *testing.T) {
+		ctx, res := newContext(http.MethodGet, "/user/me/sessions")
+
+		otherSessID := uuid.New().String()
+		sessUC.EXPECT().FindAllByUserId(gomock.Any(), userUUID).Return([]*models.Session{
+			{SessionID: claims["session_id"].(string), UserID: userUUID, IP: "10.0.0.1"},
+			{SessionID: otherSessID, UserID: userUUID, IP: "10.0.0.2"},
+		}, nil)
+
+		require.NoError(t, mw.IsLoggedIn()(handlers.GetMySessions())(ctx))
+		require.Equal(t, http.StatusOK, res.Code)
+
+		resDto := &dto.UserSessionFindResponseDto{}
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), resDto))
+		require.Len(t, resDto.Data, 2)
+		require.True(t, resDto.Data[0].Current)
+		require.Equal(t, otherSessID, resDto.Data[1].SessionID)
+		require.False(t, resDto.Data[1].Current)
+	})
+
+	t.Run("DeleteMySessionById", func(t *testing.T) {
+		ctx, res := newContext(http.MethodDelete, "/user/me/sessions/:id")
+
+		sessID := uuid.New().String()
+		ctx.SetParamNames("id")
+		ctx.SetParamValues(sessID)
+
+		sessUC.EXPECT().GetSessionById(gomock.Any(), sessID).Return(&models.Session{SessionID: sessID, UserID: userUUID}, nil)
+		sessUC.EXPECT().DeleteById(gomock.Any(), sessID).Return(nil)
+
+		require.NoError(t, mw.IsLoggedIn()(handlers.DeleteMySessionById())(ctx))
+		require.Equal(t, http.StatusOK, res.Code)
+	})
+
+	t.Run("DeleteMySessionById of other user", func(t *testing.T) {
+		ctx, res := newContext(http.MethodDelete, "/user/me/sessions/:id")
+
+		sessID := uuid.New().String()
+		ctx.SetParamNames("id")
+		ctx.SetParamValues(sessID)
+
+		sessUC.EXPECT().GetSessionById(gomock.Any(), sessID).Return(&models.Session{SessionID: sessID, UserID: uuid.New()}, nil)
+
+		require.NoError(t, mw.IsLoggedIn()(handlers.DeleteMySessionById())(ctx))
+		require.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("DeleteAllSessionsById", func(t *testing.T) {
+		ctx, res := newContext(http.MethodDelete, "/user/:id/sessions")
+
+		otherUserUUID := uuid.New()
+		ctx.SetParamNames("id")
+		ctx.SetParamValues(otherUserUUID.String())
+
+		sessUC.EXPECT().DeleteAllByUserId(gomock.Any(), otherUserUUID).Return([]string{uuid.New().String()}, nil)
+
+		require.NoError(t, handlers.DeleteAllSessionsById()(ctx))
+		require.Equal(t, http.StatusOK, res.Code)
+	})
+
+	t.Run("UnlockById", func(t *testing.T) {
+		ctx, res := newContext(http.MethodDelete, "/user/:id/lockout?ip=10.0.0.1")
+
+		otherUserUUID := uuid.New()
+		ctx.SetParamNames("id")
+		ctx.SetParamValues(otherUserUUID.String())
+
+		userUC.EXPECT().UnlockById(gomock.Any(), otherUserUUID, "10.0.0.1").Return(nil)
+
+		require.NoError(t, handlers.UnlockById()(ctx))
+		require.Equal(t, http.StatusOK, res.Code)
+	})
+}
+
+func TestUsersService_RefreshToken(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userUC := mock.NewMockUserUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Jwt: config.Jwt{RefreshTokenExpire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg, tokenService, nil)
+
+	e := echo.New()
+	v := validator.New()
+	handlers := NewUserHandlersHTTP(e.Group("user"), appLogger, cfg, mw, v, tokenService, userUC, sessUC)
+
+	userUUID := uuid.New()
+	sessID := uuid.New().String()
+	refreshTokenID := uuid.New().String()
+	accessToken, validToken, err := tokenService.GenerateTokenPair(userUUID.String(), sessID, refreshTokenID, nil)
+	require.NoError(t, err)
 
 	reqDto := &dto.UserRefreshTokenDto{
 		RefreshToken: validToken,
 	}
 
-	buf := &bytes.Buffer{}
-	_ = json.NewEncoder(buf).Encode(reqDto)
+	newContext := func() (echo.Context, *httptest.ResponseRecorder) {
+		buf := &bytes.Buffer{}
+		_ = json.NewEncoder(buf).Encode(reqDto)
 
-	req := httptest.NewRequest(http.MethodPost, "/user/refresh", buf)
-	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req := httptest.NewRequest(http.MethodPost, "/user/refresh", buf)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		res := httptest.NewRecorder()
+		return e.NewContext(req, res), res
+	}
 
-	res := httptest.NewRecorder()
-	ctx := e.NewContext(req, res)
+	sessUC.EXPECT().GetSessionById(gomock.Any(), sessID).AnyTimes().Return(&models.Session{UserID: userUUID}, nil)
+	userUC.EXPECT().FindById(gomock.Any(), userUUID).AnyTimes().Return(&models.User{}, nil)
 
-	sessUC.EXPECT().GetSessionById(gomock.Any(), claims["session_id"].(string)).AnyTimes().Return(&models.Session{}, nil)
-	userUC.EXPECT().FindById(gomock.Any(), gomock.Any()).AnyTimes().Return(&models.User{}, nil)
-	userUC.EXPECT().GenerateTokenPair(gomock.Any(), gomock.Any()).AnyTimes().Return("rt", "at", nil)
+	t.Run("Rotate", func(t *testing.T) {
+		ctx, res := newContext()
 
-	require.NoError(t, handlers.RefreshToken()(ctx))
-	require.Equal(t, http.StatusOK, res.Code)
+		sessUC.EXPECT().RotateRefreshToken(gomock.Any(), sessID, refreshTokenID).Return("next", nil)
+		userUC.EXPECT().GenerateTokenPair(gomock.Any(), sessID, "next").Return("rt", "at", nil)
+
+		require.NoError(t, handlers.RefreshToken()(ctx))
+		require.Equal(t, http.StatusOK, res.Code)
+	})
+
+	t.Run("Reused", func(t *testing.T) {
+		ctx, res := newContext()
+
+		sessUC.EXPECT().RotateRefreshToken(gomock.Any(), sessID, refreshTokenID).Return("", grpc_errors.ErrRefreshTokenReused)
+
+		require.NoError(t, handlers.RefreshToken()(ctx))
+		require.Equal(t, http.StatusUnauthorized, res.Code)
+	})
+
+	t.Run("Access token", func(t *testing.T) {
+		reqDto.RefreshToken = accessToken
+		defer func() { reqDto.RefreshToken = validToken }()
+		ctx, res := newContext()
+
+		require.NoError(t, handlers.RefreshToken()(ctx))
+		require.Equal(t, http.StatusUnauthorized, res.Code)
+	})
 }
@@ -3,14 +3,23 @@ package handlers
 func (h *userHandlersHTTP) UserMapRoutes() {
 	h.group.POST("/refresh", h.RefreshToken())
 	h.group.POST("/login", h.Login())
+	h.group.POST("/signup", h.Signup())
+	h.group.POST("/verify", h.VerifyEmail())
+	h.group.POST("/verify/resend", h.ResendVerification())
+	h.group.POST("/password/forgot", h.ForgotPassword())
+	h.group.POST("/password/reset", h.ResetPassword())
 
 	h.group.Use(h.mw.IsLoggedIn())
 	h.group.POST("/logout", h.Logout())
 	h.group.GET("/:id", h.FindById())
 	h.group.PUT("/:id", h.UpdateById())
 	h.group.GET("/me", h.GetMe())
+	h.group.GET("/me/sessions", h.GetMySessions())
+	h.group.DELETE("/me/sessions/:id", h.DeleteMySessionById())
 
 	h.group.GET("", h.FindAll())
 	h.group.POST("", h.Register(), h.mw.IsAdmin)
 	h.group.DELETE("/:id", h.DeleteById(), h.mw.IsAdmin)
+	h.group.DELETE("/:id/sessions", h.DeleteAllSessionsById(), h.mw.IsAdmin)
+	h.group.DELETE("/:id/lockout", h.UnlockById(), h.mw.IsAdmin)
 }
@@ -5,12 +5,21 @@ import "github.com/labstack/echo/v4"
 // User HTTP Handlers interface
 type UserHandlers interface {
 	Register() echo.HandlerFunc
+	Signup() echo.HandlerFunc
+	VerifyEmail() echo.HandlerFunc
+	ResendVerification() echo.HandlerFunc
 	Login() echo.HandlerFunc
+	ForgotPassword() echo.HandlerFunc
+	ResetPassword() echo.HandlerFunc
 	GetMe() echo.HandlerFunc
 	FindAll() echo.HandlerFunc
 	FindById() echo.HandlerFunc
 	UpdateById() echo.HandlerFunc
 	DeleteById() echo.HandlerFunc
 	Logout() echo.HandlerFunc
+	GetMySessions() echo.HandlerFunc
+	DeleteMySessionById() echo.HandlerFunc
+	DeleteAllSessionsById() echo.HandlerFunc
+	UnlockById() echo.HandlerFunc
 	RefreshToken() echo.HandlerFunc
 }
@@ -37,6 +37,21 @@ func (m *MockUserPGRepository) EXPECT() *MockUserPGRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountAll mocks base method.
+func (m *MockUserPGRepository) CountAll(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockUserPGRepositoryMockRecorder) CountAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockUserPGRepository)(nil).CountAll), ctx)
+}
+
 // Create mocks base method.
 func (m *MockUserPGRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -125,3 +140,18 @@ func (mr *MockUserPGRepositoryMockRecorder) UpdateById(ctx, user interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockUserPGRepository)(nil).UpdateById), ctx, user)
 }
+
+// VerifyEmailById mocks base method.
+func (m *MockUserPGRepository) VerifyEmailById(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmailById", ctx, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmailById indicates an expected call of VerifyEmailById.
+func (mr *MockUserPGRepositoryMockRecorder) VerifyEmailById(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmailById", reflect.TypeOf((*MockUserPGRepository)(nil).VerifyEmailById), ctx, userID)
+}
@@ -10,6 +10,7 @@ import (
 
 	models "github.com/dinorain/pinjembuku/internal/models"
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockUserRedisRepository is a mock of UserRedisRepository interface.
@@ -35,6 +36,20 @@ func (m *MockUserRedisRepository) EXPECT() *MockUserRedisRepositoryMockRecorder
 	return m.recorder
 }
 
+// DeletePasswordResetTokensCtx mocks base method.
+func (m *MockUserRedisRepository) DeletePasswordResetTokensCtx(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePasswordResetTokensCtx", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePasswordResetTokensCtx indicates an expected call of DeletePasswordResetTokensCtx.
+func (mr *MockUserRedisRepositoryMockRecorder) DeletePasswordResetTokensCtx(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePasswordResetTokensCtx", reflect.TypeOf((*MockUserRedisRepository)(nil).DeletePasswordResetTokensCtx), ctx, userID)
+}
+
 // DeleteUserCtx mocks base method.
 func (m *MockUserRedisRepository) DeleteUserCtx(ctx context.Context, key string) error {
 	m.ctrl.T.Helper()
@@ -64,6 +79,20 @@ func (mr *MockUserRedisRepositoryMockRecorder) GetByIdCtx(ctx, key interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIdCtx", reflect.TypeOf((*MockUserRedisRepository)(nil).GetByIdCtx), ctx, key)
 }
 
+// SetPasswordResetTokenCtx mocks base method.
+func (m *MockUserRedisRepository) SetPasswordResetTokenCtx(ctx context.Context, token string, seconds int, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPasswordResetTokenCtx", ctx, token, seconds, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPasswordResetTokenCtx indicates an expected call of SetPasswordResetTokenCtx.
+func (mr *MockUserRedisRepositoryMockRecorder) SetPasswordResetTokenCtx(ctx, token, seconds, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPasswordResetTokenCtx", reflect.TypeOf((*MockUserRedisRepository)(nil).SetPasswordResetTokenCtx), ctx, token, seconds, userID)
+}
+
 // SetUserCtx mocks base method.
 func (m *MockUserRedisRepository) SetUserCtx(ctx context.Context, key string, seconds int, user *models.User) error {
 	m.ctrl.T.Helper()
@@ -77,3 +106,47 @@ func (mr *MockUserRedisRepositoryMockRecorder) SetUserCtx(ctx, key, seconds, use
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserCtx", reflect.TypeOf((*MockUserRedisRepository)(nil).SetUserCtx), ctx, key, seconds, user)
 }
+
+// SetVerificationTokenCtx mocks base method.
+func (m *MockUserRedisRepository) SetVerificationTokenCtx(ctx context.Context, token string, seconds int, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVerificationTokenCtx", ctx, token, seconds, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVerificationTokenCtx indicates an expected call of SetVerificationTokenCtx.
+func (mr *MockUserRedisRepositoryMockRecorder) SetVerificationTokenCtx(ctx, token, seconds, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVerificationTokenCtx", reflect.TypeOf((*MockUserRedisRepository)(nil).SetVerificationTokenCtx), ctx, token, seconds, userID)
+}
+
+// TakePasswordResetTokenCtx mocks base method.
+func (m *MockUserRedisRepository) TakePasswordResetTokenCtx(ctx context.Context, token string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakePasswordResetTokenCtx", ctx, token)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakePasswordResetTokenCtx indicates an expected call of TakePasswordResetTokenCtx.
+func (mr *MockUserRedisRepositoryMockRecorder) TakePasswordResetTokenCtx(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakePasswordResetTokenCtx", reflect.TypeOf((*MockUserRedisRepository)(nil).TakePasswordResetTokenCtx), ctx, token)
+}
+
+// TakeVerificationTokenCtx mocks base method.
+func (m *MockUserRedisRepository) TakeVerificationTokenCtx(ctx context.Context, token string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeVerificationTokenCtx", ctx, token)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeVerificationTokenCtx indicates an expected call of TakeVerificationTokenCtx.
+func (mr *MockUserRedisRepositoryMockRecorder) TakeVerificationTokenCtx(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeVerificationTokenCtx", reflect.TypeOf((*MockUserRedisRepository)(nil).TakeVerificationTokenCtx), ctx, token)
+}
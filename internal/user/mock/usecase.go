@@ -52,6 +52,21 @@ func (mr *MockUserUseCaseMockRecorder) CachedFindById(ctx, userID interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CachedFindById", reflect.TypeOf((*MockUserUseCase)(nil).CachedFindById), ctx, userID)
 }
 
+// CountAll mocks base method.
+func (m *MockUserUseCase) CountAll(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockUserUseCaseMockRecorder) CountAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockUserUseCase)(nil).CountAll), ctx)
+}
+
 // DeleteById mocks base method.
 func (m *MockUserUseCase) DeleteById(ctx context.Context, userID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -111,10 +126,24 @@ func (mr *MockUserUseCaseMockRecorder) FindById(ctx, userID interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUserUseCase)(nil).FindById), ctx, userID)
 }
 
+// ForgotPassword mocks base method.
+func (m *MockUserUseCase) ForgotPassword(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockUserUseCaseMockRecorder) ForgotPassword(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockUserUseCase)(nil).ForgotPassword), ctx, email)
+}
+
 // GenerateTokenPair mocks base method.
-func (m *MockUserUseCase) GenerateTokenPair(user *models.User, sessionID string) (string, string, error) {
+func (m *MockUserUseCase) GenerateTokenPair(user *models.User, sessionID, refreshTokenID string) (string, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateTokenPair", user, sessionID)
+	ret := m.ctrl.Call(m, "GenerateTokenPair", user, sessionID, refreshTokenID)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(error)
@@ -122,24 +151,24 @@ func (m *MockUserUseCase) GenerateTokenPair(user *models.User, sessionID string)
 }
 
 // GenerateTokenPair indicates an expected call of GenerateTokenPair.
-func (mr *MockUserUseCaseMockRecorder) GenerateTokenPair(user, sessionID interface{}) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) GenerateTokenPair(user, sessionID, refreshTokenID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTokenPair", reflect.TypeOf((*MockUserUseCase)(nil).GenerateTokenPair), user, sessionID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTokenPair", reflect.TypeOf((*MockUserUseCase)(nil).GenerateTokenPair), user, sessionID, refreshTokenID)
 }
 
 // Login mocks base method.
-func (m *MockUserUseCase) Login(ctx context.Context, email, password string) (*models.User, error) {
+func (m *MockUserUseCase) Login(ctx context.Context, email, password, ip string) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", ctx, email, password)
+	ret := m.ctrl.Call(m, "Login", ctx, email, password, ip)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockUserUseCaseMockRecorder) Login(ctx, email, password interface{}) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) Login(ctx, email, password, ip interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserUseCase)(nil).Login), ctx, email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserUseCase)(nil).Login), ctx, email, password, ip)
 }
 
 // Register mocks base method.
@@ -157,6 +186,64 @@ func (mr *MockUserUseCaseMockRecorder) Register(ctx, user interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserUseCase)(nil).Register), ctx, user)
 }
 
+// ResendVerification mocks base method.
+func (m *MockUserUseCase) ResendVerification(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockUserUseCaseMockRecorder) ResendVerification(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockUserUseCase)(nil).ResendVerification), ctx, email)
+}
+
+// ResetPassword mocks base method.
+func (m *MockUserUseCase) ResetPassword(ctx context.Context, token, password string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, password)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUserUseCaseMockRecorder) ResetPassword(ctx, token, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUserUseCase)(nil).ResetPassword), ctx, token, password)
+}
+
+// Signup mocks base method.
+func (m *MockUserUseCase) Signup(ctx context.Context, user *models.User) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Signup", ctx, user)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Signup indicates an expected call of Signup.
+func (mr *MockUserUseCaseMockRecorder) Signup(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Signup", reflect.TypeOf((*MockUserUseCase)(nil).Signup), ctx, user)
+}
+
+// UnlockById mocks base method.
+func (m *MockUserUseCase) UnlockById(ctx context.Context, userID uuid.UUID, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockById", ctx, userID, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockById indicates an expected call of UnlockById.
+func (mr *MockUserUseCaseMockRecorder) UnlockById(ctx, userID, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockById", reflect.TypeOf((*MockUserUseCase)(nil).UnlockById), ctx, userID, ip)
+}
+
 // UpdateById mocks base method.
 func (m *MockUserUseCase) UpdateById(ctx context.Context, user *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -171,3 +258,18 @@ func (mr *MockUserUseCaseMockRecorder) UpdateById(ctx, user interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockUserUseCase)(nil).UpdateById), ctx, user)
 }
+
+// VerifyEmail mocks base method.
+func (m *MockUserUseCase) VerifyEmail(ctx context.Context, token string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, token)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockUserUseCaseMockRecorder) VerifyEmail(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUserUseCase)(nil).VerifyEmail), ctx, token)
+}
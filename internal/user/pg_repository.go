@@ -14,8 +14,10 @@ import (
 type UserPGRepository interface {
 	Create(ctx context.Context, user *models.User) (*models.User, error)
 	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.User, error)
+	CountAll(ctx context.Context) (int, error)
 	FindByEmail(ctx context.Context, email string) (*models.User, error)
 	FindById(ctx context.Context, userID uuid.UUID) (*models.User, error)
 	UpdateById(ctx context.Context, user *models.User) (*models.User, error)
+	VerifyEmailById(ctx context.Context, userID uuid.UUID) (*models.User, error)
 	DeleteById(ctx context.Context, userID uuid.UUID) error
 }
@@ -4,6 +4,8 @@ package user
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"github.com/dinorain/pinjembuku/internal/models"
 )
 
@@ -12,4 +14,9 @@ type UserRedisRepository interface {
 	GetByIdCtx(ctx context.Context, key string) (*models.User, error)
 	SetUserCtx(ctx context.Context, key string, seconds int, user *models.User) error
 	DeleteUserCtx(ctx context.Context, key string) error
+	SetVerificationTokenCtx(ctx context.Context, token string, seconds int, userID uuid.UUID) error
+	TakeVerificationTokenCtx(ctx context.Context, token string) (uuid.UUID, error)
+	SetPasswordResetTokenCtx(ctx context.Context, token string, seconds int, userID uuid.UUID) error
+	TakePasswordResetTokenCtx(ctx context.Context, token string) (uuid.UUID, error)
+	DeletePasswordResetTokensCtx(ctx context.Context, userID uuid.UUID) error
 }
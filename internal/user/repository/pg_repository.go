@@ -37,6 +37,7 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) (*models
 		user.Password,
 		user.Role,
 		user.Avatar,
+		user.EmailVerifiedAt,
 	).StructScan(createdUser); err != nil {
 		return nil, errors.Wrap(err, "UserRepository.Create.QueryRowxContext")
 	}
@@ -68,16 +69,43 @@ func (r *UserRepository) UpdateById(ctx context.Context, user *models.User) (*mo
 	return user, nil
 }
 
-// FindAll Find users
+// VerifyEmailById mark the email of user as verified, keeps the first verification time
+func (r *UserRepository) VerifyEmailById(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	verifiedUser := &models.User{}
+	if err := r.db.QueryRowxContext(ctx, verifyEmailByIdQuery, userID).StructScan(verifiedUser); err != nil {
+		return nil, errors.Wrap(err, "UserRepository.VerifyEmailById.QueryRowxContext")
+	}
+
+	return verifiedUser, nil
+}
+
+// FindAll Find users, newest first. Pages by keyset when pagination carries a cursor
 func (r *UserRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.User, error) {
 	var users []models.User
+	if cursor := pagination.GetCursor(); cursor != nil {
+		if err := r.db.SelectContext(ctx, &users, findAllByCursorQuery, cursor.CreatedAt, cursor.ID, pagination.GetLimit()); err != nil {
+			return nil, errors.Wrap(err, "UserRepository.FindAll.SelectContext")
+		}
+		return users, nil
+	}
+
 	if err := r.db.SelectContext(ctx, &users, findAllQuery, pagination.GetLimit(), pagination.GetOffset()); err != nil {
-		return nil, errors.Wrap(err, "UserRepository.FindById.SelectContext")
+		return nil, errors.Wrap(err, "UserRepository.FindAll.SelectContext")
 	}
 
 	return users, nil
 }
 
+// CountAll Count users
+func (r *UserRepository) CountAll(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.GetContext(ctx, &count, countAllQuery); err != nil {
+		return 0, errors.Wrap(err, "UserRepository.CountAll.GetContext")
+	}
+
+	return count, nil
+}
+
 // FindByEmail Find by user email address
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
 	user := &models.User{}
@@ -57,6 +57,7 @@ func TestUserRepository_Create(t *testing.T) {
 		mockUser.Password,
 		mockUser.Role,
 		mockUser.Avatar,
+		mockUser.EmailVerifiedAt,
 	).WillReturnRows(rows)
 
 	createdUser, err := userPGRepository.Create(context.Background(), mockUser)
@@ -155,6 +156,14 @@ func TestUserRepository_FindAll(t *testing.T) {
 	foundUsers, err = userPGRepository.FindAll(context.Background(), utils.NewPaginationQuery(size, 2))
 	require.NoError(t, err)
 	require.Nil(t, foundUsers)
+
+	cursor := utils.NewCursor(time.Now(), uuid.New())
+	pagination := utils.NewPaginationQuery(size, 2)
+	require.NoError(t, pagination.SetCursor(cursor.Encode()))
+	mock.ExpectQuery(findAllByCursorQuery).WithArgs(sqlmock.AnyArg(), cursor.ID, size).WillReturnRows(sqlmock.NewRows(columns))
+	foundUsers, err = userPGRepository.FindAll(context.Background(), pagination)
+	require.NoError(t, err)
+	require.Nil(t, foundUsers)
 }
 
 func TestUserRepository_FindById(t *testing.T) {
@@ -201,6 +210,43 @@ func TestUserRepository_FindById(t *testing.T) {
 	require.Equal(t, foundUser.UserID, mockUser.UserID)
 }
 
+func TestUserRepository_VerifyEmailById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	userPGRepository := NewUserPGRepository(sqlxDB)
+
+	columns := []string{"user_id", "first_name", "last_name", "email", "password", "avatar", "email_verified_at", "role", "created_at", "updated_at"}
+	userUUID := uuid.New()
+	verifiedAt := time.Now()
+
+	rows := sqlmock.NewRows(columns).AddRow(
+		userUUID,
+		"FirstName",
+		"LastName",
+		"email@gmail.com",
+		"123456",
+		nil,
+		verifiedAt,
+		"user",
+		time.Now(),
+		time.Now(),
+	)
+
+	mock.ExpectQuery(verifyEmailByIdQuery).WithArgs(userUUID).WillReturnRows(rows)
+
+	verifiedUser, err := userPGRepository.VerifyEmailById(context.Background(), userUUID)
+	require.NoError(t, err)
+	require.Equal(t, userUUID, verifiedUser.UserID)
+	require.True(t, verifiedUser.IsEmailVerified())
+}
+
 func TestUserRepository_UpdateById(t *testing.T) {
 	t.Parallel()
 
@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
 
 	"github.com/dinorain/pinjembuku/internal/models"
 	"github.com/dinorain/pinjembuku/internal/user"
@@ -15,16 +18,19 @@ import (
 
 // Auth redis repository
 type userRedisRepo struct {
-	redisClient *redis.Client
-	basePrefix  string
-	logger      logger.Logger
+	redisClient         *redis.Client
+	basePrefix          string
+	verificationPrefix  string
+	passwordResetPrefix string
+	passwordResetIndex  string
+	logger              logger.Logger
 }
 
 var _ user.UserRedisRepository = (*userRedisRepo)(nil)
 
 // Auth redis repository constructor
 func NewUserRedisRepo(redisClient *redis.Client, logger logger.Logger) *userRedisRepo {
-	return &userRedisRepo{redisClient: redisClient, basePrefix: "user:", logger: logger}
+	return &userRedisRepo{redisClient: redisClient, basePrefix: "user:", verificationPrefix: "user-verification:", passwordResetPrefix: "user-password-reset:", passwordResetIndex: "user-password-resets-by-user:", logger: logger}
 }
 
 // Get user by id
@@ -56,6 +62,76 @@ func (r *userRedisRepo) DeleteUserCtx(ctx context.Context, key string) error {
 	return r.redisClient.Del(ctx, r.createKey(key)).Err()
 }
 
+// Store email verification token of user with duration in seconds, only the token hash is kept
+func (r *userRedisRepo) SetVerificationTokenCtx(ctx context.Context, token string, seconds int, userID uuid.UUID) error {
+	return r.setTokenCtx(ctx, r.createTokenKey(r.verificationPrefix, token), "", seconds, userID)
+}
+
+// Take user of email verification token, the token is deleted so it can be used once
+func (r *userRedisRepo) TakeVerificationTokenCtx(ctx context.Context, token string) (uuid.UUID, error) {
+	return r.takeTokenCtx(ctx, r.createTokenKey(r.verificationPrefix, token))
+}
+
+// Store password reset token of user with duration in seconds, only the token hash is kept
+func (r *userRedisRepo) SetPasswordResetTokenCtx(ctx context.Context, token string, seconds int, userID uuid.UUID) error {
+	return r.setTokenCtx(ctx, r.createTokenKey(r.passwordResetPrefix, token), r.createIndexKey(r.passwordResetIndex, userID), seconds, userID)
+}
+
+// Take user of password reset token, the token is deleted so it can be used once
+func (r *userRedisRepo) TakePasswordResetTokenCtx(ctx context.Context, token string) (uuid.UUID, error) {
+	return r.takeTokenCtx(ctx, r.createTokenKey(r.passwordResetPrefix, token))
+}
+
+// Delete all password reset tokens of user
+func (r *userRedisRepo) DeletePasswordResetTokensCtx(ctx context.Context, userID uuid.UUID) error {
+	return r.deleteTokensCtx(ctx, r.createIndexKey(r.passwordResetIndex, userID))
+}
+
+func (r *userRedisRepo) setTokenCtx(ctx context.Context, key string, indexKey string, seconds int, userID uuid.UUID) error {
+	// the index lives as long as the newest token, taken tokens are dropped with the index
+	ttl := time.Second * time.Duration(seconds)
+	_, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, key, userID.String(), ttl)
+		if indexKey != "" {
+			pipe.SAdd(ctx, indexKey, key)
+			pipe.Expire(ctx, indexKey, ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+func (r *userRedisRepo) takeTokenCtx(ctx context.Context, key string) (uuid.UUID, error) {
+	var userIDCmd *redis.StringCmd
+	if _, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		userIDCmd = pipe.Get(ctx, key)
+		pipe.Del(ctx, key)
+		return nil
+	}); err != nil {
+		return uuid.Nil, err
+	}
+
+	return uuid.Parse(userIDCmd.Val())
+}
+
+func (r *userRedisRepo) deleteTokensCtx(ctx context.Context, indexKey string) error {
+	keys, err := r.redisClient.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return err
+	}
+
+	return r.redisClient.Del(ctx, append(keys, indexKey)...).Err()
+}
+
+func (r *userRedisRepo) createTokenKey(prefix string, token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return fmt.Sprintf("%s: %s", prefix, hex.EncodeToString(hash[:]))
+}
+
+func (r *userRedisRepo) createIndexKey(prefix string, userID uuid.UUID) string {
+	return fmt.Sprintf("%s: %s", prefix, userID.String())
+}
+
 func (r *userRedisRepo) createKey(value string) string {
 	return fmt.Sprintf("%s: %s", r.basePrefix, value)
 }
@@ -74,3 +74,76 @@ func TestUserRedisRepo_DeleteUserCtx(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestUserRedisRepo_TakeVerificationTokenCtx(t *testing.T) {
+	t.Parallel()
+
+	redisRepo := SetupRedis()
+
+	t.Run("TakeVerificationTokenCtx", func(t *testing.T) {
+		userID := uuid.New()
+
+		err := redisRepo.SetVerificationTokenCtx(context.Background(), "token", 10, userID)
+		require.NoError(t, err)
+
+		exists, err := redisRepo.redisClient.Exists(context.Background(), redisRepo.verificationPrefix+": token").Result()
+		require.NoError(t, err)
+		require.Zero(t, exists, "token is stored hashed")
+
+		takenID, err := redisRepo.TakeVerificationTokenCtx(context.Background(), "token")
+		require.NoError(t, err)
+		require.Equal(t, userID, takenID)
+
+		_, err = redisRepo.TakeVerificationTokenCtx(context.Background(), "token")
+		require.ErrorIs(t, err, redis.Nil)
+	})
+}
+
+func TestUserRedisRepo_TakePasswordResetTokenCtx(t *testing.T) {
+	t.Parallel()
+
+	redisRepo := SetupRedis()
+
+	t.Run("TakePasswordResetTokenCtx", func(t *testing.T) {
+		userID := uuid.New()
+
+		err := redisRepo.SetPasswordResetTokenCtx(context.Background(), "token", 10, userID)
+		require.NoError(t, err)
+
+		_, err = redisRepo.TakeVerificationTokenCtx(context.Background(), "token")
+		require.ErrorIs(t, err, redis.Nil, "reset tokens do not verify emails")
+
+		takenID, err := redisRepo.TakePasswordResetTokenCtx(context.Background(), "token")
+		require.NoError(t, err)
+		require.Equal(t, userID, takenID)
+
+		_, err = redisRepo.TakePasswordResetTokenCtx(context.Background(), "token")
+		require.ErrorIs(t, err, redis.Nil)
+	})
+}
+
+func TestUserRedisRepo_DeletePasswordResetTokensCtx(t *testing.T) {
+	t.Parallel()
+
+	redisRepo := SetupRedis()
+
+	t.Run("DeletePasswordResetTokensCtx", func(t *testing.T) {
+		userID, otherUserID := uuid.New(), uuid.New()
+
+		require.NoError(t, redisRepo.SetPasswordResetTokenCtx(context.Background(), "first", 10, userID))
+		require.NoError(t, redisRepo.SetPasswordResetTokenCtx(context.Background(), "second", 10, userID))
+		require.NoError(t, redisRepo.SetPasswordResetTokenCtx(context.Background(), "other", 10, otherUserID))
+
+		err := redisRepo.DeletePasswordResetTokensCtx(context.Background(), userID)
+		require.NoError(t, err)
+
+		_, err = redisRepo.TakePasswordResetTokenCtx(context.Background(), "first")
+		require.ErrorIs(t, err, redis.Nil)
+		_, err = redisRepo.TakePasswordResetTokenCtx(context.Background(), "second")
+		require.ErrorIs(t, err, redis.Nil)
+
+		takenID, err := redisRepo.TakePasswordResetTokenCtx(context.Background(), "other")
+		require.NoError(t, err)
+		require.Equal(t, otherUserID, takenID)
+	})
+}
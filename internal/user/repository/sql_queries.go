@@ -1,18 +1,25 @@
 package repository
 
 const (
-	createUserQuery = `INSERT INTO users (first_name, last_name, email, password, role, avatar) 
-		VALUES ($1, $2, $3, $4, $5, COALESCE(NULLIF($6, ''), null)) 
-		RETURNING user_id, first_name, last_name, email, password, avatar, created_at, updated_at, role`
+	createUserQuery = `INSERT INTO users (first_name, last_name, email, password, role, avatar, email_verified_at) 
+		VALUES ($1, $2, $3, $4, $5, COALESCE(NULLIF($6, ''), null), $7) 
+		RETURNING user_id, first_name, last_name, email, password, avatar, email_verified_at, created_at, updated_at, role`
 
-	findByEmailQuery = `SELECT user_id, email, first_name, last_name, role, avatar, password, created_at, updated_at FROM users WHERE email = $1`
+	findByEmailQuery = `SELECT user_id, email, first_name, last_name, role, avatar, password, email_verified_at, created_at, updated_at FROM users WHERE email = $1`
 
-	findByIdQuery = `SELECT user_id, email, first_name, last_name, role, avatar, password, created_at, updated_at FROM users WHERE user_id = $1`
+	findByIdQuery = `SELECT user_id, email, first_name, last_name, role, avatar, password, email_verified_at, created_at, updated_at FROM users WHERE user_id = $1`
 
-	findAllQuery = `SELECT user_id, email, first_name, last_name, role, avatar, password, created_at, updated_at FROM users LIMIT $1 OFFSET $2`
+	findAllQuery = `SELECT user_id, email, first_name, last_name, role, avatar, password, email_verified_at, created_at, updated_at FROM users ORDER BY created_at DESC, user_id DESC LIMIT $1 OFFSET $2`
+
+	findAllByCursorQuery = `SELECT user_id, email, first_name, last_name, role, avatar, password, email_verified_at, created_at, updated_at FROM users WHERE (created_at, user_id) < ($1, $2) ORDER BY created_at DESC, user_id DESC LIMIT $3`
 
 	updateByIdQuery = `UPDATE users SET first_name = $2, last_name = $3, email = $4, password = $5, role = $6, avatar = $7 WHERE user_id = $1
-		RETURNING user_id, first_name, last_name, email, password, avatar, created_at, updated_at, role`
+		RETURNING user_id, first_name, last_name, email, password, avatar, email_verified_at, created_at, updated_at, role`
+
+	verifyEmailByIdQuery = `UPDATE users SET email_verified_at = COALESCE(email_verified_at, now()) WHERE user_id = $1
+		RETURNING user_id, first_name, last_name, email, password, avatar, email_verified_at, created_at, updated_at, role`
 
 	deleteByIdQuery = `DELETE FROM users WHERE user_id = $1`
+
+	countAllQuery = `SELECT COUNT(*) FROM users`
 )
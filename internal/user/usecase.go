@@ -13,12 +13,19 @@ import (
 //  User UseCase interface
 type UserUseCase interface {
 	Register(ctx context.Context, user *models.User) (*models.User, error)
-	Login(ctx context.Context, email string, password string) (*models.User, error)
+	Signup(ctx context.Context, user *models.User) (*models.User, error)
+	ResendVerification(ctx context.Context, email string) error
+	VerifyEmail(ctx context.Context, token string) (*models.User, error)
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, password string) (*models.User, error)
+	Login(ctx context.Context, email string, password string, ip string) (*models.User, error)
+	UnlockById(ctx context.Context, userID uuid.UUID, ip string) error
 	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.User, error)
+	CountAll(ctx context.Context) (int, error)
 	FindByEmail(ctx context.Context, email string) (*models.User, error)
 	FindById(ctx context.Context, userID uuid.UUID) (*models.User, error)
 	CachedFindById(ctx context.Context, userID uuid.UUID) (*models.User, error)
 	UpdateById(ctx context.Context, user *models.User) (*models.User, error)
 	DeleteById(ctx context.Context, userID uuid.UUID) error
-	GenerateTokenPair(user *models.User, sessionID string) (access string, refresh string, err error)
+	GenerateTokenPair(user *models.User, sessionID string, refreshTokenID string) (access string, refresh string, err error)
 }
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -10,21 +12,30 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/lockout"
 	"github.com/dinorain/pinjembuku/internal/models"
 	"github.com/dinorain/pinjembuku/internal/user"
 	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
 	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/mailer"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
 	"github.com/dinorain/pinjembuku/pkg/utils"
 )
 
 const (
 	userByIdCacheDuration = 3600
+
+	defaultVerificationExpire  = 24 * 60 * 60
+	defaultPasswordResetExpire = 60 * 60
 )
 
 // User UseCase
 type userUseCase struct {
 	cfg        *config.Config
 	logger     logger.Logger
+	tokens     *tokens.Service
+	mailer     mailer.Mailer
+	lockoutUC  lockout.LockoutUseCase
 	userPgRepo user.UserPGRepository
 	redisRepo  user.UserRedisRepository
 }
@@ -32,20 +43,143 @@ type userUseCase struct {
 var _ user.UserUseCase = (*userUseCase)(nil)
 
 // New User UseCase
-func NewUserUseCase(cfg *config.Config, logger logger.Logger, userRepo user.UserPGRepository, redisRepo user.UserRedisRepository) *userUseCase {
-	return &userUseCase{cfg: cfg, logger: logger, userPgRepo: userRepo, redisRepo: redisRepo}
+func NewUserUseCase(cfg *config.Config, logger logger.Logger, tokens *tokens.Service, mailer mailer.Mailer, lockoutUC lockout.LockoutUseCase, userRepo user.UserPGRepository, redisRepo user.UserRedisRepository) *userUseCase {
+	return &userUseCase{cfg: cfg, logger: logger, tokens: tokens, mailer: mailer, lockoutUC: lockoutUC, userPgRepo: userRepo, redisRepo: redisRepo}
 }
 
-// Register new user
+// Register new user, users created by an admin need no email verification
 func (u *userUseCase) Register(ctx context.Context, user *models.User) (*models.User, error) {
 	existsUser, err := u.userPgRepo.FindByEmail(ctx, user.Email)
 	if existsUser != nil || err == nil {
 		return nil, grpc_errors.ErrEmailExists
 	}
 
+	if user.EmailVerifiedAt == nil {
+		verifiedAt := time.Now()
+		user.EmailVerifiedAt = &verifiedAt
+	}
+
 	return u.userPgRepo.Create(ctx, user)
 }
 
+// Signup create unverified user and email the verification link
+func (u *userUseCase) Signup(ctx context.Context, user *models.User) (*models.User, error) {
+	existsUser, err := u.userPgRepo.FindByEmail(ctx, user.Email)
+	if existsUser != nil || err == nil {
+		return nil, grpc_errors.ErrEmailExists
+	}
+
+	user.Role = models.UserRoleUser
+	user.EmailVerifiedAt = nil
+
+	createdUser, err := u.userPgRepo.Create(ctx, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "userPgRepo.Create")
+	}
+
+	if err := u.sendVerification(ctx, createdUser); err != nil {
+		// without the link the account can never be verified, drop it so the email can sign up again
+		if err := u.userPgRepo.DeleteById(ctx, createdUser.UserID); err != nil {
+			u.logger.Errorf("userPgRepo.DeleteById: %v", err)
+		}
+		return nil, errors.Wrap(err, "sendVerification")
+	}
+
+	createdUser.SanitizePassword()
+
+	return createdUser, nil
+}
+
+// ResendVerification email a new verification link, unknown or verified emails and failed sends are not reported
+func (u *userUseCase) ResendVerification(ctx context.Context, email string) error {
+	foundUser, err := u.userPgRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return errors.Wrap(err, "userPgRepo.FindByEmail")
+	}
+
+	if foundUser.IsEmailVerified() {
+		return nil
+	}
+
+	if err := u.sendVerification(ctx, foundUser); err != nil {
+		u.logger.Errorf("sendVerification: %v", err)
+	}
+
+	return nil
+}
+
+// VerifyEmail verify email of the user the token was sent to, tokens are single use
+func (u *userUseCase) VerifyEmail(ctx context.Context, token string) (*models.User, error) {
+	userID, err := u.redisRepo.TakeVerificationTokenCtx(ctx, token)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errors.Wrap(grpc_errors.ErrInvalidToken, "verification token")
+		}
+		return nil, errors.Wrap(err, "redisRepo.TakeVerificationTokenCtx")
+	}
+
+	verifiedUser, err := u.userPgRepo.VerifyEmailById(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "userPgRepo.VerifyEmailById")
+	}
+
+	if err := u.redisRepo.SetUserCtx(ctx, verifiedUser.UserID.String(), userByIdCacheDuration, verifiedUser); err != nil {
+		u.logger.Errorf("redisRepo.SetUserCtx", err)
+	}
+
+	verifiedUser.SanitizePassword()
+
+	return verifiedUser, nil
+}
+
+// ForgotPassword email a password reset link, unknown emails and failed sends are not reported so callers cannot probe accounts
+func (u *userUseCase) ForgotPassword(ctx context.Context, email string) error {
+	foundUser, err := u.userPgRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return errors.Wrap(err, "userPgRepo.FindByEmail")
+	}
+
+	if err := u.sendPasswordReset(ctx, foundUser); err != nil {
+		u.logger.Errorf("sendPasswordReset: %v", err)
+	}
+
+	return nil
+}
+
+// ResetPassword set password of the user the reset token was sent to, all reset tokens of the user are spent
+func (u *userUseCase) ResetPassword(ctx context.Context, token string, password string) (*models.User, error) {
+	userID, err := u.redisRepo.TakePasswordResetTokenCtx(ctx, token)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errors.Wrap(grpc_errors.ErrInvalidToken, "password reset token")
+		}
+		return nil, errors.Wrap(err, "redisRepo.TakePasswordResetTokenCtx")
+	}
+
+	// other reset links sent to the user stop working as well
+	if err := u.redisRepo.DeletePasswordResetTokensCtx(ctx, userID); err != nil {
+		return nil, errors.Wrap(err, "redisRepo.DeletePasswordResetTokensCtx")
+	}
+
+	foundUser, err := u.userPgRepo.FindById(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "userPgRepo.FindById")
+	}
+
+	foundUser.Password = password
+	if err := foundUser.HashPassword(); err != nil {
+		return nil, errors.Wrap(err, "user.HashPassword")
+	}
+
+	return u.UpdateById(ctx, foundUser)
+}
+
 // FindAll find users
 func (u *userUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.User, error) {
 	users, err := u.userPgRepo.FindAll(ctx, pagination)
@@ -56,6 +190,16 @@ func (u *userUseCase) FindAll(ctx context.Context, pagination *utils.Pagination)
 	return users, nil
 }
 
+// CountAll count users
+func (u *userUseCase) CountAll(ctx context.Context) (int, error) {
+	count, err := u.userPgRepo.CountAll(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "userPgRepo.CountAll")
+	}
+
+	return count, nil
+}
+
 // FindByEmail find user by email address
 func (u *userUseCase) FindByEmail(ctx context.Context, email string) (*models.User, error) {
 	findByEmail, err := u.userPgRepo.FindByEmail(ctx, email)
@@ -130,44 +274,120 @@ func (u *userUseCase) DeleteById(ctx context.Context, userID uuid.UUID) error {
 	return nil
 }
 
-// Login user with email and password
-func (u *userUseCase) Login(ctx context.Context, email string, password string) (*models.User, error) {
+// Login user with email and password, failed attempts back off and lock the account for a while
+func (u *userUseCase) Login(ctx context.Context, email string, password string, ip string) (*models.User, error) {
+	if err := u.lockoutUC.Reserve(ctx, lockout.RealmUser, email, ip); err != nil {
+		return nil, errors.Wrap(err, "lockoutUC.Reserve")
+	}
+
 	foundUser, err := u.userPgRepo.FindByEmail(ctx, email)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			u.registerLoginFailure(ctx, ip)
+		}
 		return nil, errors.Wrap(err, "userPgRepo.FindByEmail")
 	}
 
 	if err := foundUser.ComparePasswords(password); err != nil {
+		u.registerLoginFailure(ctx, ip)
 		return nil, errors.Wrap(err, "user.ComparePasswords")
 	}
 
-	return foundUser, err
+	if err := u.lockoutUC.RegisterSuccess(ctx, lockout.RealmUser, email); err != nil {
+		u.logger.Errorf("lockoutUC.RegisterSuccess: %v", err)
+	}
+
+	return foundUser, nil
 }
 
-func (u *userUseCase) GenerateTokenPair(user *models.User, sessionID string) (access string, refresh string, err error) {
-	token := jwt.New(jwt.SigningMethodHS256)
+// UnlockById reset failed logins of user and, when set, of ip
+func (u *userUseCase) UnlockById(ctx context.Context, userID uuid.UUID, ip string) error {
+	foundUser, err := u.userPgRepo.FindById(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "userPgRepo.FindById")
+	}
+
+	if err := u.lockoutUC.Unlock(ctx, lockout.RealmUser, foundUser.Email, ip); err != nil {
+		return errors.Wrap(err, "lockoutUC.Unlock")
+	}
 
-	claims := token.Claims.(jwt.MapClaims)
-	claims["session_id"] = sessionID
-	claims["user_id"] = user.UserID
-	claims["email"] = user.Email
-	claims["role"] = user.Role
-	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	return nil
+}
 
-	access, err = token.SignedString([]byte(u.cfg.Server.JwtSecretKey))
+func (u *userUseCase) GenerateTokenPair(user *models.User, sessionID string, refreshTokenID string) (access string, refresh string, err error) {
+	return u.tokens.GenerateTokenPair(user.UserID.String(), sessionID, refreshTokenID, jwt.MapClaims{
+		"user_id": user.UserID,
+		"email":   user.Email,
+		"role":    user.Role,
+	})
+}
+
+func (u *userUseCase) sendVerification(ctx context.Context, user *models.User) error {
+	token, err := utils.NewOneTimeToken()
+	if err != nil {
+		return err
+	}
+
+	expire := u.cfg.Signup.VerificationExpire
+	if expire <= 0 {
+		expire = defaultVerificationExpire
+	}
+
+	if err := u.redisRepo.SetVerificationTokenCtx(ctx, token, expire, user.UserID); err != nil {
+		return errors.Wrap(err, "redisRepo.SetVerificationTokenCtx")
+	}
+
+	link, err := utils.TokenLink(u.cfg.Signup.VerifyURL, token)
+	if err != nil {
+		return err
+	}
+
+	if err := u.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nconfirm your email address to start borrowing books:\n\n%s\n\nThe link expires in %d hours.\n",
+			user.FirstName, link, expire/3600),
+	}); err != nil {
+		return errors.Wrap(err, "mailer.Send")
+	}
+
+	return nil
+}
+
+func (u *userUseCase) sendPasswordReset(ctx context.Context, user *models.User) error {
+	token, err := utils.NewOneTimeToken()
 	if err != nil {
-		return "", "", err
+		return err
 	}
 
-	refreshToken := jwt.New(jwt.SigningMethodHS256)
-	rtClaims := refreshToken.Claims.(jwt.MapClaims)
-	rtClaims["session_id"] = sessionID
-	rtClaims["exp"] = time.Now().Add(time.Hour * 24).Unix()
+	expire := u.cfg.PasswordReset.Expire
+	if expire <= 0 {
+		expire = defaultPasswordResetExpire
+	}
+
+	if err := u.redisRepo.SetPasswordResetTokenCtx(ctx, token, expire, user.UserID); err != nil {
+		return errors.Wrap(err, "redisRepo.SetPasswordResetTokenCtx")
+	}
 
-	refresh, err = refreshToken.SignedString([]byte(u.cfg.Server.JwtSecretKey))
+	link, err := utils.TokenLink(u.cfg.PasswordReset.UserResetURL, token)
 	if err != nil {
-		return "", "", err
+		return err
 	}
 
-	return access, refresh, nil
+	if err := u.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nchoose a new password here:\n\n%s\n\nThe link expires in %d minutes. If you did not ask for it, ignore this email.\n",
+			user.FirstName, link, expire/60),
+	}); err != nil {
+		return errors.Wrap(err, "mailer.Send")
+	}
+
+	return nil
+}
+
+func (u *userUseCase) registerLoginFailure(ctx context.Context, ip string) {
+	if err := u.lockoutUC.RegisterFailure(ctx, lockout.RealmUser, ip); err != nil {
+		u.logger.Errorf("lockoutUC.RegisterFailure: %v", err)
+	}
 }
@@ -4,16 +4,25 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/internal/lockout"
+	lockoutMock "github.com/dinorain/pinjembuku/internal/lockout/mock"
 	"github.com/dinorain/pinjembuku/internal/models"
 	"github.com/dinorain/pinjembuku/internal/user/mock"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/jwtkeys"
 	"github.com/dinorain/pinjembuku/pkg/logger"
+	"github.com/dinorain/pinjembuku/pkg/mailer"
+	"github.com/dinorain/pinjembuku/pkg/tokens"
 )
 
 func TestUserUseCase_Register(t *testing.T) {
@@ -27,7 +36,10 @@ func TestUserUseCase_Register(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{Server: config.ServerConfig{JwtSecretKey: "secret123"}}
-	userUC := NewUserUseCase(cfg, apiLogger, userPGRepository, userRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	userUC := NewUserUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), userPGRepository, userRedisRepository)
 
 	userID := uuid.New()
 	mockUser := &models.User{
@@ -57,6 +69,264 @@ func TestUserUseCase_Register(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, createdUser)
 	require.Equal(t, createdUser.UserID, userID)
+	require.True(t, mockUser.IsEmailVerified())
+}
+
+func TestUserUseCase_Signup(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userPGRepository := mock.NewMockUserPGRepository(ctrl)
+	userRedisRepository := mock.NewMockUserRedisRepository(ctrl)
+	outbox := mailer.NewOutbox("")
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{JwtSecretKey: "secret123"},
+		Signup: config.Signup{VerifyURL: "http://localhost/verify?lang=en", VerificationExpire: 7200},
+	}
+	apiLogger := logger.NewAppLogger(cfg)
+	apiLogger.InitLogger()
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	userUC := NewUserUseCase(cfg, apiLogger, tokenService, outbox, lockoutMock.NewMockLockoutUseCase(ctrl), userPGRepository, userRedisRepository)
+
+	userID := uuid.New()
+	mockUser := &models.User{
+		Email:     "email@gmail.com",
+		FirstName: "FirstName",
+		LastName:  "LastName",
+		Role:      "admin",
+		Password:  "123456",
+	}
+
+	ctx := context.Background()
+
+	var token string
+	userPGRepository.EXPECT().FindByEmail(gomock.Any(), mockUser.Email).Return(nil, sql.ErrNoRows)
+	userPGRepository.EXPECT().Create(gomock.Any(), mockUser).DoAndReturn(func(ctx context.Context, user *models.User) (*models.User, error) {
+		require.Equal(t, models.UserRoleUser, user.Role)
+		require.False(t, user.IsEmailVerified())
+		createdUser := *user
+		createdUser.UserID = userID
+		return &createdUser, nil
+	})
+	userRedisRepository.EXPECT().SetVerificationTokenCtx(gomock.Any(), gomock.Any(), 7200, userID).DoAndReturn(func(ctx context.Context, verificationToken string, seconds int, userID uuid.UUID) error {
+		token = verificationToken
+		return nil
+	})
+
+	createdUser, err := userUC.Signup(ctx, mockUser)
+	require.NoError(t, err)
+	require.Equal(t, userID, createdUser.UserID)
+	require.Empty(t, createdUser.Password)
+
+	messages := outbox.Messages()
+	require.Len(t, messages, 1)
+	require.Equal(t, mockUser.Email, messages[0].To)
+	require.NotEmpty(t, token)
+	require.Contains(t, messages[0].Body, "http://localhost/verify?lang=en&token="+token)
+
+	userPGRepository.EXPECT().FindByEmail(gomock.Any(), mockUser.Email).Return(createdUser, nil)
+	_, err = userUC.Signup(ctx, mockUser)
+	require.ErrorIs(t, err, grpc_errors.ErrEmailExists)
+
+	userPGRepository.EXPECT().FindByEmail(gomock.Any(), mockUser.Email).Return(nil, sql.ErrNoRows)
+	userPGRepository.EXPECT().Create(gomock.Any(), mockUser).Return(createdUser, nil)
+	userRedisRepository.EXPECT().SetVerificationTokenCtx(gomock.Any(), gomock.Any(), 7200, userID).Return(errors.New("redis down"))
+	userPGRepository.EXPECT().DeleteById(gomock.Any(), userID).Return(nil)
+
+	_, err = userUC.Signup(ctx, mockUser)
+	require.Error(t, err, "users who cannot get the link are removed again")
+	require.Len(t, outbox.Messages(), 1)
+}
+
+func TestUserUseCase_ResendVerification(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userPGRepository := mock.NewMockUserPGRepository(ctrl)
+	userRedisRepository := mock.NewMockUserRedisRepository(ctrl)
+	outbox := mailer.NewOutbox("")
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{JwtSecretKey: "secret123"},
+		Signup: config.Signup{VerifyURL: "http://localhost/verify"},
+	}
+	apiLogger := logger.NewAppLogger(cfg)
+	apiLogger.InitLogger()
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	userUC := NewUserUseCase(cfg, apiLogger, tokenService, outbox, lockoutMock.NewMockLockoutUseCase(ctrl), userPGRepository, userRedisRepository)
+
+	ctx := context.Background()
+	mockUser := &models.User{UserID: uuid.New(), Email: "email@gmail.com", FirstName: "FirstName"}
+
+	t.Run("Unverified", func(t *testing.T) {
+		var token string
+		userPGRepository.EXPECT().FindByEmail(gomock.Any(), mockUser.Email).Return(mockUser, nil)
+		userRedisRepository.EXPECT().SetVerificationTokenCtx(gomock.Any(), gomock.Any(), defaultVerificationExpire, mockUser.UserID).DoAndReturn(func(ctx context.Context, verificationToken string, seconds int, userID uuid.UUID) error {
+			token = verificationToken
+			return nil
+		})
+
+		require.NoError(t, userUC.ResendVerification(ctx, mockUser.Email))
+		messages := outbox.Messages()
+		require.Len(t, messages, 1)
+		require.Contains(t, messages[0].Body, "http://localhost/verify?token="+token)
+	})
+
+	t.Run("Verified", func(t *testing.T) {
+		verifiedAt := time.Now()
+		userPGRepository.EXPECT().FindByEmail(gomock.Any(), "verified@gmail.com").Return(&models.User{UserID: uuid.New(), EmailVerifiedAt: &verifiedAt}, nil)
+
+		require.NoError(t, userUC.ResendVerification(ctx, "verified@gmail.com"))
+		require.Len(t, outbox.Messages(), 1)
+	})
+
+	t.Run("Unknown email", func(t *testing.T) {
+		userPGRepository.EXPECT().FindByEmail(gomock.Any(), "unknown@gmail.com").Return(nil, sql.ErrNoRows)
+
+		require.NoError(t, userUC.ResendVerification(ctx, "unknown@gmail.com"))
+		require.Len(t, outbox.Messages(), 1)
+	})
+
+	t.Run("Failed send", func(t *testing.T) {
+		userPGRepository.EXPECT().FindByEmail(gomock.Any(), mockUser.Email).Return(mockUser, nil)
+		userRedisRepository.EXPECT().SetVerificationTokenCtx(gomock.Any(), gomock.Any(), defaultVerificationExpire, mockUser.UserID).Return(errors.New("redis down"))
+
+		require.NoError(t, userUC.ResendVerification(ctx, mockUser.Email))
+		require.Len(t, outbox.Messages(), 1)
+	})
+}
+
+func TestUserUseCase_VerifyEmail(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userPGRepository := mock.NewMockUserPGRepository(ctrl)
+	userRedisRepository := mock.NewMockUserRedisRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{Server: config.ServerConfig{JwtSecretKey: "secret123"}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	userUC := NewUserUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), userPGRepository, userRedisRepository)
+
+	ctx := context.Background()
+
+	verifiedAt := time.Now()
+	verifiedUser := &models.User{UserID: uuid.New(), Password: "123456", EmailVerifiedAt: &verifiedAt}
+
+	userRedisRepository.EXPECT().TakeVerificationTokenCtx(gomock.Any(), "token").Return(verifiedUser.UserID, nil)
+	userPGRepository.EXPECT().VerifyEmailById(gomock.Any(), verifiedUser.UserID).Return(verifiedUser, nil)
+	userRedisRepository.EXPECT().SetUserCtx(gomock.Any(), verifiedUser.UserID.String(), userByIdCacheDuration, verifiedUser).Return(nil)
+
+	user, err := userUC.VerifyEmail(ctx, "token")
+	require.NoError(t, err)
+	require.True(t, user.IsEmailVerified())
+	require.Empty(t, user.Password)
+
+	userRedisRepository.EXPECT().TakeVerificationTokenCtx(gomock.Any(), "token").Return(uuid.Nil, redis.Nil)
+
+	_, err = userUC.VerifyEmail(ctx, "token")
+	require.ErrorIs(t, err, grpc_errors.ErrInvalidToken)
+}
+
+func TestUserUseCase_ForgotPassword(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userPGRepository := mock.NewMockUserPGRepository(ctrl)
+	userRedisRepository := mock.NewMockUserRedisRepository(ctrl)
+	outbox := mailer.NewOutbox("")
+
+	cfg := &config.Config{
+		Server:        config.ServerConfig{JwtSecretKey: "secret123"},
+		PasswordReset: config.PasswordReset{UserResetURL: "http://localhost/reset"},
+	}
+	apiLogger := logger.NewAppLogger(cfg)
+	apiLogger.InitLogger()
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	userUC := NewUserUseCase(cfg, apiLogger, tokenService, outbox, lockoutMock.NewMockLockoutUseCase(ctrl), userPGRepository, userRedisRepository)
+
+	ctx := context.Background()
+	mockUser := &models.User{UserID: uuid.New(), Email: "email@gmail.com", FirstName: "FirstName"}
+
+	var token string
+	userPGRepository.EXPECT().FindByEmail(gomock.Any(), mockUser.Email).Return(mockUser, nil)
+	userRedisRepository.EXPECT().SetPasswordResetTokenCtx(gomock.Any(), gomock.Any(), defaultPasswordResetExpire, mockUser.UserID).DoAndReturn(func(ctx context.Context, resetToken string, seconds int, userID uuid.UUID) error {
+		token = resetToken
+		return nil
+	})
+
+	require.NoError(t, userUC.ForgotPassword(ctx, mockUser.Email))
+	messages := outbox.Messages()
+	require.Len(t, messages, 1)
+	require.Equal(t, mockUser.Email, messages[0].To)
+	require.Contains(t, messages[0].Body, "http://localhost/reset?token="+token)
+
+	userPGRepository.EXPECT().FindByEmail(gomock.Any(), "unknown@gmail.com").Return(nil, sql.ErrNoRows)
+
+	require.NoError(t, userUC.ForgotPassword(ctx, "unknown@gmail.com"))
+	require.Len(t, outbox.Messages(), 1)
+
+	userPGRepository.EXPECT().FindByEmail(gomock.Any(), mockUser.Email).Return(mockUser, nil)
+	userRedisRepository.EXPECT().SetPasswordResetTokenCtx(gomock.Any(), gomock.Any(), defaultPasswordResetExpire, mockUser.UserID).Return(errors.New("redis down"))
+
+	require.NoError(t, userUC.ForgotPassword(ctx, mockUser.Email), "failed sends look like unknown emails")
+	require.Len(t, outbox.Messages(), 1)
+}
+
+func TestUserUseCase_ResetPassword(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userPGRepository := mock.NewMockUserPGRepository(ctrl)
+	userRedisRepository := mock.NewMockUserRedisRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{Server: config.ServerConfig{JwtSecretKey: "secret123"}}
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	userUC := NewUserUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), userPGRepository, userRedisRepository)
+
+	ctx := context.Background()
+	mockUser := &models.User{UserID: uuid.New(), Email: "email@gmail.com", Password: "old"}
+
+	userRedisRepository.EXPECT().TakePasswordResetTokenCtx(gomock.Any(), "token").Return(mockUser.UserID, nil)
+	userRedisRepository.EXPECT().DeletePasswordResetTokensCtx(gomock.Any(), mockUser.UserID).Return(nil)
+	userPGRepository.EXPECT().FindById(gomock.Any(), mockUser.UserID).Return(mockUser, nil)
+	userPGRepository.EXPECT().UpdateById(gomock.Any(), mockUser).DoAndReturn(func(ctx context.Context, user *models.User) (*models.User, error) {
+		require.NoError(t, user.ComparePasswords("new-password"))
+		return user, nil
+	})
+	userRedisRepository.EXPECT().SetUserCtx(gomock.Any(), mockUser.UserID.String(), userByIdCacheDuration, mockUser).Return(nil)
+
+	user, err := userUC.ResetPassword(ctx, "token", "new-password")
+	require.NoError(t, err)
+	require.Equal(t, mockUser.UserID, user.UserID)
+	require.Empty(t, user.Password)
+
+	userRedisRepository.EXPECT().TakePasswordResetTokenCtx(gomock.Any(), "token").Return(uuid.Nil, redis.Nil)
+
+	_, err = userUC.ResetPassword(ctx, "token", "new-password")
+	require.ErrorIs(t, err, grpc_errors.ErrInvalidToken)
 }
 
 func TestUserUseCase_FindByEmail(t *testing.T) {
@@ -70,7 +340,10 @@ func TestUserUseCase_FindByEmail(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{Server: config.ServerConfig{JwtSecretKey: "secret123"}}
-	userUC := NewUserUseCase(cfg, apiLogger, userPGRepository, userRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	userUC := NewUserUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), userPGRepository, userRedisRepository)
 
 	userID := uuid.New()
 	mockUser := &models.User{
@@ -101,10 +374,14 @@ func TestUserUseCase_Login(t *testing.T) {
 
 	userPGRepository := mock.NewMockUserPGRepository(ctrl)
 	userRedisRepository := mock.NewMockUserRedisRepository(ctrl)
+	lockoutUC := lockoutMock.NewMockLockoutUseCase(ctrl)
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{Server: config.ServerConfig{JwtSecretKey: "secret123"}}
-	userUC := NewUserUseCase(cfg, apiLogger, userPGRepository, userRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	userUC := NewUserUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutUC, userPGRepository, userRedisRepository)
 
 	userID := uuid.New()
 	mockUser := &models.User{
@@ -118,10 +395,71 @@ func TestUserUseCase_Login(t *testing.T) {
 	}
 
 	ctx := context.Background()
+	ip := "127.0.0.1"
 
-	userPGRepository.EXPECT().FindByEmail(gomock.Any(), mockUser.Email).Return(mockUser, nil)
-	_, err := userUC.Login(ctx, mockUser.Email, mockUser.Password)
-	require.NotNil(t, err)
+	t.Run("Wrong password", func(t *testing.T) {
+		lockoutUC.EXPECT().Reserve(gomock.Any(), lockout.RealmUser, mockUser.Email, ip).Return(nil)
+		userPGRepository.EXPECT().FindByEmail(gomock.Any(), mockUser.Email).Return(mockUser, nil)
+		lockoutUC.EXPECT().RegisterFailure(gomock.Any(), lockout.RealmUser, ip).Return(nil)
+
+		_, err := userUC.Login(ctx, mockUser.Email, mockUser.Password, ip)
+		require.NotNil(t, err)
+	})
+
+	t.Run("Unknown email", func(t *testing.T) {
+		lockoutUC.EXPECT().Reserve(gomock.Any(), lockout.RealmUser, "unknown@gmail.com", ip).Return(nil)
+		userPGRepository.EXPECT().FindByEmail(gomock.Any(), "unknown@gmail.com").Return(nil, sql.ErrNoRows)
+		lockoutUC.EXPECT().RegisterFailure(gomock.Any(), lockout.RealmUser, ip).Return(nil)
+
+		_, err := userUC.Login(ctx, "unknown@gmail.com", "123456", ip)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		lockoutUC.EXPECT().Reserve(gomock.Any(), lockout.RealmUser, mockUser.Email, ip).Return(&grpc_errors.RetryError{Err: grpc_errors.ErrAccountLocked, RetryAfter: time.Minute})
+
+		_, err := userUC.Login(ctx, mockUser.Email, mockUser.Password, ip)
+		require.ErrorIs(t, err, grpc_errors.ErrAccountLocked)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		hashed := *mockUser
+		require.NoError(t, hashed.HashPassword())
+
+		lockoutUC.EXPECT().Reserve(gomock.Any(), lockout.RealmUser, mockUser.Email, ip).Return(nil)
+		userPGRepository.EXPECT().FindByEmail(gomock.Any(), mockUser.Email).Return(&hashed, nil)
+		lockoutUC.EXPECT().RegisterSuccess(gomock.Any(), lockout.RealmUser, mockUser.Email).Return(nil)
+
+		loggedUser, err := userUC.Login(ctx, mockUser.Email, mockUser.Password, ip)
+		require.NoError(t, err)
+		require.Equal(t, userID, loggedUser.UserID)
+	})
+}
+
+func TestUserUseCase_UnlockById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userPGRepository := mock.NewMockUserPGRepository(ctrl)
+	userRedisRepository := mock.NewMockUserRedisRepository(ctrl)
+	lockoutUC := lockoutMock.NewMockLockoutUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{}
+	userUC := NewUserUseCase(cfg, apiLogger, nil, mailer.NewOutbox(""), lockoutUC, userPGRepository, userRedisRepository)
+
+	userID := uuid.New()
+	mockUser := &models.User{UserID: userID, Email: "email@gmail.com"}
+
+	ctx := context.Background()
+
+	userPGRepository.EXPECT().FindById(gomock.Any(), userID).Return(mockUser, nil)
+	lockoutUC.EXPECT().Unlock(gomock.Any(), lockout.RealmUser, mockUser.Email, "127.0.0.1").Return(nil)
+
+	err := userUC.UnlockById(ctx, userID, "127.0.0.1")
+	require.NoError(t, err)
 }
 
 func TestUserUseCase_FindByAll(t *testing.T) {
@@ -135,7 +473,10 @@ func TestUserUseCase_FindByAll(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{}
-	userUC := NewUserUseCase(cfg, apiLogger, userPGRepository, userRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	userUC := NewUserUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), userPGRepository, userRedisRepository)
 
 	userID := uuid.New()
 	mockUser := &models.User{
@@ -169,7 +510,10 @@ func TestUserUseCase_FindById(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{}
-	userUC := NewUserUseCase(cfg, apiLogger, userPGRepository, userRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	userUC := NewUserUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), userPGRepository, userRedisRepository)
 
 	userID := uuid.New()
 	mockUser := &models.User{
@@ -206,7 +550,10 @@ func TestUserUseCase_CachedFindById(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{}
-	userUC := NewUserUseCase(cfg, apiLogger, userPGRepository, userRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	userUC := NewUserUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), userPGRepository, userRedisRepository)
 
 	userID := uuid.New()
 	mockUser := &models.User{
@@ -242,7 +589,10 @@ func TestUserUseCase_UpdateById(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{}
-	userUC := NewUserUseCase(cfg, apiLogger, userPGRepository, userRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	userUC := NewUserUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), userPGRepository, userRedisRepository)
 
 	userID := uuid.New()
 	mockUser := &models.User{
@@ -277,7 +627,10 @@ func TestUserUseCase_DeleteById(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{}
-	userUC := NewUserUseCase(cfg, apiLogger, userPGRepository, userRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	userUC := NewUserUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), userPGRepository, userRedisRepository)
 
 	userID := uuid.New()
 	mockUser := &models.User{
@@ -294,7 +647,7 @@ func TestUserUseCase_DeleteById(t *testing.T) {
 	userPGRepository.EXPECT().DeleteById(gomock.Any(), mockUser.UserID).Return(nil)
 	userRedisRepository.EXPECT().DeleteUserCtx(gomock.Any(), mockUser.UserID.String()).AnyTimes().Return(nil)
 
-	err := userUC.DeleteById(ctx, mockUser.UserID)
+	err = userUC.DeleteById(ctx, mockUser.UserID)
 	require.NoError(t, err)
 
 	userPGRepository.EXPECT().FindById(gomock.Any(), mockUser.UserID).AnyTimes().Return(nil, nil)
@@ -312,7 +665,10 @@ func TestUserUseCase_GenerateTokenPair(t *testing.T) {
 	apiLogger := logger.NewAppLogger(nil)
 
 	cfg := &config.Config{}
-	userUC := NewUserUseCase(cfg, apiLogger, userPGRepository, userRedisRepository)
+	keys, err := jwtkeys.NewKeyringFromConfig(cfg)
+	require.NoError(t, err)
+	tokenService := tokens.NewService(cfg, keys)
+	userUC := NewUserUseCase(cfg, apiLogger, tokenService, mailer.NewOutbox(""), lockoutMock.NewMockLockoutUseCase(ctrl), userPGRepository, userRedisRepository)
 
 	userID := uuid.New()
 	mockUser := &models.User{
@@ -325,8 +681,19 @@ func TestUserUseCase_GenerateTokenPair(t *testing.T) {
 		Password:  "123456",
 	}
 
-	at, rt, err := userUC.GenerateTokenPair(mockUser, mockUser.UserID.String())
+	at, rt, err := userUC.GenerateTokenPair(mockUser, mockUser.UserID.String(), "refresh token id")
 	require.NoError(t, err)
 	require.NotEqual(t, at, "")
 	require.NotEqual(t, rt, "")
+
+	accessToken, err := tokenService.ParseAccessToken(at)
+	require.NoError(t, err)
+	atClaims := accessToken.Claims.(jwt.MapClaims)
+	require.Equal(t, mockUser.UserID.String(), atClaims["sub"])
+	require.Equal(t, mockUser.UserID.String(), atClaims["user_id"])
+	require.Equal(t, "admin", atClaims["role"])
+
+	refreshToken, err := tokenService.ParseRefreshToken(rt)
+	require.NoError(t, err)
+	require.Equal(t, "refresh token id", refreshToken.Claims.(jwt.MapClaims)["jti"])
 }
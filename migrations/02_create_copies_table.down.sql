@@ -0,0 +1,3 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS copy_id;
+DROP TABLE IF EXISTS copies CASCADE;
+DROP TYPE IF EXISTS copy_status;
\ No newline at end of file
@@ -0,0 +1,19 @@
+CREATE TYPE copy_status AS ENUM ('available', 'on_loan', 'lost', 'withdrawn');
+
+DROP TABLE IF EXISTS copies CASCADE;
+CREATE TABLE copies
+(
+    copy_id     UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    barcode     VARCHAR(64) UNIQUE       NOT NULL CHECK ( barcode <> '' ),
+    book_key    VARCHAR(64)              NOT NULL CHECK ( book_key <> '' ),
+    condition   VARCHAR(32)              NOT NULL DEFAULT 'good',
+    location    VARCHAR(128),
+    status      copy_status              NOT NULL DEFAULT 'available',
+
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX copies_book_key_status_idx ON copies (book_key, status);
+
+ALTER TABLE orders ADD COLUMN copy_id UUID REFERENCES copies (copy_id);
@@ -0,0 +1,9 @@
+ALTER TABLE orders ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE orders ALTER COLUMN status TYPE TEXT;
+UPDATE orders SET status = 'accepted' WHERE status NOT IN ('pending', 'accepted');
+
+DROP TYPE IF EXISTS status;
+CREATE TYPE status AS ENUM ('pending', 'accepted');
+
+ALTER TABLE orders ALTER COLUMN status TYPE status USING status::status;
+ALTER TABLE orders ALTER COLUMN status SET DEFAULT 'pending';
\ No newline at end of file
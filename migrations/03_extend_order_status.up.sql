@@ -0,0 +1,6 @@
+ALTER TYPE status ADD VALUE IF NOT EXISTS 'rejected';
+ALTER TYPE status ADD VALUE IF NOT EXISTS 'cancelled';
+ALTER TYPE status ADD VALUE IF NOT EXISTS 'picked_up';
+ALTER TYPE status ADD VALUE IF NOT EXISTS 'returned';
+ALTER TYPE status ADD VALUE IF NOT EXISTS 'overdue';
+ALTER TYPE status ADD VALUE IF NOT EXISTS 'lost';
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS orders_status_due_at_idx;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS returned_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS due_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS picked_up_at;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE orders ADD COLUMN picked_up_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE orders ADD COLUMN due_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE orders ADD COLUMN returned_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX orders_status_due_at_idx ON orders (status, due_at);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS fines CASCADE;
+DROP TYPE IF EXISTS fine_status;
+DROP TYPE IF EXISTS fine_kind;
\ No newline at end of file
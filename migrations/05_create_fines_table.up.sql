@@ -0,0 +1,23 @@
+CREATE TYPE fine_kind AS ENUM ('overdue', 'lost');
+CREATE TYPE fine_status AS ENUM ('outstanding', 'paid', 'waived');
+
+DROP TABLE IF EXISTS fines CASCADE;
+CREATE TABLE fines
+(
+    fine_id      UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    order_id     UUID                     NOT NULL REFERENCES orders (order_id) ON DELETE CASCADE,
+    user_id      UUID                     NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    librarian_id UUID REFERENCES librarians (librarian_id),
+    kind         fine_kind                NOT NULL,
+    amount       BIGINT                   NOT NULL CHECK ( amount > 0 ),
+    status       fine_status              NOT NULL DEFAULT 'outstanding',
+    note         VARCHAR(256),
+    settled_at   TIMESTAMP WITH TIME ZONE,
+
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX fines_order_id_kind_outstanding_idx ON fines (order_id, kind) WHERE status = 'outstanding';
+
+CREATE INDEX fines_user_id_status_idx ON fines (user_id, status);
\ No newline at end of file
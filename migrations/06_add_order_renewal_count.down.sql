@@ -0,0 +1 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS renewal_count;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN renewal_count INT NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS holds CASCADE;
+DROP TYPE IF EXISTS hold_status;
+
+UPDATE copies SET status = 'available' WHERE status = 'on_hold';
+ALTER TABLE copies ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE copies ALTER COLUMN status TYPE TEXT;
+DROP TYPE IF EXISTS copy_status;
+CREATE TYPE copy_status AS ENUM ('available', 'on_loan', 'lost', 'withdrawn');
+ALTER TABLE copies ALTER COLUMN status TYPE copy_status USING status::copy_status;
+ALTER TABLE copies ALTER COLUMN status SET DEFAULT 'available';
\ No newline at end of file
@@ -0,0 +1,20 @@
+ALTER TYPE copy_status ADD VALUE IF NOT EXISTS 'on_hold';
+
+CREATE TYPE hold_status AS ENUM ('waiting', 'ready', 'fulfilled', 'cancelled', 'expired');
+
+DROP TABLE IF EXISTS holds CASCADE;
+CREATE TABLE holds
+(
+    hold_id     UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    user_id     UUID                     NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    book_key    VARCHAR(64)              NOT NULL CHECK ( book_key <> '' ),
+    copy_id     UUID REFERENCES copies (copy_id),
+    status      hold_status              NOT NULL DEFAULT 'waiting',
+    ready_until TIMESTAMP WITH TIME ZONE,
+
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX holds_book_key_status_created_at_idx ON holds (book_key, status, created_at);
+CREATE UNIQUE INDEX holds_user_id_book_key_active_idx ON holds (user_id, book_key) WHERE status IN ('waiting', 'ready');
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS reject_reason;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN reject_reason VARCHAR(250);
\ No newline at end of file
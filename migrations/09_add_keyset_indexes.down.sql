@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS orders_created_at_order_id_idx;
+DROP INDEX IF EXISTS users_created_at_user_id_idx;
+DROP INDEX IF EXISTS librarians_created_at_librarian_id_idx;
\ No newline at end of file
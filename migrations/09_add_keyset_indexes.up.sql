@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS orders_created_at_order_id_idx ON orders (created_at, order_id);
+CREATE INDEX IF NOT EXISTS users_created_at_user_id_idx ON users (created_at, user_id);
+CREATE INDEX IF NOT EXISTS librarians_created_at_librarian_id_idx ON librarians (created_at, librarian_id);
\ No newline at end of file
@@ -20,8 +20,14 @@ const (
 	REPLY    = "REPLY"
 	TIME     = "TIME"
 
-	Page   = "page"
-	Size   = "size"
-	Search = "search"
-	ID     = "id"
+	Page    = "page"
+	Size    = "size"
+	Search  = "search"
+	ID      = "id"
+	Key     = "key"
+	UserID  = "user_id"
+	OrderBy = "orderBy"
+	Cursor  = "cursor"
+
+	Principal = "principal"
 )
@@ -3,21 +3,70 @@ package grpc_errors
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 )
 
 var (
-	ErrNotFound         = errors.New("Not found")
-	ErrNoCtxMetaData    = errors.New("No ctx metadata")
-	ErrInvalidSessionId = errors.New("Invalid session id")
-	ErrEmailExists      = errors.New("Email already exists")
+	ErrNotFound           = errors.New("Not found")
+	ErrNoCtxMetaData      = errors.New("No ctx metadata")
+	ErrInvalidSessionId   = errors.New("Invalid session id")
+	ErrEmailExists        = errors.New("Email already exists")
+	ErrNoCopyAvailable    = errors.New("No copy available")
+	ErrInvalidStatus      = errors.New("Invalid status transition")
+	ErrPermissionDenied   = errors.New("Permission denied")
+	ErrRenewalDenied      = errors.New("Renewal not allowed")
+	ErrHoldExists         = errors.New("Hold already placed")
+	ErrCopyAvailable      = errors.New("Copy available, place an order instead")
+	ErrInvalidOrderBy     = errors.New("Invalid orderBy")
+	ErrInvalidCursor      = errors.New("Invalid cursor")
+	ErrInvalidToken       = errors.New("Invalid token")
+	ErrRefreshTokenReused = errors.New("Refresh token already used")
+	ErrEmailNotVerified   = errors.New("Email not verified")
+	ErrTooManyAttempts    = errors.New("Too many login attempts")
+	ErrAccountLocked      = errors.New("Account temporarily locked")
+	ErrInvalidUUID        = errors.New("Invalid uuid")
+	ErrInvalidBookKey     = errors.New("Invalid book key")
+	ErrCopyInUse          = errors.New("Copy is in use")
 )
 
+// RetryError error that clears by itself after RetryAfter
+type RetryError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%v, retry after %v", e.Err, e.RetryAfter)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// RetryAfterSeconds whole seconds to wait, rounded up
+func (e *RetryError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+// SetRetryAfterHeader send retry-after header when err clears by itself
+func SetRetryAfterHeader(ctx context.Context, err error) {
+	var retryErr *RetryError
+	if errors.As(err, &retryErr) {
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(retryErr.RetryAfterSeconds())))
+	}
+}
+
 // Parse error and get code
 func ParseGRPCErrStatusCode(err error) codes.Code {
 	switch {
@@ -25,14 +74,48 @@ func ParseGRPCErrStatusCode(err error) codes.Code {
 		return codes.NotFound
 	case errors.Is(err, redis.Nil):
 		return codes.NotFound
+	case errors.Is(err, ErrNotFound):
+		return codes.NotFound
 	case errors.Is(err, context.Canceled):
 		return codes.Canceled
 	case errors.Is(err, context.DeadlineExceeded):
 		return codes.DeadlineExceeded
 	case errors.Is(err, ErrEmailExists):
 		return codes.AlreadyExists
+	case errors.Is(err, ErrNoCopyAvailable):
+		return codes.FailedPrecondition
+	case errors.Is(err, ErrInvalidStatus):
+		return codes.FailedPrecondition
+	case errors.Is(err, ErrRenewalDenied):
+		return codes.FailedPrecondition
+	case errors.Is(err, ErrHoldExists):
+		return codes.AlreadyExists
+	case errors.Is(err, ErrCopyAvailable):
+		return codes.FailedPrecondition
+	case errors.Is(err, ErrCopyInUse):
+		return codes.FailedPrecondition
+	case errors.Is(err, ErrPermissionDenied):
+		return codes.PermissionDenied
+	case errors.Is(err, ErrEmailNotVerified):
+		return codes.PermissionDenied
+	case errors.Is(err, ErrInvalidOrderBy):
+		return codes.InvalidArgument
+	case errors.Is(err, ErrInvalidCursor):
+		return codes.InvalidArgument
+	case errors.Is(err, ErrInvalidUUID):
+		return codes.InvalidArgument
+	case errors.Is(err, ErrInvalidBookKey):
+		return codes.InvalidArgument
+	case errors.Is(err, ErrInvalidToken):
+		return codes.Unauthenticated
+	case errors.Is(err, ErrRefreshTokenReused):
+		return codes.Unauthenticated
 	case errors.Is(err, ErrNoCtxMetaData):
 		return codes.Unauthenticated
+	case errors.Is(err, ErrTooManyAttempts):
+		return codes.ResourceExhausted
+	case errors.Is(err, ErrAccountLocked):
+		return codes.ResourceExhausted
 	case errors.Is(err, ErrInvalidSessionId):
 		return codes.PermissionDenied
 	case strings.Contains(err.Error(), "Validate"):
@@ -62,6 +145,10 @@ func MapGRPCErrCodeToHttpStatus(code codes.Code) int {
 		return http.StatusGatewayTimeout
 	case codes.InvalidArgument:
 		return http.StatusBadRequest
+	case codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
 	}
 	return http.StatusInternalServerError
 }
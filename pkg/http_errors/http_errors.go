@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,13 +15,19 @@ import (
 
 	"github.com/go-playground/validator"
 	"github.com/labstack/echo/v4"
+
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
 )
 
 const (
 	ErrBadRequest          = "Bad request"
 	ErrNotFound            = "Not Found"
 	ErrUnauthorized        = "Unauthorized"
+	ErrConflict            = "Conflict"
+	ErrForbidden           = "Forbidden"
 	ErrRequestTimeout      = "Request Timeout"
+	ErrTooManyRequests     = "Too Many Requests"
+	ErrLocked              = "Locked"
 	ErrInvalidEmail        = "Invalid email"
 	ErrInvalidPassword     = "Invalid password"
 	ErrInvalidField        = "Invalid field"
@@ -33,6 +40,7 @@ var (
 	NotFound            = errors.New("Not Found")
 	Unauthorized        = errors.New("Unauthorized")
 	Forbidden           = errors.New("Forbidden")
+	Conflict            = errors.New("Conflict")
 	InternalServerError = errors.New("Internal Server Error")
 )
 
@@ -185,6 +193,34 @@ func ParseErrors(err error, debug bool) RestErr {
 		return NewRestError(http.StatusUnauthorized, ErrUnauthorized, err.Error(), debug)
 	case errors.Is(err, WrongCredentials):
 		return NewRestError(http.StatusUnauthorized, ErrUnauthorized, err.Error(), debug)
+	case errors.Is(err, grpc_errors.ErrNoCopyAvailable):
+		return NewRestError(http.StatusConflict, ErrConflict, err.Error(), debug)
+	case errors.Is(err, grpc_errors.ErrInvalidStatus):
+		return NewRestError(http.StatusConflict, ErrConflict, err.Error(), debug)
+	case errors.Is(err, grpc_errors.ErrRenewalDenied):
+		return NewRestError(http.StatusConflict, ErrConflict, err.Error(), debug)
+	case errors.Is(err, grpc_errors.ErrHoldExists):
+		return NewRestError(http.StatusConflict, ErrConflict, err.Error(), debug)
+	case errors.Is(err, grpc_errors.ErrCopyAvailable):
+		return NewRestError(http.StatusConflict, ErrConflict, err.Error(), debug)
+	case errors.Is(err, grpc_errors.ErrCopyInUse):
+		return NewRestError(http.StatusConflict, ErrConflict, err.Error(), debug)
+	case errors.Is(err, grpc_errors.ErrPermissionDenied):
+		return NewRestError(http.StatusForbidden, ErrForbidden, err.Error(), debug)
+	case errors.Is(err, grpc_errors.ErrEmailNotVerified):
+		return NewRestError(http.StatusForbidden, ErrForbidden, err.Error(), debug)
+	case errors.Is(err, grpc_errors.ErrTooManyAttempts):
+		return NewRestError(http.StatusTooManyRequests, ErrTooManyRequests, err.Error(), debug)
+	case errors.Is(err, grpc_errors.ErrAccountLocked):
+		return NewRestError(http.StatusLocked, ErrLocked, err.Error(), debug)
+	case errors.Is(err, grpc_errors.ErrInvalidOrderBy):
+		return NewRestError(http.StatusBadRequest, ErrBadRequest, err.Error(), debug)
+	case errors.Is(err, grpc_errors.ErrInvalidCursor):
+		return NewRestError(http.StatusBadRequest, ErrBadRequest, err.Error(), debug)
+	case errors.Is(err, grpc_errors.ErrInvalidUUID):
+		return NewRestError(http.StatusBadRequest, ErrBadRequest, err.Error(), debug)
+	case errors.Is(err, grpc_errors.ErrInvalidBookKey):
+		return NewRestError(http.StatusBadRequest, ErrBadRequest, err.Error(), debug)
 	case errors.Is(err, middleware.ErrJWTMissing):
 		return NewRestError(http.StatusUnauthorized, ErrUnauthorized, err.Error(), debug)
 	case strings.Contains(strings.ToLower(err.Error()), "sqlstate"):
@@ -210,6 +246,8 @@ func ParseErrors(err error, debug bool) RestErr {
 		return NewRestError(http.StatusBadRequest, ErrBadRequest, err.Error(), debug)
 	case strings.Contains(strings.ToLower(err.Error()), "no documents in result"):
 		return NewRestError(http.StatusNotFound, ErrNotFound, err.Error(), debug)
+	case strings.Contains(strings.ToLower(err.Error()), "not found"):
+		return NewRestError(http.StatusNotFound, ErrNotFound, err.Error(), debug)
 	default:
 		if restErr, ok := err.(*RestError); ok {
 			return restErr
@@ -239,8 +277,13 @@ func ErrorResponse(err error, debug bool) (int, interface{}) {
 	return ParseErrors(err, debug).Status(), ParseErrors(err, debug)
 }
 
-// ErrorCtxResponse Error response object and status code
+// ErrorCtxResponse Error response object and status code, sets Retry-After of errors that clear by themselves
 func ErrorCtxResponse(ctx echo.Context, err error, debug bool) error {
+	var retryErr *grpc_errors.RetryError
+	if errors.As(err, &retryErr) {
+		ctx.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(retryErr.RetryAfterSeconds()))
+	}
+
 	restErr := ParseErrors(err, debug)
 	return ctx.JSON(restErr.Status(), restErr)
 }
@@ -0,0 +1,88 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	rsaKeyBits = 2048
+)
+
+// Key is a signing key of the keyring, it signs tokens from NotBefore and verifies them until NotAfter
+type Key struct {
+	ID        string
+	Method    jwt.SigningMethod
+	NotBefore time.Time
+	NotAfter  time.Time
+
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// NewKey parses PEM encoded private key of algorithm
+func NewKey(id string, algorithm string, privateKeyPEM []byte) (*Key, error) {
+	switch algorithm {
+	case AlgorithmRS256:
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+		if err != nil {
+			return nil, errors.Wrap(err, "jwt.ParseRSAPrivateKeyFromPEM")
+		}
+		return &Key{ID: id, Method: jwt.SigningMethodRS256, signKey: privateKey, verifyKey: &privateKey.PublicKey}, nil
+	case AlgorithmEdDSA:
+		privateKey, err := jwt.ParseEdPrivateKeyFromPEM(privateKeyPEM)
+		if err != nil {
+			return nil, errors.Wrap(err, "jwt.ParseEdPrivateKeyFromPEM")
+		}
+		edKey, ok := privateKey.(ed25519.PrivateKey)
+		if !ok {
+			return nil, errors.New("jwtkeys.NewKey: not an ed25519 private key")
+		}
+		return &Key{ID: id, Method: jwt.SigningMethodEdDSA, signKey: edKey, verifyKey: edKey.Public()}, nil
+	}
+	return nil, fmt.Errorf("jwtkeys.NewKey: unsupported algorithm %q", algorithm)
+}
+
+// GenerateKey creates a random key of algorithm, tokens signed with it do not survive a restart
+func GenerateKey(id string, algorithm string) (*Key, error) {
+	switch algorithm {
+	case AlgorithmRS256:
+		privateKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, errors.Wrap(err, "rsa.GenerateKey")
+		}
+		return &Key{ID: id, Method: jwt.SigningMethodRS256, signKey: privateKey, verifyKey: &privateKey.PublicKey}, nil
+	case AlgorithmEdDSA:
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "ed25519.GenerateKey")
+		}
+		return &Key{ID: id, Method: jwt.SigningMethodEdDSA, signKey: privateKey, verifyKey: publicKey}, nil
+	}
+	return nil, fmt.Errorf("jwtkeys.GenerateKey: unsupported algorithm %q", algorithm)
+}
+
+// NewHMACKey wraps shared secret, it is never published in JWKS
+func NewHMACKey(id string, secret []byte) *Key {
+	return &Key{ID: id, Method: jwt.SigningMethodHS256, signKey: secret, verifyKey: secret}
+}
+
+// activeAt reports whether key may still verify tokens at t
+func (k *Key) activeAt(t time.Time) bool {
+	return k.NotAfter.IsZero() || t.Before(k.NotAfter)
+}
+
+// signsAt reports whether key may sign tokens at t
+func (k *Key) signsAt(t time.Time) bool {
+	return !t.Before(k.NotBefore) && k.activeAt(t)
+}
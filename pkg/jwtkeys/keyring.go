@@ -0,0 +1,202 @@
+package jwtkeys
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/config"
+)
+
+var (
+	ErrNoSigningKey = errors.New("no active signing key")
+	ErrUnknownKey   = errors.New("unknown signing key")
+	ErrNoPrivateKey = errors.New("no private key")
+)
+
+// Keyring holds keys selected by kid. The newest key whose NotBefore has passed signs,
+// older keys keep verifying until their NotAfter, so keys rotate by adding a successor with a future NotBefore
+type Keyring struct {
+	mu   sync.RWMutex
+	keys []*Key
+	now  func() time.Time
+}
+
+// NewKeyring returns keyring of keys, kids must be unique
+func NewKeyring(keys ...*Key) (*Keyring, error) {
+	k := &Keyring{now: time.Now}
+	for _, key := range keys {
+		if err := k.Add(key); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// NewKeyringFromConfig loads jwt keys from config, every key needs a private key.
+// Without any key the legacy HS256 server secret is used
+func NewKeyringFromConfig(cfg *config.Config) (*Keyring, error) {
+	if len(cfg.Jwt.Keys) == 0 {
+		return NewKeyring(NewHMACKey("", []byte(cfg.Server.JwtSecretKey)))
+	}
+
+	keys := make([]*Key, 0, len(cfg.Jwt.Keys))
+	for _, keyCfg := range cfg.Jwt.Keys {
+		key, err := newKeyFromConfig(keyCfg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "jwt key %s", keyCfg.ID)
+		}
+		keys = append(keys, key)
+	}
+
+	return NewKeyring(keys...)
+}
+
+func newKeyFromConfig(keyCfg config.JwtKey) (key *Key, err error) {
+	privateKeyPEM := []byte(keyCfg.PrivateKey)
+	if keyCfg.PrivateKeyFile != "" {
+		if privateKeyPEM, err = ioutil.ReadFile(keyCfg.PrivateKeyFile); err != nil {
+			return nil, errors.Wrap(err, "ioutil.ReadFile")
+		}
+	}
+
+	// a generated key would differ per process and restart, invalidating tokens signed by any other
+	if len(privateKeyPEM) == 0 {
+		return nil, ErrNoPrivateKey
+	}
+
+	key, err = NewKey(keyCfg.ID, keyCfg.Algorithm, privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyCfg.NotBefore != "" {
+		if key.NotBefore, err = time.Parse(time.RFC3339, keyCfg.NotBefore); err != nil {
+			return nil, errors.Wrap(err, "NotBefore")
+		}
+	}
+	if keyCfg.NotAfter != "" {
+		if key.NotAfter, err = time.Parse(time.RFC3339, keyCfg.NotAfter); err != nil {
+			return nil, errors.Wrap(err, "NotAfter")
+		}
+	}
+
+	return key, nil
+}
+
+// Add key to the keyring and drop expired keys
+func (k *Keyring) Add(key *Key) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.add(key)
+}
+
+// Rotate adds next key, keys signing before it are retired grace after next starts signing,
+// so tokens they issued stay verifiable until they expire
+func (k *Keyring) Rotate(next *Key, grace time.Duration) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if err := k.add(next); err != nil {
+		return err
+	}
+
+	retireAt := next.NotBefore.Add(grace)
+	for _, key := range k.keys {
+		if key == next || key.NotBefore.After(next.NotBefore) {
+			continue
+		}
+		if key.NotAfter.IsZero() || key.NotAfter.After(retireAt) {
+			key.NotAfter = retireAt
+		}
+	}
+
+	return nil
+}
+
+func (k *Keyring) add(key *Key) error {
+	now := k.now()
+	keys := make([]*Key, 0, len(k.keys)+1)
+	for _, existing := range k.keys {
+		if existing.ID == key.ID {
+			return fmt.Errorf("jwtkeys.Keyring.Add: duplicate kid %q", key.ID)
+		}
+		if existing.activeAt(now) {
+			keys = append(keys, existing)
+		}
+	}
+	k.keys = append(keys, key)
+
+	return nil
+}
+
+// SigningKey returns the key that signs new tokens now
+func (k *Keyring) SigningKey() (*Key, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	now := k.now()
+	var signing *Key
+	for _, key := range k.keys {
+		if key.signsAt(now) && (signing == nil || !key.NotBefore.Before(signing.NotBefore)) {
+			signing = key
+		}
+	}
+	if signing == nil {
+		return nil, ErrNoSigningKey
+	}
+	return signing, nil
+}
+
+// Sign claims with the current signing key and put its kid into the header
+func (k *Keyring) Sign(claims jwt.Claims) (string, error) {
+	key, err := k.SigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	return token.SignedString(key.signKey)
+}
+
+// Keyfunc finds verification key by kid, the token algorithm has to match the key
+func (k *Keyring) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	now := k.now()
+	for _, key := range k.keys {
+		if key.ID != kid || !key.activeAt(now) {
+			continue
+		}
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %v for kid %q", token.Header["alg"], kid)
+		}
+		return key.verifyKey, nil
+	}
+
+	return nil, errors.Wrapf(ErrUnknownKey, "kid %q", kid)
+}
+
+// Parse verifies token string against the keyring and returns its claims
+func (k *Keyring) Parse(tokenString string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, k.Keyfunc)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
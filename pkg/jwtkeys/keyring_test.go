@@ -0,0 +1,176 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/config"
+)
+
+func newTestClaims() jwt.MapClaims {
+	return jwt.MapClaims{"session_id": "s1", "exp": time.Now().Add(time.Minute).Unix()}
+}
+
+func TestKeyring_SignAndParse(t *testing.T) {
+	t.Parallel()
+
+	for _, algorithm := range []string{AlgorithmRS256, AlgorithmEdDSA} {
+		key, err := GenerateKey("k-"+algorithm, algorithm)
+		require.NoError(t, err)
+		keys, err := NewKeyring(key)
+		require.NoError(t, err)
+
+		token, err := keys.Sign(newTestClaims())
+		require.NoError(t, err)
+
+		parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+		require.NoError(t, err)
+		require.Equal(t, "k-"+algorithm, parsed.Header["kid"])
+		require.Equal(t, algorithm, parsed.Header["alg"])
+
+		claims, err := keys.Parse(token)
+		require.NoError(t, err)
+		require.Equal(t, "s1", claims["session_id"])
+	}
+}
+
+func TestKeyring_Keyfunc(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateKey("k1", AlgorithmEdDSA)
+	require.NoError(t, err)
+	keys, err := NewKeyring(key)
+	require.NoError(t, err)
+
+	t.Run("Unknown kid", func(t *testing.T) {
+		other, err := GenerateKey("k2", AlgorithmEdDSA)
+		require.NoError(t, err)
+		otherKeys, err := NewKeyring(other)
+		require.NoError(t, err)
+
+		token, err := otherKeys.Sign(newTestClaims())
+		require.NoError(t, err)
+
+		_, err = keys.Parse(token)
+		require.Error(t, err)
+	})
+
+	t.Run("Algorithm confusion", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, newTestClaims())
+		token.Header["kid"] = "k1"
+		signed, err := token.SignedString([]byte(key.verifyKey.(ed25519.PublicKey)))
+		require.NoError(t, err)
+
+		_, err = keys.Parse(signed)
+		require.Error(t, err)
+	})
+}
+
+func TestKeyring_Rotate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	current, err := GenerateKey("k1", AlgorithmEdDSA)
+	require.NoError(t, err)
+	keys, err := NewKeyring(current)
+	require.NoError(t, err)
+	keys.now = func() time.Time { return now }
+
+	issued, err := keys.Sign(newTestClaims())
+	require.NoError(t, err)
+
+	next, err := GenerateKey("k2", AlgorithmEdDSA)
+	require.NoError(t, err)
+	next.NotBefore = now.Add(time.Minute)
+	require.NoError(t, keys.Rotate(next, time.Hour))
+
+	signing, err := keys.SigningKey()
+	require.NoError(t, err)
+	require.Equal(t, "k1", signing.ID)
+	require.Len(t, keys.JWKS().Keys, 2)
+
+	keys.now = func() time.Time { return now.Add(2 * time.Minute) }
+	signing, err = keys.SigningKey()
+	require.NoError(t, err)
+	require.Equal(t, "k2", signing.ID)
+
+	_, err = keys.Parse(issued)
+	require.NoError(t, err)
+
+	keys.now = func() time.Time { return now.Add(2 * time.Hour) }
+	_, err = keys.Parse(issued)
+	require.True(t, errors.Is(err.(*jwt.ValidationError).Inner, ErrUnknownKey))
+	require.Len(t, keys.JWKS().Keys, 1)
+
+	require.Error(t, keys.Add(next))
+}
+
+func TestKeyring_JWKS(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := GenerateKey("rsa", AlgorithmRS256)
+	require.NoError(t, err)
+	edKey, err := GenerateKey("ed", AlgorithmEdDSA)
+	require.NoError(t, err)
+	keys, err := NewKeyring(rsaKey, edKey, NewHMACKey("hmac", []byte("secret")))
+	require.NoError(t, err)
+
+	set := keys.JWKS()
+	require.Len(t, set.Keys, 2)
+	require.Equal(t, JWK{Kty: "RSA", Kid: "rsa", Use: "sig", Alg: AlgorithmRS256, N: set.Keys[0].N, E: "AQAB"}, set.Keys[0])
+	require.Equal(t, "OKP", set.Keys[1].Kty)
+	require.Equal(t, "Ed25519", set.Keys[1].Crv)
+	require.Len(t, set.Keys[1].X, 43)
+}
+
+func TestNewKeyringFromConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Legacy secret", func(t *testing.T) {
+		keys, err := NewKeyringFromConfig(&config.Config{Server: config.ServerConfig{JwtSecretKey: "secret"}})
+		require.NoError(t, err)
+
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, newTestClaims()).SignedString([]byte("secret"))
+		require.NoError(t, err)
+		_, err = keys.Parse(token)
+		require.NoError(t, err)
+		require.Empty(t, keys.JWKS().Keys)
+	})
+
+	t.Run("PEM key", func(t *testing.T) {
+		_, privateKey, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		require.NoError(t, err)
+
+		keys, err := NewKeyringFromConfig(&config.Config{Jwt: config.Jwt{Keys: []config.JwtKey{{
+			ID:         "k1",
+			Algorithm:  AlgorithmEdDSA,
+			PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+			NotAfter:   time.Now().Add(time.Hour).Format(time.RFC3339),
+		}}}})
+		require.NoError(t, err)
+
+		signing, err := keys.SigningKey()
+		require.NoError(t, err)
+		require.Equal(t, privateKey.Public(), signing.verifyKey)
+		require.False(t, signing.NotAfter.IsZero())
+	})
+
+	t.Run("Missing private key", func(t *testing.T) {
+		_, err := NewKeyringFromConfig(&config.Config{Jwt: config.Jwt{Keys: []config.JwtKey{{ID: "k1", Algorithm: AlgorithmEdDSA}}}})
+		require.ErrorIs(t, err, ErrNoPrivateKey)
+	})
+
+	t.Run("Unsupported algorithm", func(t *testing.T) {
+		_, err := NewKeyringFromConfig(&config.Config{Jwt: config.Jwt{Keys: []config.JwtKey{{ID: "k1", Algorithm: "none"}}}})
+		require.Error(t, err)
+	})
+}
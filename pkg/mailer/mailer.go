@@ -0,0 +1,44 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dinorain/pinjembuku/config"
+)
+
+const (
+	ProviderSMTP   = "smtp"
+	ProviderFile   = "file"
+	ProviderMemory = "memory"
+)
+
+// Message plain text email
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer delivers emails
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewMailer returns the mailer selected in config
+func NewMailer(cfg *config.Config) (Mailer, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Mailer.Provider)) {
+	case "", ProviderMemory:
+		return NewOutbox(""), nil
+	case ProviderFile:
+		if cfg.Mailer.OutboxPath == "" {
+			return nil, fmt.Errorf("mailer outbox path required")
+		}
+		return NewOutbox(cfg.Mailer.OutboxPath), nil
+	case ProviderSMTP:
+		return NewSMTPMailer(cfg.Mailer.SmtpHost, cfg.Mailer.SmtpPort, cfg.Mailer.SmtpUsername, cfg.Mailer.SmtpPassword, cfg.Mailer.From), nil
+	}
+
+	return nil, fmt.Errorf("mailer provider invalid: %v", cfg.Mailer.Provider)
+}
@@ -0,0 +1,64 @@
+package mailer
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/config"
+)
+
+func TestOutbox_Send(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	outbox := NewOutbox(path)
+
+	msg := Message{To: "email@gmail.com", Subject: "Subject", Body: "Body"}
+	require.NoError(t, outbox.Send(context.Background(), msg))
+	require.NoError(t, outbox.Send(context.Background(), msg))
+	require.Equal(t, []Message{msg, msg}, outbox.Messages())
+
+	outboxBytes, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(outboxBytes)), "\n")
+	require.Len(t, lines, 2)
+
+	var written Message
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &written))
+	require.Equal(t, msg, written)
+}
+
+func TestSMTPMailer_compose(t *testing.T) {
+	t.Parallel()
+
+	m := NewSMTPMailer("smtp.example.com", 0, "", "", "noreply@example.com")
+	require.Equal(t, "smtp.example.com:587", m.addr)
+
+	composed := string(m.compose(Message{To: "email@gmail.com", Subject: "Subject", Body: "line 1\nline 2"}))
+	require.True(t, strings.HasPrefix(composed, "From: noreply@example.com\r\nTo: email@gmail.com\r\nSubject: Subject\r\n"))
+	require.True(t, strings.HasSuffix(composed, "\r\n\r\nline 1\r\nline 2"))
+}
+
+func TestNewMailer(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMailer(&config.Config{})
+	require.NoError(t, err)
+	require.IsType(t, &Outbox{}, m)
+
+	_, err = NewMailer(&config.Config{Mailer: config.Mailer{Provider: ProviderFile}})
+	require.Error(t, err)
+
+	m, err = NewMailer(&config.Config{Mailer: config.Mailer{Provider: ProviderSMTP, SmtpHost: "localhost", SmtpPort: 25}})
+	require.NoError(t, err)
+	require.IsType(t, &SMTPMailer{}, m)
+
+	_, err = NewMailer(&config.Config{Mailer: config.Mailer{Provider: "pigeon"}})
+	require.Error(t, err)
+}
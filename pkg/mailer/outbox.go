@@ -0,0 +1,70 @@
+package mailer
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Outbox keeps sent emails in memory and, when path is set, appends them to a json lines file
+type Outbox struct {
+	mu       sync.Mutex
+	path     string
+	messages []Message
+}
+
+var _ Mailer = (*Outbox)(nil)
+
+// Outbox constructor, empty path keeps messages in memory only
+func NewOutbox(path string) *Outbox {
+	return &Outbox{path: path}
+}
+
+// Send record email in the outbox
+func (o *Outbox) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.path != "" {
+		if err := o.appendFile(msg); err != nil {
+			return err
+		}
+	}
+	o.messages = append(o.messages, msg)
+
+	return nil
+}
+
+// Messages sent so far, oldest first
+func (o *Outbox) Messages() []Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return append([]Message(nil), o.messages...)
+}
+
+func (o *Outbox) appendFile(msg Message) error {
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "Outbox.json.Marshal")
+	}
+
+	f, err := os.OpenFile(o.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "Outbox.OpenFile")
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(msgBytes, '\n')); err != nil {
+		return errors.Wrap(err, "Outbox.Write")
+	}
+
+	return nil
+}
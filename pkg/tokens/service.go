@@ -0,0 +1,161 @@
+package tokens
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/jwtkeys"
+)
+
+const (
+	TokenUseAccess  = "access"
+	TokenUseRefresh = "refresh"
+
+	defaultIssuer             = "pinjembuku"
+	defaultAudience           = "pinjembuku"
+	defaultAccessTokenExpire  = 15 * 60
+	defaultRefreshTokenExpire = 24 * 60 * 60
+	defaultClockSkew          = 30
+)
+
+// Service issues and validates access and refresh tokens of users and librarians
+type Service struct {
+	keys               *jwtkeys.Keyring
+	issuer             string
+	audience           string
+	accessTokenExpire  int
+	refreshTokenExpire int
+	clockSkew          time.Duration
+	now                func() time.Time
+}
+
+// NewService takes lifetimes, issuer, audience and clock skew from the jwt config
+func NewService(cfg *config.Config, keys *jwtkeys.Keyring) *Service {
+	s := &Service{
+		keys:               keys,
+		issuer:             cfg.Jwt.Issuer,
+		audience:           cfg.Jwt.Audience,
+		accessTokenExpire:  cfg.Jwt.AccessTokenExpire,
+		refreshTokenExpire: cfg.Jwt.RefreshTokenExpire,
+		clockSkew:          time.Duration(cfg.Jwt.ClockSkew) * time.Second,
+		now:                time.Now,
+	}
+	if s.issuer == "" {
+		s.issuer = defaultIssuer
+	}
+	if s.audience == "" {
+		s.audience = defaultAudience
+	}
+	if s.accessTokenExpire <= 0 {
+		s.accessTokenExpire = defaultAccessTokenExpire
+	}
+	if s.refreshTokenExpire <= 0 {
+		s.refreshTokenExpire = defaultRefreshTokenExpire
+	}
+	if cfg.Jwt.ClockSkew <= 0 {
+		s.clockSkew = defaultClockSkew * time.Second
+	}
+	return s
+}
+
+// RefreshTokenExpire is the refresh token lifetime in seconds, sessions and refresh token families live as long
+func (s *Service) RefreshTokenExpire() int {
+	return s.refreshTokenExpire
+}
+
+// GenerateTokenPair signs an access token carrying claims of subject and a refresh token of the session
+func (s *Service) GenerateTokenPair(subject string, sessionID string, refreshTokenID string, claims jwt.MapClaims) (access string, refresh string, err error) {
+	now := s.now()
+
+	accessClaims := s.newClaims(TokenUseAccess, subject, sessionID, uuid.New().String(), now, s.accessTokenExpire)
+	for name, value := range claims {
+		if _, ok := accessClaims[name]; !ok {
+			accessClaims[name] = value
+		}
+	}
+
+	access, err = s.keys.Sign(accessClaims)
+	if err != nil {
+		return "", "", errors.Wrap(err, "keys.Sign")
+	}
+
+	refresh, err = s.keys.Sign(s.newClaims(TokenUseRefresh, subject, sessionID, refreshTokenID, now, s.refreshTokenExpire))
+	if err != nil {
+		return "", "", errors.Wrap(err, "keys.Sign")
+	}
+
+	return access, refresh, nil
+}
+
+// ParseAccessToken verifies signature and standard claims of an access token
+func (s *Service) ParseAccessToken(tokenString string) (*jwt.Token, error) {
+	return s.parse(tokenString, TokenUseAccess)
+}
+
+// ParseRefreshToken verifies signature and standard claims of a refresh token
+func (s *Service) ParseRefreshToken(tokenString string) (*jwt.Token, error) {
+	return s.parse(tokenString, TokenUseRefresh)
+}
+
+func (s *Service) newClaims(tokenUse string, subject string, sessionID string, tokenID string, now time.Time, expire int) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":        s.issuer,
+		"aud":        s.audience,
+		"sub":        subject,
+		"iat":        now.Unix(),
+		"exp":        now.Add(time.Duration(expire) * time.Second).Unix(),
+		"jti":        tokenID,
+		"session_id": sessionID,
+		"token_use":  tokenUse,
+	}
+}
+
+func (s *Service) parse(tokenString string, tokenUse string) (*jwt.Token, error) {
+	parser := &jwt.Parser{SkipClaimsValidation: true}
+	token, err := parser.ParseWithClaims(tokenString, jwt.MapClaims{}, s.keys.Keyfunc)
+	if err != nil {
+		return nil, errors.Wrapf(grpc_errors.ErrInvalidToken, "jwt.Parse: %v", err)
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	if err := s.validate(claims, tokenUse); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (s *Service) validate(claims jwt.MapClaims, tokenUse string) error {
+	now := s.now()
+
+	if !claims.VerifyExpiresAt(now.Add(-s.clockSkew).Unix(), true) {
+		return errors.Wrap(grpc_errors.ErrInvalidToken, "token is expired")
+	}
+	if !claims.VerifyIssuedAt(now.Add(s.clockSkew).Unix(), true) {
+		return errors.Wrap(grpc_errors.ErrInvalidToken, "token used before issued")
+	}
+	if !claims.VerifyNotBefore(now.Add(s.clockSkew).Unix(), false) {
+		return errors.Wrap(grpc_errors.ErrInvalidToken, "token is not valid yet")
+	}
+	if !claims.VerifyIssuer(s.issuer, true) {
+		return errors.Wrap(grpc_errors.ErrInvalidToken, "iss claim")
+	}
+	if !claims.VerifyAudience(s.audience, true) {
+		return errors.Wrap(grpc_errors.ErrInvalidToken, "aud claim")
+	}
+	for _, name := range []string{"sub", "jti", "session_id"} {
+		if value, _ := claims[name].(string); value == "" {
+			return errors.Wrapf(grpc_errors.ErrInvalidToken, "%s claim", name)
+		}
+	}
+	if claims["token_use"] != tokenUse {
+		return errors.Wrapf(grpc_errors.ErrInvalidToken, "token_use claim is not %s", tokenUse)
+	}
+
+	return nil
+}
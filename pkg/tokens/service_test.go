@@ -0,0 +1,95 @@
+package tokens
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/config"
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+	"github.com/dinorain/pinjembuku/pkg/jwtkeys"
+)
+
+func newTestService(t *testing.T, jwtCfg config.Jwt) *Service {
+	key, err := jwtkeys.GenerateKey("k1", jwtkeys.AlgorithmEdDSA)
+	require.NoError(t, err)
+	keys, err := jwtkeys.NewKeyring(key)
+	require.NoError(t, err)
+
+	return NewService(&config.Config{Jwt: jwtCfg}, keys)
+}
+
+func TestService_GenerateTokenPair(t *testing.T) {
+	t.Parallel()
+
+	s := newTestService(t, config.Jwt{Issuer: "issuer", Audience: "audience", AccessTokenExpire: 60, RefreshTokenExpire: 3600})
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	subject := uuid.New().String()
+	access, refresh, err := s.GenerateTokenPair(subject, "s1", "rt-id", jwt.MapClaims{"user_id": subject, "role": "user", "sub": "ignored"})
+	require.NoError(t, err)
+
+	accessToken, err := s.ParseAccessToken(access)
+	require.NoError(t, err)
+	claims := accessToken.Claims.(jwt.MapClaims)
+	require.Equal(t, "issuer", claims["iss"])
+	require.Equal(t, "audience", claims["aud"])
+	require.Equal(t, subject, claims["sub"])
+	require.Equal(t, float64(now.Unix()), claims["iat"])
+	require.Equal(t, float64(now.Add(time.Minute).Unix()), claims["exp"])
+	require.NotEmpty(t, claims["jti"])
+	require.Equal(t, "s1", claims["session_id"])
+	require.Equal(t, "user", claims["role"])
+
+	refreshToken, err := s.ParseRefreshToken(refresh)
+	require.NoError(t, err)
+	claims = refreshToken.Claims.(jwt.MapClaims)
+	require.Equal(t, "rt-id", claims["jti"])
+	require.Equal(t, float64(now.Add(time.Hour).Unix()), claims["exp"])
+	require.Nil(t, claims["role"])
+	require.Equal(t, 3600, s.RefreshTokenExpire())
+
+	_, err = s.ParseAccessToken(refresh)
+	require.True(t, errors.Is(err, grpc_errors.ErrInvalidToken))
+	_, err = s.ParseRefreshToken(access)
+	require.True(t, errors.Is(err, grpc_errors.ErrInvalidToken))
+}
+
+func TestService_Validate(t *testing.T) {
+	t.Parallel()
+
+	s := newTestService(t, config.Jwt{AccessTokenExpire: 60, ClockSkew: 10})
+	now := time.Now()
+
+	access, _, err := s.GenerateTokenPair(uuid.New().String(), "s1", "rt-id", nil)
+	require.NoError(t, err)
+
+	s.now = func() time.Time { return now.Add(65 * time.Second) }
+	_, err = s.ParseAccessToken(access)
+	require.NoError(t, err, "expired within clock skew")
+
+	s.now = func() time.Time { return now.Add(2 * time.Minute) }
+	_, err = s.ParseAccessToken(access)
+	require.True(t, errors.Is(err, grpc_errors.ErrInvalidToken))
+
+	s.now = func() time.Time { return now.Add(-time.Minute) }
+	_, err = s.ParseAccessToken(access)
+	require.True(t, errors.Is(err, grpc_errors.ErrInvalidToken), "issued in the future")
+
+	s.now = time.Now
+	for _, jwtCfg := range []config.Jwt{{Issuer: "other"}, {Audience: "other"}} {
+		other := NewService(&config.Config{Jwt: jwtCfg}, s.keys)
+		_, err = other.ParseAccessToken(access)
+		require.True(t, errors.Is(err, grpc_errors.ErrInvalidToken))
+	}
+
+	bare, err := s.keys.Sign(jwt.MapClaims{"exp": now.Add(time.Minute).Unix(), "iat": now.Unix()})
+	require.NoError(t, err)
+	_, err = s.ParseAccessToken(bare)
+	require.True(t, errors.Is(err, grpc_errors.ErrInvalidToken), "missing standard claims")
+}
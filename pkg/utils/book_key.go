@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+)
+
+var bookKeyRegex = regexp.MustCompile(`^(?:/?WORKS/)?(OL[0-9]+W)$`)
+
+// NormalizeBookKey normalize OpenLibrary work key to its /works/OL...W form, accepting the bare id and any letter case.
+// Other input wraps grpc_errors.ErrInvalidBookKey
+func NormalizeBookKey(key string) (string, error) {
+	match := bookKeyRegex.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(key)))
+	if match == nil {
+		return "", errors.Wrapf(grpc_errors.ErrInvalidBookKey, "%q", key)
+	}
+	return "/works/" + match[1], nil
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+)
+
+func TestNormalizeBookKey(t *testing.T) {
+	t.Parallel()
+
+	for _, key := range []string{"/works/OL66554W", " works/OL66554W ", "OL66554W", "ol66554w", "/WORKS/ol66554W"} {
+		normalized, err := NormalizeBookKey(key)
+		require.NoError(t, err, key)
+		require.Equal(t, "/works/OL66554W", normalized, key)
+	}
+
+	for _, key := range []string{"", "/books/OL7353617M", "/works/OL66554", "/works/OL66554W/editions", "Pride and Prejudice"} {
+		_, err := NormalizeBookKey(key)
+		require.ErrorIs(t, err, grpc_errors.ErrInvalidBookKey, key)
+	}
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+)
+
+// KeysetSort sort of lists paged by Cursor, newest first with id breaking ties
+const KeysetSort = "created_at:desc,id:desc"
+
+// OrderBySort sort of lists ordered by orderBy ("column" or "column:asc|desc"), id breaks ties as in KeysetSort
+func OrderBySort(orderBy string) string {
+	if orderBy == "" {
+		return KeysetSort
+	}
+
+	field, direction := orderBy, "asc"
+	if i := strings.Index(orderBy, ":"); i >= 0 {
+		field, direction = orderBy[:i], strings.ToLower(orderBy[i+1:])
+	}
+	return field + ":" + direction + ",id:desc"
+}
+
+// Cursor position of the last row of a page in (created_at, id) order
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// NewCursor Cursor constructor
+func NewCursor(createdAt time.Time, id uuid.UUID) *Cursor {
+	return &Cursor{CreatedAt: createdAt, ID: id}
+}
+
+// Encode opaque url safe representation of cursor
+func (c *Cursor) Encode() string {
+	cursorBytes, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(cursorBytes)
+}
+
+// DecodeCursor parse cursor produced by Encode
+func DecodeCursor(encoded string) (*Cursor, error) {
+	cursorBytes, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.Wrap(grpc_errors.ErrInvalidCursor, err.Error())
+	}
+
+	cursor := &Cursor{}
+	if err := json.Unmarshal(cursorBytes, cursor); err != nil {
+		return nil, errors.Wrap(grpc_errors.ErrInvalidCursor, err.Error())
+	}
+	if cursor.ID == uuid.Nil || cursor.CreatedAt.IsZero() {
+		return nil, grpc_errors.ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
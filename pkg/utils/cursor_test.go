@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+)
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+
+	cursor := NewCursor(time.Now(), uuid.New())
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	require.NoError(t, err)
+	require.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	require.Equal(t, cursor.ID, decoded.ID)
+
+	for _, encoded := range []string{"not base64!", "bm90IGpzb24", "e30"} {
+		_, err := DecodeCursor(encoded)
+		require.True(t, errors.Is(err, grpc_errors.ErrInvalidCursor), encoded)
+	}
+}
+
+func TestOrderBySort(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, KeysetSort, OrderBySort(""))
+	require.Equal(t, "due_at:asc,id:desc", OrderBySort("due_at"))
+	require.Equal(t, "status:desc,id:desc", OrderBySort("status:DESC"))
+}
+
+func TestNewKeysetPaginationMetaDto(t *testing.T) {
+	t.Parallel()
+
+	last := NewCursor(time.Now(), uuid.New())
+
+	t.Run("Offset mode", func(t *testing.T) {
+		meta := NewKeysetPaginationMetaDto(NewPaginationQuery(10, 2), 25, 10, last)
+		require.Equal(t, KeysetSort, meta.Sort)
+		require.Equal(t, 10, meta.Offset)
+		require.Equal(t, 3, meta.TotalPages)
+		require.True(t, meta.HasMore)
+		require.Equal(t, last.Encode(), meta.NextCursor)
+	})
+
+	t.Run("Cursor mode", func(t *testing.T) {
+		pagination := NewPaginationQuery(10, 1)
+		require.NoError(t, pagination.SetCursor(last.Encode()))
+
+		meta := NewKeysetPaginationMetaDto(pagination, 25, 5, last)
+		require.Zero(t, meta.Offset)
+		require.False(t, meta.HasMore)
+		require.Empty(t, meta.NextCursor)
+	})
+}
@@ -1,7 +1,43 @@
 package utils
 
 type PaginationMetaDto struct {
-	Limit  int `json:"limit"`
-	Offset int `json:"offset"`
-	Page   int `json:"page"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	Page       int    `json:"page"`
+	TotalCount int    `json:"total_count"`
+	TotalPages int    `json:"total_pages"`
+	HasMore    bool   `json:"has_more"`
+	Sort       string `json:"sort,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// NewPaginationMetaDto build list meta for pagination out of totalCount matching rows
+func NewPaginationMetaDto(pagination *Pagination, totalCount int) PaginationMetaDto {
+	return PaginationMetaDto{
+		Limit:      pagination.GetLimit(),
+		Offset:     pagination.GetOffset(),
+		Page:       pagination.GetPage(),
+		TotalCount: totalCount,
+		TotalPages: pagination.GetTotalPages(totalCount),
+		HasMore:    pagination.GetHasMore(totalCount),
+	}
+}
+
+// NewKeysetPaginationMetaDto build list meta for lists in KeysetSort order, last being the cursor of the final row of the page.
+// next_cursor is set whenever the page is full, so clients may switch from offset to cursor mode at any page
+func NewKeysetPaginationMetaDto(pagination *Pagination, totalCount int, pageLen int, last *Cursor) PaginationMetaDto {
+	meta := NewPaginationMetaDto(pagination, totalCount)
+	meta.Sort = KeysetSort
+
+	full := last != nil && pageLen >= pagination.GetLimit()
+	if full {
+		meta.NextCursor = last.Encode()
+	}
+	if pagination.GetCursor() != nil {
+		meta.Offset = 0
+		meta.Page = 0
+		meta.HasMore = full
+	}
+
+	return meta
 }
@@ -12,9 +12,10 @@ const (
 
 // Pagination query params
 type Pagination struct {
-	Size    int    `json:"size,omitempty"`
-	Page    int    `json:"page,omitempty"`
-	OrderBy string `json:"orderBy,omitempty"`
+	Size    int     `json:"size,omitempty"`
+	Page    int     `json:"page,omitempty"`
+	OrderBy string  `json:"orderBy,omitempty"`
+	Cursor  *Cursor `json:"cursor,omitempty"`
 }
 
 // NewPaginationQuery Pagination query constructor
@@ -34,6 +35,19 @@ func NewPaginationFromQueryParams(size string, page string) *Pagination {
 	return p
 }
 
+// NewPaginationFromRequest Pagination from numeric request fields, non positive values fall back to defaults
+func NewPaginationFromRequest(size int64, page int64) *Pagination {
+	p := &Pagination{Size: defaultSize, Page: 1}
+	if size > 0 {
+		p.Size = int(size)
+	}
+	if page > 0 {
+		p.Page = int(page)
+	}
+
+	return p
+}
+
 // SetSize Set page size
 func (q *Pagination) SetSize(sizeQuery string) error {
 	if sizeQuery == "" {
@@ -69,6 +83,21 @@ func (q *Pagination) SetOrderBy(orderByQuery string) {
 	q.OrderBy = orderByQuery
 }
 
+// SetCursor Set keyset cursor, empty cursor keeps offset mode
+func (q *Pagination) SetCursor(cursorQuery string) error {
+	if cursorQuery == "" {
+		q.Cursor = nil
+		return nil
+	}
+	cursor, err := DecodeCursor(cursorQuery)
+	if err != nil {
+		return err
+	}
+	q.Cursor = cursor
+
+	return nil
+}
+
 // GetOffset Get offset
 func (q *Pagination) GetOffset() int {
 	if q.Page == 0 {
@@ -87,6 +116,11 @@ func (q *Pagination) GetOrderBy() string {
 	return q.OrderBy
 }
 
+// GetCursor Get cursor, nil in offset mode
+func (q *Pagination) GetCursor() *Cursor {
+	return q.Cursor
+}
+
 // GetPage Get OrderBy
 func (q *Pagination) GetPage() int {
 	return q.Page
@@ -104,11 +138,14 @@ func (q *Pagination) GetQueryString() string {
 
 // GetTotalPages Get total pages int
 func (q *Pagination) GetTotalPages(totalCount int) int {
+	if q.GetSize() <= 0 {
+		return 0
+	}
 	d := float64(totalCount) / float64(q.GetSize())
 	return int(math.Ceil(d))
 }
 
 // GetHasMore Get has more
 func (q *Pagination) GetHasMore(totalCount int) bool {
-	return q.GetPage() < totalCount/q.GetSize()
+	return q.GetOffset()+q.GetSize() < totalCount
 }
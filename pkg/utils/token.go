@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// NewOneTimeToken random url safe token for links sent by email
+func NewOneTimeToken() (string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", errors.Wrap(err, "rand.Read")
+	}
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
+
+// TokenLink rawURL with token added to its query
+func TokenLink(rawURL string, token string) (string, error) {
+	link, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errors.Wrap(err, "url.Parse")
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOneTimeToken(t *testing.T) {
+	t.Parallel()
+
+	token, err := NewOneTimeToken()
+	require.NoError(t, err)
+	require.Len(t, token, 43)
+
+	other, err := NewOneTimeToken()
+	require.NoError(t, err)
+	require.NotEqual(t, token, other)
+}
+
+func TestTokenLink(t *testing.T) {
+	t.Parallel()
+
+	link, err := TokenLink("http://localhost/verify?lang=en", "a-b_c")
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost/verify?lang=en&token=a-b_c", link)
+
+	_, err = TokenLink("://", "token")
+	require.Error(t, err)
+}
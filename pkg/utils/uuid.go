@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/pinjembuku/pkg/grpc_errors"
+)
+
+// ParseUUID parse uuid, malformed input wraps grpc_errors.ErrInvalidUUID
+func ParseUUID(s string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(grpc_errors.ErrInvalidUUID, err.Error())
+	}
+	return parsed, nil
+}